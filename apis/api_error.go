@@ -64,6 +64,15 @@ func NewUnauthorizedError(message string, data any) *ApiError {
 	return NewApiError(http.StatusUnauthorized, message, data)
 }
 
+// NewPreconditionFailedError creates and returns 412 `ApiError`.
+func NewPreconditionFailedError(message string, data any) *ApiError {
+	if message == "" {
+		message = "The resource was modified since it was last fetched."
+	}
+
+	return NewApiError(http.StatusPreconditionFailed, message, data)
+}
+
 // NewApiError creates and returns new normalized `ApiError` instance.
 func NewApiError(status int, message string, data any) *ApiError {
 	return &ApiError{
@@ -160,3 +160,26 @@ func TestNewUnauthorizedError(t *testing.T) {
 		}
 	}
 }
+
+func TestNewPreconditionFailedError(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		message  string
+		data     any
+		expected string
+	}{
+		{"", nil, `{"code":412,"message":"The resource was modified since it was last fetched.","data":{}}`},
+		{"demo", "rawData_test", `{"code":412,"message":"Demo.","data":{}}`},
+		{"demo", validation.Errors{"err1": validation.NewError("test_code", "test_message")}, `{"code":412,"message":"Demo.","data":{"err1":{"code":"test_code","message":"Test_message."}}}`},
+	}
+
+	for i, scenario := range scenarios {
+		e := apis.NewPreconditionFailedError(scenario.message, scenario.data)
+		result, _ := json.Marshal(e)
+
+		if string(result) != scenario.expected {
+			t.Errorf("(%d) Expected \n%v, \ngot \n%v", i, scenario.expected, string(result))
+		}
+	}
+}
@@ -57,6 +57,7 @@ func InitApi(app core.App) (*echo.Echo, error) {
 			return next(c)
 		}
 	})
+	e.Use(defaultRateLimit(app))
 
 	// custom error handler
 	e.HTTPErrorHandler = func(c echo.Context, err error) {
@@ -121,6 +122,13 @@ func InitApi(app core.App) (*echo.Echo, error) {
 	bindAdminApi(app, api)
 	bindCollectionApi(app, api)
 	bindRecordCrudApi(app, api)
+	bindRecordAggregateApi(app, api)
+	bindRecordExportApi(app, api)
+	bindRecordBatchApi(app, api)
+	bindRecordBulkApi(app, api)
+	bindRecordImportApi(app, api)
+	bindRecordTrashApi(app, api)
+	bindRecordRevisionApi(app, api)
 	bindRecordAuthApi(app, api)
 	bindFileApi(app, api)
 	bindRealtimeApi(app, api)
@@ -0,0 +1,249 @@
+package apis
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+)
+
+// rateLimiterStoreKey is the app store key of the shared rate limiter.
+const rateLimiterStoreKey = "@rateLimiter"
+
+var rateLimiterMux sync.Mutex
+
+// rateLimitCollectionActions maps the default collection routes to their rate limit actions.
+//
+// Routes that could either create or update records (eg. upsert)
+// are counted against each of their actions rules.
+//
+// Note that the batch route is not listed here because
+// each of its operations is rate limited separately.
+var rateLimitCollectionActions = map[string][]string{
+	"GET /api/collections/:collection/records":                                  {settings.RateLimitActionList},
+	"GET /api/collections/:collection/records/:id":                              {settings.RateLimitActionView},
+	"POST /api/collections/:collection/records":                                 {settings.RateLimitActionCreate},
+	"PUT /api/collections/:collection/records":                                  {settings.RateLimitActionCreate, settings.RateLimitActionUpdate},
+	"PATCH /api/collections/:collection/records/:id":                            {settings.RateLimitActionUpdate},
+	"DELETE /api/collections/:collection/records/:id":                           {settings.RateLimitActionDelete},
+	"PATCH /api/collections/:collection/bulk":                                   {settings.RateLimitActionUpdate},
+	"DELETE /api/collections/:collection/bulk":                                  {settings.RateLimitActionDelete},
+	"GET /api/collections/:collection/aggregate":                                {settings.RateLimitActionList},
+	"GET /api/collections/:collection/export":                                   {settings.RateLimitActionList},
+	"POST /api/collections/:collection/import":                                  {settings.RateLimitActionCreate, settings.RateLimitActionUpdate},
+	"GET /api/collections/:collection/trash":                                    {settings.RateLimitActionList},
+	"POST /api/collections/:collection/trash/:id/restore":                       {settings.RateLimitActionUpdate},
+	"DELETE /api/collections/:collection/trash/:id":                             {settings.RateLimitActionDelete},
+	"GET /api/collections/:collection/records/:id/revisions":                    {settings.RateLimitActionView},
+	"GET /api/collections/:collection/records/:id/revisions/diff":               {settings.RateLimitActionView},
+	"POST /api/collections/:collection/records/:id/revisions/:revision/restore": {settings.RateLimitActionUpdate},
+	"POST /api/collections/:collection/auth-refresh":                            {settings.RateLimitActionAuth},
+	"POST /api/collections/:collection/auth-with-oauth2":                        {settings.RateLimitActionAuth},
+	"POST /api/collections/:collection/auth-with-password":                      {settings.RateLimitActionAuth},
+	"POST /api/collections/:collection/request-password-reset":                  {settings.RateLimitActionAuth},
+	"POST /api/collections/:collection/confirm-password-reset":                  {settings.RateLimitActionAuth},
+	"POST /api/collections/:collection/request-verification":                    {settings.RateLimitActionAuth},
+	"POST /api/collections/:collection/confirm-verification":                    {settings.RateLimitActionAuth},
+	"POST /api/collections/:collection/request-email-change":                    {settings.RateLimitActionAuth},
+	"POST /api/collections/:collection/confirm-email-change":                    {settings.RateLimitActionAuth},
+}
+
+// RateLimit middleware limits the requests rate based on the first
+// app settings rate limit rule matching one of the provided labels
+// (usually custom tags, eg. apis.RateLimit(app, "hello")).
+//
+// The limit is tracked separately for each admin, auth record or guest IP.
+//
+// The middleware does nothing if the app settings rate limits are disabled
+// or there is no matching rule for the current request audience.
+func RateLimit(app core.App, labels ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := checkRateLimit(app, c, labels); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// defaultRateLimit middleware applies the app settings rate limit rules
+// for the default collection actions and the request path.
+//
+// This middleware is expected to be already registered by default for all routes.
+func defaultRateLimit(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().RateLimits
+			if !config.Enabled {
+				return next(c)
+			}
+
+			pathLabels := pathRateLimitLabels(config.Rules, c.Request())
+
+			actions := rateLimitCollectionActions[c.Request().Method+" "+c.Path()]
+			if len(actions) == 0 {
+				if err := checkRateLimit(app, c, pathLabels); err != nil {
+					return err
+				}
+
+				return next(c)
+			}
+
+			// normalize the collection identifier since it could be also an id
+			name := c.PathParam("collection")
+			if collection, err := app.Dao().FindCollectionByNameOrId(name); err == nil {
+				name = collection.Name
+			}
+
+			for i, action := range actions {
+				labels := collectionRateLimitLabels(name, action)
+
+				// the path rules are counted only once per request
+				if i == 0 {
+					labels = append(labels, pathLabels...)
+				}
+
+				if err := checkRateLimit(app, c, labels); err != nil {
+					return err
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// checkRateLimit registers a new request hit for the first rule matching
+// the labels and returns a 429 error if the rule limit is exceeded.
+func checkRateLimit(app core.App, c echo.Context, labels []string) error {
+	config := app.Settings().RateLimits
+	if !config.Enabled || len(labels) == 0 {
+		return nil
+	}
+
+	audience, requester := rateLimitRequester(c, config.TrustProxy)
+
+	rule, ok := config.FindRule(labels, audience)
+	if !ok {
+		return nil // no matching rule
+	}
+
+	key := rule.Label + "|" + rule.Audience + "|" + requester
+
+	allowed, retryAfter := rateLimiter(app).Allow(key, rule.MaxRequests, rule.DurationTime())
+	if !allowed {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+		return NewApiError(http.StatusTooManyRequests, "Too many requests.", nil)
+	}
+
+	return nil
+}
+
+// rateLimiter returns the app shared rate limiter store
+// (it is created on first use).
+func rateLimiter(app core.App) *ratelimit.SlidingWindow {
+	rateLimiterMux.Lock()
+	defer rateLimiterMux.Unlock()
+
+	limiter, _ := app.Store().Get(rateLimiterStoreKey).(*ratelimit.SlidingWindow)
+	if limiter == nil {
+		limiter = ratelimit.NewSlidingWindow()
+		app.Store().Set(rateLimiterStoreKey, limiter)
+	}
+
+	return limiter
+}
+
+// rateLimitRequester returns the audience and the unique
+// rate limit identifier of the current request's requester.
+func rateLimitRequester(c echo.Context, trustProxy bool) (string, string) {
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		return settings.RateLimitAudienceAdmin, "admin:" + admin.Id
+	}
+
+	if record, _ := c.Get(ContextAuthRecordKey).(*models.Record); record != nil {
+		return settings.RateLimitAudienceAuth, "record:" + record.Collection().Id + ":" + record.Id
+	}
+
+	ip, _, _ := net.SplitHostPort(c.Request().RemoteAddr)
+	if trustProxy {
+		ip = realUserIp(c.Request(), ip)
+	}
+
+	return settings.RateLimitAudienceGuest, "ip:" + ip
+}
+
+// collectionRateLimitLabels returns the tag labels
+// of the specified collection name and action.
+func collectionRateLimitLabels(collectionName string, action string) []string {
+	return []string{collectionName + ":" + action, "*:" + action}
+}
+
+// pathRateLimitLabels returns the path rules labels matching
+// the request sorted by their specificity (longest path first).
+func pathRateLimitLabels(rules []settings.RateLimitRule, r *http.Request) []string {
+	type candidate struct {
+		label     string
+		path      string
+		hasMethod bool
+	}
+
+	candidates := []candidate{}
+	existing := map[string]struct{}{}
+
+	for _, rule := range rules {
+		if !rule.IsPathLabel() {
+			continue
+		}
+
+		if _, ok := existing[rule.Label]; ok {
+			continue // already added
+		}
+
+		method, path, hasMethod := strings.Cut(rule.Label, " ")
+		if !hasMethod {
+			path = rule.Label
+		}
+
+		if hasMethod && method != r.Method {
+			continue
+		}
+
+		if strings.HasSuffix(path, "/") {
+			if !strings.HasPrefix(r.URL.Path+"/", path) {
+				continue
+			}
+		} else if r.URL.Path != path {
+			continue
+		}
+
+		existing[rule.Label] = struct{}{}
+		candidates = append(candidates, candidate{rule.Label, path, hasMethod})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if len(candidates[i].path) != len(candidates[j].path) {
+			return len(candidates[i].path) > len(candidates[j].path)
+		}
+		return candidates[i].hasMethod && !candidates[j].hasMethod
+	})
+
+	labels := make([]string, len(candidates))
+	for i, c := range candidates {
+		labels[i] = c.label
+	}
+
+	return labels
+}
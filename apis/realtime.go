@@ -293,11 +293,12 @@ func (api *realtimeApi) bindEvents() {
 		return nil
 	})
 
-	api.app.OnModelAfterUpdate().PreAdd(func(e *core.ModelEvent) error {
-		if record := api.resolveRecord(e.Model); record != nil {
-			if err := api.broadcastRecord("update", record, false); err != nil {
+	// trashing a soft delete collection record is broadcasted as "delete"
+	api.app.OnModelBeforeUpdate().Add(func(e *core.ModelEvent) error {
+		if record := api.resolveRecord(e.Model); record != nil && record.IsTrashed() {
+			if err := api.broadcastRecord("delete", record, true); err != nil {
 				api.app.Logger().Debug(
-					"Failed to broadcast record update",
+					"Failed to dry cache record trash",
 					slog.String("id", record.Id),
 					slog.String("collectionName", record.Collection().Name),
 					slog.String("error", err.Error()),
@@ -307,6 +308,35 @@ func (api *realtimeApi) bindEvents() {
 		return nil
 	})
 
+	api.app.OnModelAfterUpdate().PreAdd(func(e *core.ModelEvent) error {
+		record := api.resolveRecord(e.Model)
+		if record == nil {
+			return nil
+		}
+
+		var err error
+		switch {
+		case record.IsTrashed():
+			err = api.broadcastDryCachedRecord("delete", record)
+		case record.OriginalCopy().IsTrashed():
+			// restored from the trash
+			err = api.broadcastRecord("create", record, false)
+		default:
+			err = api.broadcastRecord("update", record, false)
+		}
+
+		if err != nil {
+			api.app.Logger().Debug(
+				"Failed to broadcast record update",
+				slog.String("id", record.Id),
+				slog.String("collectionName", record.Collection().Name),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return nil
+	})
+
 	api.app.OnModelBeforeDelete().Add(func(e *core.ModelEvent) error {
 		if record := api.resolveRecord(e.Model); record != nil {
 			if err := api.broadcastRecord("delete", record, true); err != nil {
@@ -435,6 +465,17 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record, dr
 					}
 				}
 
+				// hide the fields that the client is not allowed to view
+				if err := api.app.Dao().HideRestrictedRecordsFields([]*models.Record{cleanRecord}, requestInfo); err != nil {
+					api.app.Logger().Debug(
+						"[broadcastRecord] fields view rules error",
+						slog.String("id", cleanRecord.Id),
+						slog.String("collectionName", cleanRecord.Collection().Name),
+						slog.String("sub", sub),
+						slog.String("error", err.Error()),
+					)
+				}
+
 				// ignore the auth record email visibility checks
 				// for auth owner, admin or manager
 				if collection.IsAuth() {
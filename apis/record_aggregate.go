@@ -0,0 +1,79 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindRecordAggregateApi registers the records aggregate api endpoint
+// and the corresponding handler.
+func bindRecordAggregateApi(app core.App, rg *echo.Group) {
+	api := recordAggregateApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/aggregate",
+		ActivityLogger(app),
+		LoadCollectionContext(app),
+	)
+
+	subGroup.GET("", api.aggregate)
+}
+
+type recordAggregateApi struct {
+	app core.App
+}
+
+func (api *recordAggregateApi) aggregate(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	// forbid users and guests to query special filter/sort/group fields
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return err
+	}
+
+	if requestInfo.Admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	// forbid users and guests to aggregate fields with view rule
+	if err := checkForRestrictedAggregateFields(collection, requestInfo); err != nil {
+		return err
+	}
+
+	fieldsResolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		requestInfo,
+		// hidden fields are aggregatable only by admins
+		requestInfo.Admin != nil,
+	)
+
+	searchProvider := search.NewProvider(fieldsResolver).
+		Query(api.app.Dao().RecordQuery(collection))
+
+	if requestInfo.Admin == nil && collection.ListRule != nil {
+		searchProvider.AddFilter(search.FilterData(*collection.ListRule))
+	}
+
+	if err := searchProvider.Parse(c.QueryParams().Encode()); err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	result, err := searchProvider.ExecAggregate()
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,235 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/spf13/cast"
+)
+
+// bindRecordBatchApi registers the records batch api endpoint and
+// the corresponding handler.
+func bindRecordBatchApi(app core.App, rg *echo.Group) {
+	api := recordBatchApi{app: app}
+
+	rg.POST("/batch", api.batch, ActivityLogger(app))
+}
+
+type recordBatchApi struct {
+	app core.App
+}
+
+// batchResponse defines the result of a single successful batch operation.
+type batchResponse struct {
+	Status int `json:"status"`
+	Body   any `json:"body"`
+}
+
+func (api *recordBatchApi) batch(c echo.Context) error {
+	form := forms.NewRecordsBatch(api.app)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	failedIndex := -1
+	results := make([]*recordTxResult, 0, len(form.Requests))
+
+	submitErr := form.Submit(func(txDao *daos.Dao, i int, r *forms.RecordsBatchRequest) error {
+		result, err := api.process(c, txDao, r)
+		if err != nil {
+			failedIndex = i
+			return err
+		}
+
+		results = append(results, result)
+
+		return nil
+	})
+	if submitErr != nil {
+		if failedIndex < 0 {
+			return NewBadRequestError("Failed to process the batch request.", submitErr)
+		}
+
+		return batchError(failedIndex, submitErr)
+	}
+
+	// trigger the after request hooks only after the transaction is committed
+	for _, result := range results {
+		if err := result.afterHook(); err != nil {
+			return err
+		}
+	}
+
+	if c.Response().Committed {
+		return nil
+	}
+
+	responses := make([]*batchResponse, len(results))
+	for i, result := range results {
+		responses[i] = &batchResponse{Status: result.status}
+
+		if result.record == nil {
+			continue
+		}
+
+		if err := EnrichRecord(c, api.app.Dao(), result.record); err != nil {
+			api.app.Logger().Debug(
+				"Failed to enrich batch record",
+				slog.String("id", result.record.Id),
+				slog.String("collectionName", result.record.Collection().Name),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		responses[i].Body = result.record
+	}
+
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (api *recordBatchApi) process(
+	c echo.Context,
+	txDao *daos.Dao,
+	r *forms.RecordsBatchRequest,
+) (*recordTxResult, error) {
+	collection, err := txDao.FindCollectionByNameOrId(r.Collection)
+	if err != nil || collection == nil {
+		return nil, NewNotFoundError("Missing collection.", err)
+	}
+
+	if collection.IsView() {
+		return nil, NewBadRequestError("View collection records cannot be modified.", nil)
+	}
+
+	data := make(map[string]any, len(r.Data)+1)
+	for k, v := range r.Data {
+		data[k] = v
+	}
+
+	switch r.Action {
+	case forms.RecordsBatchActionCreate:
+		if err := api.checkRateLimit(c, collection, settings.RateLimitActionCreate); err != nil {
+			return nil, err
+		}
+		return txRecordCreate(api.app, c, txDao, collection, batchRequestInfo(c, http.MethodPost, data), batchLoadForm(data))
+	case forms.RecordsBatchActionUpdate:
+		if err := api.checkRateLimit(c, collection, settings.RateLimitActionUpdate); err != nil {
+			return nil, err
+		}
+		return txRecordUpdate(api.app, c, txDao, collection, r.Id, batchRequestInfo(c, http.MethodPatch, data), batchLoadForm(data))
+	case forms.RecordsBatchActionDelete:
+		if err := api.checkRateLimit(c, collection, settings.RateLimitActionDelete); err != nil {
+			return nil, err
+		}
+		return txRecordDelete(api.app, c, txDao, collection, r.Id, batchRequestInfo(c, http.MethodDelete, nil))
+	case forms.RecordsBatchActionUpsert:
+		id := r.Id
+		if id == "" {
+			id = cast.ToString(data[schema.FieldNameId])
+		} else {
+			data[schema.FieldNameId] = id
+		}
+
+		if id != "" {
+			if _, err := txDao.FindTrashedRecordById(collection.Id, id); err == nil {
+				return nil, newUpsertTrashedTargetError()
+			}
+
+			if _, err := txDao.FindRecordById(collection.Id, id); err == nil {
+				if err := api.checkRateLimit(c, collection, settings.RateLimitActionUpdate); err != nil {
+					return nil, err
+				}
+				return txRecordUpdate(api.app, c, txDao, collection, id, batchRequestInfo(c, http.MethodPatch, data), batchLoadForm(data))
+			}
+		}
+
+		if err := api.checkRateLimit(c, collection, settings.RateLimitActionCreate); err != nil {
+			return nil, err
+		}
+		return txRecordCreate(api.app, c, txDao, collection, batchRequestInfo(c, http.MethodPost, data), batchLoadForm(data))
+	}
+
+	return nil, NewBadRequestError(fmt.Sprintf("Unsupported batch action %q.", r.Action), nil)
+}
+
+// checkRateLimit applies the app settings collection action rate limit
+// rules to a single batch operation (each operation is counted separately).
+func (api *recordBatchApi) checkRateLimit(c echo.Context, collection *models.Collection, action string) error {
+	return checkRateLimit(api.app, c, collectionRateLimitLabels(collection.Name, action))
+}
+
+// batchRequestInfo returns a shallow copy of the current request info
+// with replaced method and data fields to match the batch operation.
+func batchRequestInfo(c echo.Context, method string, data map[string]any) *models.RequestInfo {
+	requestInfo := *RequestInfo(c)
+
+	requestInfo.Method = method
+	requestInfo.Data = data
+	if requestInfo.Data == nil {
+		requestInfo.Data = map[string]any{}
+	}
+
+	return &requestInfo
+}
+
+// batchLoadForm returns a form loader function for the provided batch operation data.
+func batchLoadForm(data map[string]any) func(form *forms.RecordUpsert) error {
+	return func(form *forms.RecordUpsert) error {
+		if err := form.LoadData(data); err != nil {
+			return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+		}
+
+		// the files are deleted right after the record save and
+		// therefore they cannot be restored on transaction rollback
+		if len(form.FilesToDelete()) > 0 {
+			return NewBadRequestError("File fields cannot be changed with a batch request.", nil)
+		}
+
+		return nil
+	}
+}
+
+// batchError wraps the provided batch operation error
+// into a new ApiError with the failed request index.
+func batchError(index int, err error) *ApiError {
+	status := http.StatusBadRequest
+	message := err.Error()
+	var data any = err
+
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		status = apiErr.Code
+		message = apiErr.Message
+		data = apiErr.RawData()
+	}
+
+	var itemErr any
+	if v, ok := data.(validation.Errors); ok {
+		itemErr = v
+	} else {
+		itemErr = validation.NewError("validation_batch_request_failed", message)
+	}
+
+	return NewApiError(
+		status,
+		fmt.Sprintf("Batch request %d failed.", index),
+		map[string]any{
+			"requests": map[string]any{
+				strconv.Itoa(index): itemErr,
+			},
+		},
+	)
+}
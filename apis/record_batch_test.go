@@ -0,0 +1,255 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordBatch(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:           "empty body",
+			Method:         http.MethodPost,
+			Url:            "/api/batch",
+			Body:           strings.NewReader(`{}`),
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"data":{`,
+				`"requests":{"code":"validation_required"`,
+			},
+		},
+		{
+			Name:   "invalid requests",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"create","collection":"demo2"},
+				{"action":"invalid","collection":""},
+				{"action":"update","collection":"demo2"}
+			]}`),
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"requests":{`,
+				`"1":{"action":{"code":"validation_in_invalid"`,
+				`"collection":{"code":"validation_required"`,
+				`"2":{"id":{"code":"validation_required"`,
+			},
+			NotExpectedContent: []string{
+				`"0":`,
+			},
+		},
+		{
+			Name:   "missing collection",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"create","collection":"demo2","data":{"title":"new"}},
+				{"action":"create","collection":"missing","data":{"title":"new"}}
+			]}`),
+			ExpectedStatus: 404,
+			ExpectedContent: []string{
+				`"requests":{"1":{"code":"validation_batch_request_failed"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnModelBeforeCreate":         1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				ensureDemo2TitleMissing(t, app, "new")
+			},
+		},
+		{
+			Name:   "view collection",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"create","collection":"view1","data":{"title":"new"}}
+			]}`),
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"requests":{"0":{"code":"validation_batch_request_failed"`,
+			},
+		},
+		{
+			Name:   "guest trying to create in a nil rule collection",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"create","collection":"demo1","data":{"text":"new"}}
+			]}`),
+			ExpectedStatus: 403,
+			ExpectedContent: []string{
+				`"requests":{"0":{"code":"validation_batch_request_failed"`,
+			},
+		},
+		{
+			Name:   "failed record validation",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"create","collection":"demo2","data":{"title":"new"}},
+				{"action":"update","collection":"demo2","id":"achvryl401bhse3","data":{"title":"a"}}
+			]}`),
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"requests":{"1":{"title":{"code":"validation_min_text_constraint"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnModelBeforeCreate":         1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				ensureDemo2TitleMissing(t, app, "new")
+			},
+		},
+		{
+			Name:   "upsert of a trashed record",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"upsert","collection":"demo2","id":"llvuca81nly1qls","data":{"title":"new"}}
+			]}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				collection.Options["softDelete"] = true
+				if err := app.Dao().SaveCollection(collection); err != nil {
+					t.Fatal(err)
+				}
+
+				record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := app.Dao().DeleteRecord(record); err != nil {
+					t.Fatal(err)
+				}
+
+				app.ResetEventCalls()
+			},
+			ExpectedStatus: 409,
+			ExpectedContent: []string{
+				`"requests":{"0":{"code":"validation_batch_request_failed","message":"The upsert target record is in the trash. Restore or purge it first."}}`,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				ensureDemo2TitleMissing(t, app, "new")
+			},
+		},
+		{
+			Name:   "missing record to update",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"update","collection":"demo2","id":"missing","data":{"title":"new"}}
+			]}`),
+			ExpectedStatus: 404,
+			ExpectedContent: []string{
+				`"requests":{"0":{"code":"validation_batch_request_failed"`,
+			},
+		},
+		{
+			Name:   "successful create, update, upsert and delete",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"create","collection":"demo2","data":{"title":"new"}},
+				{"action":"update","collection":"demo2","id":"achvryl401bhse3","data":{"title":"test2_updated"}},
+				{"action":"upsert","collection":"demo2","id":"0yxhwia2amd8gec","data":{"title":"test3_upserted"}},
+				{"action":"upsert","collection":"demo2","data":{"id":"batch1234567890","title":"upsert_new"}},
+				{"action":"delete","collection":"demo2","id":"llvuca81nly1qls"}
+			]}`),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"status":200,"body":{`,
+				`"title":"new"`,
+				`"id":"achvryl401bhse3"`,
+				`"title":"test2_updated"`,
+				`"id":"0yxhwia2amd8gec"`,
+				`"title":"test3_upserted"`,
+				`"id":"batch1234567890"`,
+				`"title":"upsert_new"`,
+				`{"status":204,"body":null}`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 2,
+				"OnRecordAfterCreateRequest":  2,
+				"OnRecordBeforeUpdateRequest": 2,
+				"OnRecordAfterUpdateRequest":  2,
+				"OnRecordBeforeDeleteRequest": 1,
+				"OnRecordAfterDeleteRequest":  1,
+				"OnModelBeforeCreate":         2,
+				"OnModelAfterCreate":          2,
+				"OnModelBeforeUpdate":         3, // +1 for the relation references cleanup
+				"OnModelAfterUpdate":          3,
+				"OnModelBeforeDelete":         1,
+				"OnModelAfterDelete":          1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if _, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls"); err == nil {
+					t.Fatal("Expected the deleted record to be missing")
+				}
+
+				record, err := app.Dao().FindRecordById("demo2", "batch1234567890")
+				if err != nil {
+					t.Fatalf("Expected the upserted record to be created, got %v", err)
+				}
+				if v := record.GetString("title"); v != "upsert_new" {
+					t.Fatalf("Expected title %q, got %q", "upsert_new", v)
+				}
+			},
+		},
+		{
+			Name:   "rollback on delete failure",
+			Method: http.MethodPost,
+			Url:    "/api/batch",
+			Body: strings.NewReader(`{"requests":[
+				{"action":"create","collection":"demo2","data":{"title":"new"}},
+				{"action":"delete","collection":"demo2","id":"llvuca81nly1qls"}
+			]}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.OnRecordBeforeDeleteRequest().Add(func(e *core.RecordDeleteEvent) error {
+					return errors.New("test error")
+				})
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"requests":{"1":{"code":"validation_batch_request_failed"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordBeforeDeleteRequest": 1,
+				"OnModelBeforeCreate":         1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				ensureDemo2TitleMissing(t, app, "new")
+
+				if _, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls"); err != nil {
+					t.Fatalf("Expected the record to not be deleted, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func ensureDemo2TitleMissing(t *testing.T, app *tests.TestApp, title string) {
+	record, _ := app.Dao().FindFirstRecordByData("demo2", "title", title)
+	if record != nil {
+		t.Fatalf("Expected record with title %q to be missing (transaction rollback)", title)
+	}
+}
@@ -0,0 +1,339 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+const (
+	// bulkBatchSize specifies the max number of records
+	// that are processed within a single bulk transaction.
+	bulkBatchSize = 100
+
+	bulkSkipHooksQueryParam = "skipHooks"
+)
+
+// bindRecordBulkApi registers the records bulk update/delete api
+// endpoints and the corresponding handlers.
+func bindRecordBulkApi(app core.App, rg *echo.Group) {
+	api := recordBulkApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/bulk",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+
+	subGroup.PATCH("", api.update)
+	subGroup.DELETE("", api.delete)
+}
+
+type recordBulkApi struct {
+	app core.App
+}
+
+// bulkFailure defines a single failed bulk record operation.
+type bulkFailure struct {
+	Id      string `json:"id"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+// bulkResponse defines the result of a bulk update/delete request.
+type bulkResponse struct {
+	Affected int            `json:"affected"`
+	Failures []*bulkFailure `json:"failures"`
+}
+
+// bulkRecordFunc defines a single bulk record operation handler.
+type bulkRecordFunc func(txDao *daos.Dao, id string) (*recordTxResult, error)
+
+func (api *recordBulkApi) update(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	skipHooks, err := api.skipHooks(c, requestInfo)
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]any, len(requestInfo.Data))
+	for k, v := range requestInfo.Data {
+		data[k] = v
+	}
+
+	ids, err := api.findIds(c, collection, requestInfo, collection.UpdateRule)
+	if err != nil {
+		return err
+	}
+
+	var handler bulkRecordFunc
+	if skipHooks {
+		handler = func(txDao *daos.Dao, id string) (*recordTxResult, error) {
+			record, err := txDao.FindRecordById(collection.Id, id)
+			if err != nil {
+				return nil, NewNotFoundError("", err)
+			}
+
+			record.SetRevisionActor(requestRevisionActor(requestInfo))
+
+			form := forms.NewRecordUpsert(api.app, record)
+			form.SetDao(txDao)
+			form.SetFullManageAccess(true)
+			form.SetRequestInfo(requestInfo)
+			if err := batchLoadForm(data)(form); err != nil {
+				return nil, err
+			}
+
+			if err := form.Submit(); err != nil {
+				return nil, NewBadRequestError("Failed to update record.", err)
+			}
+
+			return &recordTxResult{status: http.StatusOK, record: record}, nil
+		}
+	} else {
+		handler = func(txDao *daos.Dao, id string) (*recordTxResult, error) {
+			return txRecordUpdate(api.app, c, txDao, collection, id, batchRequestInfo(c, http.MethodPatch, data), batchLoadForm(data))
+		}
+	}
+
+	return c.JSON(http.StatusOK, api.process(ids, skipHooks, handler))
+}
+
+func (api *recordBulkApi) delete(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	skipHooks, err := api.skipHooks(c, requestInfo)
+	if err != nil {
+		return err
+	}
+
+	ids, err := api.findIds(c, collection, requestInfo, collection.DeleteRule)
+	if err != nil {
+		return err
+	}
+
+	var handler bulkRecordFunc
+	if skipHooks {
+		handler = func(txDao *daos.Dao, id string) (*recordTxResult, error) {
+			record, err := txDao.FindRecordById(collection.Id, id)
+			if err != nil {
+				return nil, NewNotFoundError("", err)
+			}
+
+			record.SetRevisionActor(requestRevisionActor(requestInfo))
+
+			if err := txDao.DeleteRecord(record); err != nil {
+				return nil, NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
+			}
+
+			return &recordTxResult{status: http.StatusNoContent}, nil
+		}
+	} else {
+		handler = func(txDao *daos.Dao, id string) (*recordTxResult, error) {
+			return txRecordDelete(api.app, c, txDao, collection, id, batchRequestInfo(c, http.MethodDelete, nil))
+		}
+	}
+
+	return c.JSON(http.StatusOK, api.process(ids, skipHooks, handler))
+}
+
+// skipHooks checks whether the request has the skipHooks query param
+// set and ensures that it is used only by admins.
+func (api *recordBulkApi) skipHooks(c echo.Context, requestInfo *models.RequestInfo) (bool, error) {
+	raw := c.QueryParam(bulkSkipHooksQueryParam)
+	if raw == "" {
+		return false, nil
+	}
+
+	skip, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, NewBadRequestError("Invalid skipHooks value.", err)
+	}
+
+	if skip && requestInfo.Admin == nil {
+		return false, NewForbiddenError("Only admins can skip the record hooks.", nil)
+	}
+
+	return skip, nil
+}
+
+// findIds returns the ids of the collection records matching
+// the request filter and the provided collection API rule.
+func (api *recordBulkApi) findIds(
+	c echo.Context,
+	collection *models.Collection,
+	requestInfo *models.RequestInfo,
+	rule *string,
+) ([]string, error) {
+	// forbid users and guests to query special filter fields
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return nil, err
+	}
+
+	if requestInfo.Admin == nil && rule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	filter := c.QueryParam(search.FilterQueryParam)
+	if filter == "" {
+		return nil, NewBadRequestError("Missing required filter query parameter.", nil)
+	}
+
+	filters := []search.FilterData{search.FilterData(filter)}
+	if requestInfo.Admin == nil && *rule != "" {
+		filters = append(filters, search.FilterData(*rule))
+	}
+
+	resolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		requestInfo,
+		// hidden fields are searchable only by admins
+		requestInfo.Admin != nil,
+	)
+
+	query := api.app.Dao().RecordQuery(collection)
+
+	for _, f := range filters {
+		expr, err := f.BuildExpr(resolver)
+		if err != nil {
+			return nil, NewBadRequestError("Invalid filter expression.", err)
+		}
+		query.AndWhere(expr)
+	}
+
+	if err := resolver.UpdateQuery(query); err != nil {
+		return nil, NewBadRequestError("Invalid filter expression.", err)
+	}
+
+	ids := []string{}
+
+	idCol := collection.Name + ".id"
+	err := query.Distinct(true).Select(idCol).OrderBy(idCol).Column(&ids)
+	if err != nil {
+		return nil, NewBadRequestError("Failed to fetch the matching records.", err)
+	}
+
+	return ids, nil
+}
+
+// process executes the provided handler for each of the specified
+// record ids in batches of bulkBatchSize records per transaction.
+//
+// Each record is processed within its own savepoint so that a failed
+// record is reverted and reported without affecting the other batch records.
+func (api *recordBulkApi) process(ids []string, skipHooks bool, handler bulkRecordFunc) *bulkResponse {
+	dao := api.app.Dao()
+	if skipHooks {
+		// a dao without the app model hooks
+		dao = dao.WithoutHooks()
+	}
+
+	response := &bulkResponse{Failures: []*bulkFailure{}}
+
+	for len(ids) > 0 {
+		n := min(bulkBatchSize, len(ids))
+		batch := ids[:n]
+		ids = ids[n:]
+
+		api.processBatch(dao, batch, handler, response)
+	}
+
+	return response
+}
+
+// processBatch executes the provided handler for the specified record
+// ids within a single transaction and triggers their after request
+// hooks once the transaction is committed.
+//
+// The batch result is appended to the provided response.
+func (api *recordBulkApi) processBatch(dao *daos.Dao, ids []string, handler bulkRecordFunc, response *bulkResponse) {
+	results := make(map[string]*recordTxResult, len(ids))
+	failures := []*bulkFailure{}
+
+	txErr := dao.RunInTransaction(func(txDao *daos.Dao) error {
+		for _, id := range ids {
+			var result *recordTxResult
+
+			err := txDao.RunInSavepoint(func(spDao *daos.Dao) error {
+				var err error
+				result, err = handler(spDao, id)
+				return err
+			})
+			if errors.Is(err, daos.ErrSavepointFailed) {
+				return err // abort the entire batch
+			}
+			if err != nil {
+				failures = append(failures, newBulkFailure(id, err))
+				continue
+			}
+
+			results[id] = result
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		// nothing from the batch was committed
+		for _, id := range ids {
+			response.Failures = append(response.Failures, newBulkFailure(id, txErr))
+		}
+		return
+	}
+
+	response.Affected += len(results)
+	response.Failures = append(response.Failures, failures...)
+
+	// the record changes are already committed so the after hook
+	// errors are only reported (the records are still counted as affected)
+	for _, id := range ids {
+		result := results[id]
+		if result == nil || result.afterHook == nil {
+			continue
+		}
+
+		if err := result.afterHook(); err != nil {
+			response.Failures = append(response.Failures, newBulkFailure(id, err))
+		}
+	}
+}
+
+// newBulkFailure creates a new bulkFailure from the provided record operation error.
+func newBulkFailure(id string, err error) *bulkFailure {
+	failure := &bulkFailure{
+		Id:      id,
+		Status:  http.StatusBadRequest,
+		Message: err.Error(),
+		Data:    map[string]any{},
+	}
+
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		failure.Status = apiErr.Code
+		failure.Message = apiErr.Message
+		failure.Data = apiErr.Data
+	}
+
+	return failure
+}
@@ -1,18 +1,32 @@
 package apis
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
+)
+
+const (
+	upsertKeysQueryParam = "on"
+	headerETag           = "ETag"
+	headerIfMatch        = "If-Match"
 )
 
 // bindRecordCrudApi registers the record crud api endpoints and
@@ -28,6 +42,7 @@ func bindRecordCrudApi(app core.App, rg *echo.Group) {
 	subGroup.GET("/records", api.list, LoadCollectionContext(app))
 	subGroup.GET("/records/:id", api.view, LoadCollectionContext(app))
 	subGroup.POST("/records", api.create, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.PUT("/records", api.upsert, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
 	subGroup.PATCH("/records/:id", api.update, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
 	subGroup.DELETE("/records/:id", api.delete, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
 }
@@ -62,16 +77,37 @@ func (api *recordApi) list(c echo.Context) error {
 		requestInfo.Admin != nil,
 	)
 
+	// select only the columns required for the requested fields and expands
+	//
+	// note: the projection is skipped if there are request hook handlers
+	// because they could rely on the full records data (eg. on resave)
+	projection := newRecordsProjection(c.QueryParam(fieldsQueryParam))
+	expands := projection.expands(requestExpands(c.QueryParam(expandQueryParam)))
+	query := api.app.Dao().RecordQuery(collection)
+	if api.app.OnRecordsListRequest().Length() == 0 {
+		projection.apply(api.app.Dao(), collection, query, expands)
+	}
+
 	searchProvider := search.NewProvider(fieldsResolver).
-		Query(api.app.Dao().RecordQuery(collection))
+		Query(query)
+
+	// full-text search
+	if text := c.QueryParam(searchQueryParam); text != "" {
+		if err := fieldsResolver.SetSearch(text); err != nil {
+			return NewBadRequestError("Invalid full-text search.", err)
+		}
+
+		// sort by relevance if no other sort is specified
+		if c.QueryParam(search.SortQueryParam) == "" {
+			searchProvider.AddSort(search.SortField{Name: "@rank", Direction: search.SortAsc})
+		}
+	}
 
 	if requestInfo.Admin == nil && collection.ListRule != nil {
 		searchProvider.AddFilter(search.FilterData(*collection.ListRule))
 	}
 
-	records := []*models.Record{}
-
-	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &records)
+	result, records, err := execRecordsSearch(api.app.Dao(), collection, searchProvider, c.QueryParams().Encode())
 	if err != nil {
 		return NewBadRequestError("", err)
 	}
@@ -87,10 +123,14 @@ func (api *recordApi) list(c echo.Context) error {
 			return nil
 		}
 
-		if err := EnrichRecords(e.HttpContext, api.app.Dao(), e.Records); err != nil {
+		if err := enrichRecords(e.HttpContext, api.app.Dao(), e.Records, expands); err != nil {
 			api.app.Logger().Debug("Failed to enrich list records", slog.String("error", err.Error()))
 		}
 
+		if etag := recordsListETag(e.Records); etag != "" {
+			e.HttpContext.Response().Header().Set(headerETag, etag)
+		}
+
 		return e.HttpContext.JSON(http.StatusOK, e.Result)
 	})
 }
@@ -113,7 +153,19 @@ func (api *recordApi) view(c echo.Context) error {
 		return NewForbiddenError("Only admins can perform this action.", nil)
 	}
 
+	// select only the columns required for the requested fields and expands
+	//
+	// note: the projection is skipped if there are request hook handlers
+	// because they could rely on the full record data (eg. on resave)
+	projection := newRecordsProjection(c.QueryParam(fieldsQueryParam))
+	expands := projection.expands(requestExpands(c.QueryParam(expandQueryParam)))
+	applyProjection := api.app.OnRecordViewRequest().Length() == 0
+
 	ruleFunc := func(q *dbx.SelectQuery) error {
+		if applyProjection {
+			projection.apply(api.app.Dao(), collection, q, expands)
+		}
+
 		if requestInfo.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
 			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
 			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
@@ -141,7 +193,7 @@ func (api *recordApi) view(c echo.Context) error {
 			return nil
 		}
 
-		if err := EnrichRecord(e.HttpContext, api.app.Dao(), e.Record); err != nil {
+		if err := enrichRecords(e.HttpContext, api.app.Dao(), []*models.Record{e.Record}, expands); err != nil {
 			api.app.Logger().Debug(
 				"Failed to enrich view record",
 				slog.String("id", e.Record.Id),
@@ -150,6 +202,8 @@ func (api *recordApi) view(c echo.Context) error {
 			)
 		}
 
+		setRecordETagHeader(e.HttpContext, e.Record)
+
 		return e.HttpContext.JSON(http.StatusOK, e.Record)
 	})
 }
@@ -181,6 +235,7 @@ func (api *recordApi) create(c echo.Context) error {
 
 		testForm := forms.NewRecordUpsert(api.app, testRecord)
 		testForm.SetFullManageAccess(true)
+		testForm.SetRequestInfo(requestInfo)
 		if err := testForm.LoadRequest(c.Request(), ""); err != nil {
 			return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
 		}
@@ -206,7 +261,7 @@ func (api *recordApi) create(c echo.Context) error {
 				return fmt.Errorf("DrySubmit create rule failure: %w", err)
 			}
 			hasFullManageAccess = hasAuthManageAccess(txDao, foundRecord, requestInfo)
-			return nil
+			return checkFieldsWriteRules(txDao, testForm, models.NewRecord(collection), foundRecord, requestInfo)
 		})
 
 		if testErr != nil {
@@ -215,8 +270,10 @@ func (api *recordApi) create(c echo.Context) error {
 	}
 
 	record := models.NewRecord(collection)
+	record.SetRevisionActor(requestRevisionActor(requestInfo))
 	form := forms.NewRecordUpsert(api.app, record)
 	form.SetFullManageAccess(hasFullManageAccess)
+	form.SetRequestInfo(requestInfo)
 
 	// load request
 	if err := form.LoadRequest(c.Request(), ""); err != nil {
@@ -253,6 +310,8 @@ func (api *recordApi) create(c echo.Context) error {
 						return nil
 					}
 
+					setRecordETagHeader(e.HttpContext, e.Record)
+
 					return e.HttpContext.JSON(http.StatusOK, e.Record)
 				})
 			})
@@ -307,14 +366,27 @@ func (api *recordApi) update(c echo.Context) error {
 		return NewNotFoundError("", fetchErr)
 	}
 
+	ifMatch := c.Request().Header.Get(headerIfMatch)
+	if ifMatch != "" && !record.MatchETag(ifMatch) {
+		return NewPreconditionFailedError("", nil)
+	}
+
+	record.SetRevisionActor(requestRevisionActor(requestInfo))
+
 	form := forms.NewRecordUpsert(api.app, record)
 	form.SetFullManageAccess(requestInfo.Admin != nil || hasAuthManageAccess(api.app.Dao(), record, requestInfo))
+	form.SetIfMatch(ifMatch)
+	form.SetRequestInfo(requestInfo)
 
 	// load request
 	if err := form.LoadRequest(c.Request(), ""); err != nil {
 		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
 	}
 
+	if err := checkFieldsWriteRules(api.app.Dao(), form, record, record, requestInfo); err != nil {
+		return NewBadRequestError("Failed to update record.", err)
+	}
+
 	event := new(core.RecordUpdateEvent)
 	event.HttpContext = c
 	event.Collection = collection
@@ -328,6 +400,9 @@ func (api *recordApi) update(c echo.Context) error {
 
 			return api.app.OnRecordBeforeUpdateRequest().Trigger(event, func(e *core.RecordUpdateEvent) error {
 				if err := next(e.Record); err != nil {
+					if errors.Is(err, daos.ErrRecordETagMismatch) {
+						return NewPreconditionFailedError("", nil)
+					}
 					return NewBadRequestError("Failed to update record.", err)
 				}
 
@@ -345,6 +420,8 @@ func (api *recordApi) update(c echo.Context) error {
 						return nil
 					}
 
+					setRecordETagHeader(e.HttpContext, e.Record)
+
 					return e.HttpContext.JSON(http.StatusOK, e.Record)
 				})
 			})
@@ -352,6 +429,111 @@ func (api *recordApi) update(c echo.Context) error {
 	})
 }
 
+func (api *recordApi) upsert(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	keys, err := resolveUpsertKeys(collection, c.QueryParam(upsertKeysQueryParam))
+	if err != nil {
+		return NewBadRequestError("Invalid upsert keys.", validation.Errors{upsertKeysQueryParam: err})
+	}
+
+	requestInfo := RequestInfo(c)
+
+	keysExpr := make(dbx.HashExp, len(keys))
+	keysErrs := validation.Errors{}
+	for _, key := range keys {
+		value := requestInfo.Data[key]
+		if field := collection.Schema.GetFieldByName(key); field != nil {
+			value = field.PrepareValue(value)
+		} else {
+			value = cast.ToString(value)
+		}
+
+		if validation.IsEmpty(value) {
+			keysErrs[key] = validation.NewError("validation_missing_upsert_key", "Missing required upsert key value.")
+			continue
+		}
+
+		keysExpr[key] = value
+	}
+	if len(keysErrs) > 0 {
+		return NewBadRequestError("Failed to resolve the upsert target.", keysErrs)
+	}
+
+	loadForm := func(form *forms.RecordUpsert) error {
+		if err := form.LoadRequest(c.Request(), ""); err != nil {
+			return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+		}
+		return nil
+	}
+
+	var result *recordTxResult
+
+	// the target lookup and the create/update are executed in a single
+	// transaction to prevent concurrent upserts creating duplicated records
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		existing, err := txDao.FindRecordsByExpr(collection.Id, keysExpr)
+		if err != nil {
+			return NewBadRequestError("Failed to resolve the upsert target.", err)
+		}
+
+		// the trashed records are excluded from the above lookup
+		// but they still hold their unique keys values
+		if len(existing) == 0 {
+			var totalTrashed int
+			err := txDao.TrashedRecordQuery(collection).
+				Select("count(*)").
+				AndWhere(keysExpr).
+				Row(&totalTrashed)
+			if err != nil {
+				return NewBadRequestError("Failed to resolve the upsert target.", err)
+			}
+			if totalTrashed > 0 {
+				return newUpsertTrashedTargetError()
+			}
+		}
+
+		info := *requestInfo
+
+		if len(existing) > 0 {
+			info.Method = http.MethodPatch
+			result, err = txRecordUpdate(api.app, c, txDao, collection, existing[0].Id, &info, loadForm)
+		} else {
+			info.Method = http.MethodPost
+			result, err = txRecordCreate(api.app, c, txDao, collection, &info, loadForm)
+		}
+
+		return err
+	})
+	if txErr != nil {
+		return txErr
+	}
+
+	if err := result.afterHook(); err != nil {
+		return err
+	}
+
+	if c.Response().Committed {
+		return nil
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), result.record); err != nil {
+		api.app.Logger().Debug(
+			"Failed to enrich upsert record",
+			slog.String("id", result.record.Id),
+			slog.String("collectionName", result.record.Collection().Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	setRecordETagHeader(c, result.record)
+
+	return c.JSON(result.status, result.record)
+}
+
 func (api *recordApi) delete(c echo.Context) error {
 	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
 	if collection == nil {
@@ -388,6 +570,14 @@ func (api *recordApi) delete(c echo.Context) error {
 		return NewNotFoundError("", fetchErr)
 	}
 
+	ifMatch := c.Request().Header.Get(headerIfMatch)
+	if ifMatch != "" && !record.MatchETag(ifMatch) {
+		return NewPreconditionFailedError("", nil)
+	}
+
+	// in case of soft delete the record is only updated
+	record.SetRevisionActor(requestRevisionActor(requestInfo))
+
 	event := new(core.RecordDeleteEvent)
 	event.HttpContext = c
 	event.Collection = collection
@@ -395,8 +585,18 @@ func (api *recordApi) delete(c echo.Context) error {
 
 	return api.app.OnRecordBeforeDeleteRequest().Trigger(event, func(e *core.RecordDeleteEvent) error {
 		// delete the record
-		if err := api.app.Dao().DeleteRecord(e.Record); err != nil {
-			return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
+		deleteErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+			if err := txDao.CheckRecordETag(e.Record, ifMatch); err != nil {
+				return err
+			}
+
+			return txDao.DeleteRecord(e.Record)
+		})
+		if deleteErr != nil {
+			if errors.Is(deleteErr, daos.ErrRecordETagMismatch) {
+				return NewPreconditionFailedError("", nil)
+			}
+			return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", deleteErr)
 		}
 
 		return api.app.OnRecordAfterDeleteRequest().Trigger(event, func(e *core.RecordDeleteEvent) error {
@@ -408,3 +608,94 @@ func (api *recordApi) delete(c echo.Context) error {
 		})
 	})
 }
+
+// newUpsertTrashedTargetError returns a new conflict ApiError
+// for upsert requests matching a trashed record.
+func newUpsertTrashedTargetError() *ApiError {
+	return NewApiError(
+		http.StatusConflict,
+		"The upsert target record is in the trash. Restore or purge it first.",
+		nil,
+	)
+}
+
+// resolveUpsertKeys parses the comma separated list of upsert keys and
+// checks whether they could be used to uniquely identify a collection record.
+//
+// The keys are considered valid if they are the record "id" or if
+// they match exactly the columns of a non-partial unique index.
+//
+// Returns ["id"] if rawKeys is empty.
+func resolveUpsertKeys(collection *models.Collection, rawKeys string) ([]string, error) {
+	keys := []string{}
+	for _, k := range strings.Split(rawKeys, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	keys = list.ToUniqueStringSlice(keys)
+
+	if len(keys) == 0 {
+		return []string{schema.FieldNameId}, nil
+	}
+
+	if len(keys) == 1 && keys[0] == schema.FieldNameId {
+		return keys, nil
+	}
+
+	for _, idx := range collection.Indexes {
+		parsed := dbutils.ParseIndex(idx)
+		if !parsed.Unique || parsed.Where != "" || len(parsed.Columns) != len(keys) {
+			continue
+		}
+
+		matches := true
+		for _, col := range parsed.Columns {
+			if !list.ExistInSlice(col.Name, keys) {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return keys, nil
+		}
+	}
+
+	return nil, validation.NewError(
+		"validation_invalid_upsert_keys",
+		"The upsert keys must match the columns of a single unique index.",
+	)
+}
+
+// setRecordETagHeader sets the "ETag" response header
+// for the provided record (if it has one).
+func setRecordETagHeader(c echo.Context, record *models.Record) {
+	if etag := record.ETag(); etag != "" {
+		c.Response().Header().Set(headerETag, etag)
+	}
+}
+
+// recordsListETag returns a weak entity tag generated from
+// the ETags of the provided records.
+//
+// Returns an empty string if none of the records has an ETag.
+func recordsListETag(records []*models.Record) string {
+	var hasETag bool
+
+	var sb strings.Builder
+	for _, r := range records {
+		etag := r.ETag()
+		if etag != "" {
+			hasETag = true
+		}
+		sb.WriteString(etag)
+		sb.WriteString(",")
+	}
+
+	if !hasETag {
+		return ""
+	}
+
+	return `W/"` + security.MD5(sb.String()) + `"`
+}
@@ -0,0 +1,98 @@
+package apis
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// bindRecordExportApi registers the records export api endpoint
+// and the corresponding handler.
+func bindRecordExportApi(app core.App, rg *echo.Group) {
+	api := recordExportApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/export",
+		ActivityLogger(app),
+		LoadCollectionContext(app),
+	)
+
+	subGroup.GET("", api.export)
+}
+
+type recordExportApi struct {
+	app core.App
+}
+
+func (api *recordExportApi) export(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	// forbid users and guests to query special filter/sort fields
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return err
+	}
+
+	if requestInfo.Admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	form := forms.NewRecordsExport(api.app, collection)
+	form.SetRequestInfo(requestInfo)
+	form.SetExpandFetchFunc(expandFetch(api.app.Dao(), requestInfo))
+
+	if v := c.QueryParam("format"); v != "" {
+		form.Format = v
+	}
+	if v := c.QueryParam("relations"); v != "" {
+		form.Relations = v
+	}
+	if v := c.QueryParam("files"); v != "" {
+		form.Files = v
+	}
+	form.Filter = c.QueryParam("filter")
+	form.Sort = c.QueryParam("sort")
+	form.Fields = c.QueryParam("fields")
+
+	if err := form.Validate(); err != nil {
+		return NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, form.ContentType())
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", collection.Name+"."+form.Format),
+	)
+
+	if err := form.Submit(c.Response()); err != nil {
+		if !c.Response().Committed {
+			c.Response().Header().Del(echo.HeaderContentDisposition)
+			return NewBadRequestError("Failed to export the collection records.", err)
+		}
+
+		// the response is already partially streamed
+		api.app.Logger().Error(
+			"Failed to export the collection records",
+			slog.String("collectionName", collection.Name),
+			slog.String("error", err.Error()),
+		)
+
+		return nil
+	}
+
+	if !c.Response().Committed {
+		c.Response().WriteHeader(http.StatusOK)
+	}
+
+	return nil
+}
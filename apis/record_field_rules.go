@@ -0,0 +1,94 @@
+package apis
+
+import (
+	"encoding/json"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// checkFieldsWriteRules returns a validation error for each changed form
+// field whose write rule is not satisfied by the current request.
+//
+// The original record is used to determine the changed fields and
+// the persisted target record is used to evaluate the write rules
+// (usually the same record on update or the dry submitted one on create).
+func checkFieldsWriteRules(
+	dao *daos.Dao,
+	form *forms.RecordUpsert,
+	original *models.Record,
+	target *models.Record,
+	requestInfo *models.RequestInfo,
+) error {
+	if requestInfo.Admin != nil {
+		return nil // admins can change everything
+	}
+
+	errs := validation.Errors{}
+
+	data := form.Data()
+	uploads := form.FilesToUpload()
+
+	for _, field := range original.Collection().Schema.Fields() {
+		if field.WriteRule == "" {
+			continue
+		}
+
+		if len(uploads[field.Name]) == 0 && !isFieldValueChanged(field, original.Get(field.Name), data[field.Name]) {
+			continue // not changed
+		}
+
+		ok, err := dao.CanAccessRecord(target, requestInfo, &field.WriteRule)
+		if err != nil || !ok {
+			errs[field.Name] = validation.NewError(
+				"validation_field_write_forbidden",
+				"You are not allowed to change this field.",
+			)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// isFieldValueChanged checks whether the new field value is different from the old one.
+func isFieldValueChanged(field *schema.SchemaField, oldValue any, newValue any) bool {
+	oldRaw, oldErr := json.Marshal(field.PrepareValue(oldValue))
+	newRaw, newErr := json.Marshal(field.PrepareValue(newValue))
+
+	return oldErr != nil || newErr != nil || string(oldRaw) != string(newRaw)
+}
+
+// checkForRestrictedAggregateFields loosely checks and returns an error if
+// the RequestInfo aggregate or groupBy expressions contain a collection
+// field with view rule (aka. only the admins can aggregate restricted fields).
+func checkForRestrictedAggregateFields(collection *models.Collection, requestInfo *models.RequestInfo) error {
+	if requestInfo.Admin != nil || len(requestInfo.Query) == 0 {
+		return nil // admin or nothing to check
+	}
+
+	for _, field := range collection.Schema.Fields() {
+		if field.ViewRule == "" {
+			continue
+		}
+
+		fieldRegex := regexp.MustCompile(`(^|[^\w\.@])` + regexp.QuoteMeta(field.Name) + `($|[^\w])`)
+
+		for _, param := range []string{search.AggregateQueryParam, search.GroupByQueryParam} {
+			v, _ := requestInfo.Query[param].(string)
+			if v != "" && fieldRegex.MatchString(v) {
+				return NewForbiddenError("Only admins can aggregate by "+field.Name, nil)
+			}
+		}
+	}
+
+	return nil
+}
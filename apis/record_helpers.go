@@ -23,6 +23,7 @@ const ContextRequestInfoKey = "requestInfo"
 
 const expandQueryParam = "expand"
 const fieldsQueryParam = "fields"
+const searchQueryParam = "search"
 
 // Deprecated: Use RequestInfo instead.
 func RequestData(c echo.Context) *models.RequestInfo {
@@ -101,13 +102,18 @@ func RecordAuthResponse(
 		// allow always returning the email address of the authenticated account
 		e.Record.IgnoreEmailVisibility(true)
 
+		// create a copy of the cached request data and adjust it to the current auth record
+		requestInfo := *RequestInfo(e.HttpContext)
+		requestInfo.Admin = nil
+		requestInfo.AuthRecord = e.Record
+
+		if err := app.Dao().HideRestrictedRecordsFields([]*models.Record{e.Record}, &requestInfo); err != nil {
+			app.Logger().Debug("[RecordAuthResponse] Failed to resolve the fields view rules", slog.String("error", err.Error()))
+		}
+
 		// expand record relations
 		expands := strings.Split(c.QueryParam(expandQueryParam), ",")
 		if len(expands) > 0 {
-			// create a copy of the cached request data and adjust it to the current auth record
-			requestInfo := *RequestInfo(e.HttpContext)
-			requestInfo.Admin = nil
-			requestInfo.AuthRecord = e.Record
 			failed := app.Dao().ExpandRecord(
 				e.Record,
 				expands,
@@ -141,6 +147,7 @@ func RecordAuthResponse(
 //   - expands relations (if defaultExpands and/or ?expand query param is set)
 //   - ensures that the emails of the auth record and its expanded auth relations
 //     are visibe only for the current logged admin, record owner or record with manage access
+//   - hides the record and its expanded relations fields with unsatisfied view rule
 func EnrichRecord(c echo.Context, dao *daos.Dao, record *models.Record, defaultExpands ...string) error {
 	return EnrichRecords(c, dao, []*models.Record{record}, defaultExpands...)
 }
@@ -149,17 +156,26 @@ func EnrichRecord(c echo.Context, dao *daos.Dao, record *models.Record, defaultE
 //   - expands relations (if defaultExpands and/or ?expand query param is set)
 //   - ensures that the emails of the auth records and their expanded auth relations
 //     are visibe only for the current logged admin, record owner or record with manage access
+//   - hides the records and their expanded relations fields with unsatisfied view rule
 func EnrichRecords(c echo.Context, dao *daos.Dao, records []*models.Record, defaultExpands ...string) error {
+	expands := append(defaultExpands, requestExpands(c.QueryParam(expandQueryParam))...)
+
+	return enrichRecords(c, dao, records, expands)
+}
+
+// enrichRecords is similar to [EnrichRecords] but expands
+// only the explicitly provided expand paths.
+func enrichRecords(c echo.Context, dao *daos.Dao, records []*models.Record, expands []string) error {
 	requestInfo := RequestInfo(c)
 
 	if err := autoIgnoreAuthRecordsEmailVisibility(dao, records, requestInfo); err != nil {
 		return fmt.Errorf("Failed to resolve email visibility: %w", err)
 	}
 
-	expands := defaultExpands
-	if param := c.QueryParam(expandQueryParam); param != "" {
-		expands = append(expands, strings.Split(param, ",")...)
+	if err := dao.HideRestrictedRecordsFields(records, requestInfo); err != nil {
+		return fmt.Errorf("Failed to resolve the fields view rules: %w", err)
 	}
+
 	if len(expands) == 0 {
 		return nil // nothing to expand
 	}
@@ -202,6 +218,7 @@ func expandFetch(
 
 		if err == nil && len(records) > 0 {
 			autoIgnoreAuthRecordsEmailVisibility(dao, records, requestInfo)
+			dao.HideRestrictedRecordsFields(records, requestInfo)
 		}
 
 		return records, err
@@ -315,7 +332,40 @@ func hasAuthManageAccess(
 	return findErr == nil
 }
 
-var ruleQueryParams = []string{search.FilterQueryParam, search.SortQueryParam}
+// execRecordsSearch executes the provided records search provider
+// and returns its result with the found collection records as items.
+//
+// The records are initialized from the raw db rows so that the cursor
+// pagination sort values could be read from the fetched rows.
+func execRecordsSearch(
+	dao *daos.Dao,
+	collection *models.Collection,
+	provider *search.Provider,
+	urlQuery string,
+) (*search.Result, []*models.Record, error) {
+	rows := []dbx.NullStringMap{}
+
+	result, err := provider.ParseAndExec(urlQuery, &rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := models.NewRecordsFromNullStringMaps(collection, rows)
+	if err := dao.DecryptRecords(records...); err != nil {
+		return nil, nil, err
+	}
+
+	result.Items = records
+
+	return result, records, nil
+}
+
+var ruleQueryParams = []string{
+	search.FilterQueryParam,
+	search.SortQueryParam,
+	search.GroupByQueryParam,
+	search.AggregateQueryParam,
+}
 var adminOnlyRuleFields = []string{"@collection.", "@request."}
 
 // @todo consider moving the rules check to the RecordFieldResolver.
@@ -0,0 +1,96 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+// bindRecordImportApi registers the records import api endpoint
+// and the corresponding handler.
+func bindRecordImportApi(app core.App, rg *echo.Group) {
+	api := recordImportApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/import",
+		ActivityLogger(app),
+		RequireAdminAuth(),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+
+	subGroup.POST("", api.importRecords)
+}
+
+type recordImportApi struct {
+	app core.App
+}
+
+func (api *recordImportApi) importRecords(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	files, err := rest.FindUploadedFiles(c.Request(), "file")
+	if err != nil {
+		return NewBadRequestError("Missing or invalid uploaded file.", err)
+	}
+
+	form := forms.NewRecordsImport(api.app, collection)
+
+	if v := c.FormValue("format"); v != "" {
+		form.Format = v
+	} else if ext := strings.ToLower(filepath.Ext(files[0].OriginalName)); ext == ".ndjson" || ext == ".jsonl" {
+		form.Format = forms.RecordsImportFormatNDJSON
+	}
+	if v := c.FormValue("mode"); v != "" {
+		form.Mode = v
+	}
+	if v := c.FormValue("dryRun"); v != "" {
+		form.DryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			return NewBadRequestError("Invalid dryRun value.", err)
+		}
+	}
+	if v := c.FormValue("batchSize"); v != "" {
+		form.BatchSize, err = strconv.Atoi(v)
+		if err != nil {
+			return NewBadRequestError("Invalid batchSize value.", err)
+		}
+	}
+	if v := c.FormValue("mapping"); v != "" {
+		if err := json.Unmarshal([]byte(v), &form.Mapping); err != nil {
+			return NewBadRequestError("Invalid mapping value.", err)
+		}
+	}
+
+	if err := form.Validate(); err != nil {
+		return NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	f, err := files[0].Reader.Open()
+	if err != nil {
+		return NewBadRequestError("Failed to read the uploaded file.", err)
+	}
+	defer f.Close()
+
+	result, err := form.Submit(f)
+	if err != nil {
+		return NewBadRequestError("Failed to import the collection records.", err)
+	}
+
+	// normalize the rows validation errors to the same format as the other api errors
+	for _, rowErr := range result.Errors {
+		rowErr.Data = safeErrorsData(rowErr.Data)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,174 @@
+package apis
+
+import (
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/tokenizer"
+)
+
+// recordsProjection describes the subset of the record fields
+// requested with the fields query parameter.
+//
+// It is used to narrow the list/view records select query and to skip
+// the relation expands that will be excluded from the response anyway.
+type recordsProjection struct {
+	// fields contains the requested root level keys
+	// and their nested paths (if any), eg.:
+	//	"title,expand.author.name,data.a:excerpt(10)" => {"title": [""], "expand": ["author.name"], "data": ["a"]}
+	fields map[string][]string
+
+	// roots contains the requested root level keys in their original order
+	roots []string
+}
+
+// newRecordsProjection parses the provided raw fields expression
+// (the same format as the one used by [rest.PickFields]).
+//
+// Returns nil if all record fields are requested (eg. on empty or "*" expression)
+// or the expression is invalid, leaving the error reporting to the serializer.
+func newRecordsProjection(rawFields string) *recordsProjection {
+	if rawFields == "" {
+		return nil
+	}
+
+	parsed, err := tokenizer.NewFromString(rawFields).ScanAll()
+	if err != nil {
+		return nil
+	}
+
+	fields := make(map[string][]string, len(parsed))
+	roots := make([]string, 0, len(parsed))
+
+	for _, f := range parsed {
+		// strip the modifier (if any)
+		path, _, _ := strings.Cut(strings.TrimSpace(f), ":")
+
+		root, nested, _ := strings.Cut(path, ".")
+		if root == "*" {
+			return nil
+		}
+
+		if _, ok := fields[root]; !ok {
+			roots = append(roots, root)
+		}
+
+		fields[root] = append(fields[root], nested)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &recordsProjection{fields: fields, roots: roots}
+}
+
+// columns returns the collection columns that are required
+// to render the requested fields and the specified expands.
+//
+// The id and updated columns are always selected since they are
+// used for the relations resolving and the ETag generation.
+//
+// Returns nil if the projection cannot be applied (eg. for view collections
+// since their columns are not known before the query execution).
+func (p *recordsProjection) columns(collection *models.Collection, expands []string) []string {
+	if p == nil || collection.IsView() {
+		return nil
+	}
+
+	columns := []string{schema.FieldNameId, schema.FieldNameUpdated}
+
+	add := func(name string) {
+		if !list.ExistInSlice(name, columns) {
+			columns = append(columns, name)
+		}
+	}
+
+	for _, name := range p.roots {
+		switch {
+		case name == schema.FieldNameCreated:
+			add(name)
+		case collection.Schema.GetFieldByName(name) != nil:
+			add(name)
+		case collection.IsAuth() && (name == schema.FieldNameUsername || name == schema.FieldNameVerified || name == schema.FieldNameEmailVisibility):
+			add(name)
+		case collection.IsAuth() && name == schema.FieldNameEmail:
+			// the email visibility is required to determine whether the email could be shown
+			add(name)
+			add(schema.FieldNameEmailVisibility)
+		}
+	}
+
+	// the relation fields of the expands
+	// (back-relations are resolved using the record id)
+	for _, expand := range expands {
+		name, _, _ := strings.Cut(expand, ".")
+		if field := collection.Schema.GetFieldByName(name); field != nil && field.Type == schema.FieldTypeRelation {
+			add(name)
+		}
+	}
+
+	return columns
+}
+
+// expands filters the provided expand paths and returns
+// only those that will be present in the response.
+func (p *recordsProjection) expands(expands []string) []string {
+	if p == nil || len(expands) == 0 {
+		return expands
+	}
+
+	paths, ok := p.fields[schema.FieldNameExpand]
+	if !ok {
+		return nil // no expand field requested
+	}
+
+	requested := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		name, _, _ := strings.Cut(path, ".")
+		if name == "" || name == "*" {
+			return expands // the entire expand object is requested
+		}
+		requested[name] = struct{}{}
+	}
+
+	result := make([]string, 0, len(expands))
+	for _, expand := range expands {
+		name, _, _ := strings.Cut(strings.TrimSpace(expand), ".")
+		if _, ok := requested[name]; ok {
+			result = append(result, expand)
+		}
+	}
+
+	return result
+}
+
+// apply narrows the select columns of the provided records query.
+func (p *recordsProjection) apply(dao *daos.Dao, collection *models.Collection, query *dbx.SelectQuery, expands []string) {
+	columns := p.columns(collection, expands)
+	if len(columns) == 0 {
+		return
+	}
+
+	tableName := dao.DB().QuoteSimpleColumnName(collection.Name)
+
+	selects := make([]string, len(columns))
+	for i, col := range columns {
+		selects[i] = tableName + "." + dao.DB().QuoteSimpleColumnName(col)
+	}
+
+	query.Select(selects...)
+}
+
+// requestExpands returns the expand paths from the request expand query parameter.
+func requestExpands(rawExpand string) []string {
+	if rawExpand == "" {
+		return nil
+	}
+
+	return strings.Split(rawExpand, ",")
+}
@@ -0,0 +1,250 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindRecordRevisionApi registers the record revisions api endpoints
+// and the corresponding handlers.
+func bindRecordRevisionApi(app core.App, rg *echo.Group) {
+	api := recordRevisionApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/records/:id/revisions",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+
+	subGroup.GET("", api.list)
+	subGroup.GET("/diff", api.diff)
+	subGroup.POST("/:revision/restore", api.restore)
+}
+
+type recordRevisionApi struct {
+	app core.App
+}
+
+// revisionDiffResponse defines the response of the revisions diff request.
+type revisionDiffResponse struct {
+	From    string                            `json:"from"`
+	To      string                            `json:"to"`
+	Changes map[string]*models.RevisionChange `json:"changes"`
+}
+
+func (api *recordRevisionApi) list(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "action", "changes",
+		"actorType", "actorCollectionId", "actorId",
+	)
+
+	searchProvider := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().RecordRevisionQuery(record))
+
+	// newest first by default
+	if c.QueryParam(search.SortQueryParam) == "" {
+		searchProvider.AddSort(search.SortField{Name: "created", Direction: search.SortDesc})
+	}
+
+	revisions := []*models.Revision{}
+
+	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &revisions)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	// strip the field values that the client is not allowed to view
+	for _, field := range record.Collection().Schema.Fields() {
+		if !record.IsHidden(field.Name) {
+			continue
+		}
+		for _, revision := range revisions {
+			delete(revision.Data, field.Name)
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *recordRevisionApi) diff(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	from, err := api.app.Dao().FindRecordRevisionById(record, c.QueryParam("from"))
+	if err != nil {
+		return NewNotFoundError("Missing or invalid from revision.", err)
+	}
+
+	// compare with the current record state if "to" is not set
+	toId := c.QueryParam("to")
+	toData := record.SchemaData()
+	if toId != "" {
+		to, err := api.app.Dao().FindRecordRevisionById(record, toId)
+		if err != nil {
+			return NewNotFoundError("Missing or invalid to revision.", err)
+		}
+		toData = to.Data
+	}
+
+	changes := models.DiffRevisionData(from.Data, toData)
+
+	// strip the field values that the client is not allowed to view
+	for name := range changes {
+		if record.IsHidden(name) {
+			delete(changes, name)
+		}
+	}
+
+	return c.JSON(http.StatusOK, &revisionDiffResponse{
+		From:    from.Id,
+		To:      toId,
+		Changes: changes,
+	})
+}
+
+func (api *recordRevisionApi) restore(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	revision, err := api.app.Dao().FindRecordRevisionById(record, c.PathParam("revision"))
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	collection := record.Collection()
+
+	// restore only the existing non-file fields
+	// (the files are not versioned and may no longer exist)
+	data := make(map[string]any, len(revision.Data))
+	for _, field := range collection.Schema.Fields() {
+		if field.Type == schema.FieldTypeFile {
+			continue
+		}
+		if v, ok := revision.Data[field.Name]; ok {
+			data[field.Name] = v
+		}
+	}
+
+	requestInfo := *RequestInfo(c)
+	requestInfo.Method = http.MethodPatch
+	requestInfo.Data = data
+
+	loadForm := func(form *forms.RecordUpsert) error {
+		if err := form.LoadData(data); err != nil {
+			return NewBadRequestError("Failed to load the revision data.", err)
+		}
+		return nil
+	}
+
+	var result *recordTxResult
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		var err error
+		result, err = txRecordUpdate(api.app, c, txDao, collection, record.Id, &requestInfo, loadForm)
+		return err
+	})
+	if txErr != nil {
+		return txErr
+	}
+
+	if err := result.afterHook(); err != nil {
+		return err
+	}
+
+	if c.Response().Committed {
+		return nil
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), result.record); err != nil {
+		api.app.Logger().Debug(
+			"Failed to enrich restored revision record",
+			slog.String("id", result.record.Id),
+			slog.String("collectionName", collection.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	setRecordETagHeader(c, result.record)
+
+	return c.JSON(result.status, result.record)
+}
+
+// findRecord returns the path record applying the collection view and update
+// API rules and ensuring that its collection has enabled history tracking.
+//
+// The history could be accessed only by admins and clients that are
+// allowed to modify the record (aka. to restore one of its revisions).
+//
+// The record fields that the client is not allowed to view are marked as hidden.
+func (api *recordRevisionApi) findRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	if !collection.HasTrackHistory() {
+		return nil, NewBadRequestError("The collection doesn't have history tracking enabled.", nil)
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && (collection.ViewRule == nil || collection.UpdateRule == nil) {
+		// only admins can access if the rules are nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(
+		collection.Id,
+		c.PathParam("id"),
+		txRuleFunc(api.app.Dao(), collection, requestInfo, collection.ViewRule),
+	)
+	if err != nil || record == nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	if ok, _ := api.app.Dao().CanAccessRecord(record, requestInfo, collection.UpdateRule); !ok {
+		return nil, NewForbiddenError("You are not allowed to access the record history.", nil)
+	}
+
+	if err := api.app.Dao().HideRestrictedRecordsFields([]*models.Record{record}, requestInfo); err != nil {
+		api.app.Logger().Debug(
+			"Failed to resolve the revisions record fields view rules",
+			slog.String("id", record.Id),
+			slog.String("collectionName", collection.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return record, nil
+}
+
+// requestRevisionActor returns the request admin or auth record
+// that should be stored with the record revision (if any).
+func requestRevisionActor(requestInfo *models.RequestInfo) models.Model {
+	if requestInfo.Admin != nil {
+		return requestInfo.Admin
+	}
+
+	if requestInfo.AuthRecord != nil {
+		return requestInfo.AuthRecord
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindRecordTrashApi registers the soft deleted records api endpoints
+// and the corresponding handlers.
+func bindRecordTrashApi(app core.App, rg *echo.Group) {
+	api := recordTrashApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/trash",
+		ActivityLogger(app),
+		RequireAdminAuth(),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+
+	subGroup.GET("", api.list)
+	subGroup.POST("/:id/restore", api.restore)
+	subGroup.DELETE("/:id", api.purge)
+}
+
+type recordTrashApi struct {
+	app core.App
+}
+
+func (api *recordTrashApi) list(c echo.Context) error {
+	collection, err := api.trashCollection(c)
+	if err != nil {
+		return err
+	}
+
+	fieldsResolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		RequestInfo(c),
+		true,
+	)
+
+	searchProvider := search.NewProvider(fieldsResolver).
+		Query(api.app.Dao().TrashedRecordQuery(collection))
+
+	result, records, err := execRecordsSearch(api.app.Dao(), collection, searchProvider, c.QueryParams().Encode())
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records); err != nil {
+		api.app.Logger().Debug("Failed to enrich trashed records", slog.String("error", err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *recordTrashApi) restore(c echo.Context) error {
+	collection, err := api.trashCollection(c)
+	if err != nil {
+		return err
+	}
+
+	record, err := api.app.Dao().FindTrashedRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	record.SetRevisionActor(requestRevisionActor(RequestInfo(c)))
+
+	// the restore is a regular record update
+	// and therefore it triggers the update request hooks
+	event := new(core.RecordUpdateEvent)
+	event.HttpContext = c
+	event.Collection = collection
+	event.Record = record
+	event.UploadedFiles = map[string][]*filesystem.File{}
+
+	return api.app.OnRecordBeforeUpdateRequest().Trigger(event, func(e *core.RecordUpdateEvent) error {
+		if err := api.app.Dao().RestoreRecord(e.Record); err != nil {
+			return NewBadRequestError("Failed to restore record.", err)
+		}
+
+		return api.app.OnRecordAfterUpdateRequest().Trigger(event, func(e *core.RecordUpdateEvent) error {
+			if e.HttpContext.Response().Committed {
+				return nil
+			}
+
+			if err := EnrichRecord(e.HttpContext, api.app.Dao(), e.Record); err != nil {
+				api.app.Logger().Debug(
+					"Failed to enrich restored record",
+					slog.String("id", e.Record.Id),
+					slog.String("collectionName", e.Collection.Name),
+					slog.String("error", err.Error()),
+				)
+			}
+
+			setRecordETagHeader(e.HttpContext, e.Record)
+
+			return e.HttpContext.JSON(http.StatusOK, e.Record)
+		})
+	})
+}
+
+func (api *recordTrashApi) purge(c echo.Context) error {
+	collection, err := api.trashCollection(c)
+	if err != nil {
+		return err
+	}
+
+	record, err := api.app.Dao().FindTrashedRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	// the purge is a regular (hard) record delete
+	// and therefore it triggers the delete request hooks
+	event := new(core.RecordDeleteEvent)
+	event.HttpContext = c
+	event.Collection = collection
+	event.Record = record
+
+	return api.app.OnRecordBeforeDeleteRequest().Trigger(event, func(e *core.RecordDeleteEvent) error {
+		if err := api.app.Dao().PurgeRecord(e.Record); err != nil {
+			return NewBadRequestError("Failed to purge record. Make sure that the record is not part of a required relation reference.", err)
+		}
+
+		return api.app.OnRecordAfterDeleteRequest().Trigger(event, func(e *core.RecordDeleteEvent) error {
+			if e.HttpContext.Response().Committed {
+				return nil
+			}
+
+			return e.HttpContext.NoContent(http.StatusNoContent)
+		})
+	})
+}
+
+// trashCollection returns the context collection
+// ensuring that it has soft delete enabled.
+func (api *recordTrashApi) trashCollection(c echo.Context) (*models.Collection, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	if !collection.HasSoftDelete() {
+		return nil, NewBadRequestError("The collection doesn't have soft delete enabled.", nil)
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,273 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// recordTxResult holds the result of a single record
+// operation executed as part of a larger transaction.
+type recordTxResult struct {
+	status int
+	record *models.Record
+
+	// afterHook triggers the related OnRecordAfter*Request app hook
+	// and it is expected to be called after the transaction commit.
+	afterHook func() error
+}
+
+// txRecordCreate creates a new collection record within the provided
+// transaction dao applying the collection create API rule.
+//
+// loadForm is used to load the submitted data into the upsert form
+// (it is called for both the create rule check and the actual create).
+func txRecordCreate(
+	app core.App,
+	c echo.Context,
+	txDao *daos.Dao,
+	collection *models.Collection,
+	requestInfo *models.RequestInfo,
+	loadForm func(form *forms.RecordUpsert) error,
+) (*recordTxResult, error) {
+	if requestInfo.Admin == nil && collection.CreateRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	hasFullManageAccess := requestInfo.Admin != nil
+
+	// temporary save the record and check it against the create rule
+	if requestInfo.Admin == nil && collection.CreateRule != nil {
+		testRecord := models.NewRecord(collection)
+
+		// replace modifiers fields so that the resolved value is always
+		// available when accessing requestInfo.Data using just the field name
+		if requestInfo.HasModifierDataKeys() {
+			requestInfo.Data = testRecord.ReplaceModifers(requestInfo.Data)
+		}
+
+		testForm := forms.NewRecordUpsert(app, testRecord)
+		testForm.SetDao(txDao)
+		testForm.SetFullManageAccess(true)
+		testForm.SetRequestInfo(requestInfo)
+		if err := loadForm(testForm); err != nil {
+			return nil, err
+		}
+
+		testErr := testForm.DrySubmit(func(dryDao *daos.Dao) error {
+			foundRecord, err := dryDao.FindRecordById(collection.Id, testRecord.Id, txRuleFunc(dryDao, collection, requestInfo, collection.CreateRule))
+			if err != nil {
+				return fmt.Errorf("DrySubmit create rule failure: %w", err)
+			}
+			hasFullManageAccess = hasAuthManageAccess(dryDao, foundRecord, requestInfo)
+			return checkFieldsWriteRules(dryDao, testForm, models.NewRecord(collection), foundRecord, requestInfo)
+		})
+
+		if testErr != nil {
+			return nil, NewBadRequestError("Failed to create record.", testErr)
+		}
+	}
+
+	record := models.NewRecord(collection)
+	record.SetRevisionActor(requestRevisionActor(requestInfo))
+	form := forms.NewRecordUpsert(app, record)
+	form.SetDao(txDao)
+	form.SetFullManageAccess(hasFullManageAccess)
+	form.SetRequestInfo(requestInfo)
+
+	if err := loadForm(form); err != nil {
+		return nil, err
+	}
+
+	event := new(core.RecordCreateEvent)
+	event.HttpContext = c
+	event.Collection = collection
+	event.Record = record
+	event.UploadedFiles = form.FilesToUpload()
+
+	submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(m *models.Record) error {
+			event.Record = m
+
+			return app.OnRecordBeforeCreateRequest().Trigger(event, func(e *core.RecordCreateEvent) error {
+				if err := next(e.Record); err != nil {
+					return NewBadRequestError("Failed to create record.", err)
+				}
+
+				return nil
+			})
+		}
+	})
+	if submitErr != nil {
+		return nil, submitErr
+	}
+
+	return &recordTxResult{
+		status: http.StatusOK,
+		record: event.Record,
+		afterHook: func() error {
+			return app.OnRecordAfterCreateRequest().Trigger(event)
+		},
+	}, nil
+}
+
+// txRecordUpdate updates the specified collection record within the
+// provided transaction dao applying the collection update API rule.
+//
+// loadForm is used to load the submitted data into the upsert form.
+func txRecordUpdate(
+	app core.App,
+	c echo.Context,
+	txDao *daos.Dao,
+	collection *models.Collection,
+	recordId string,
+	requestInfo *models.RequestInfo,
+	loadForm func(form *forms.RecordUpsert) error,
+) (*recordTxResult, error) {
+	if requestInfo.Admin == nil && collection.UpdateRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	// eager fetch the record so that the modifier field values are replaced
+	// and available when accessing requestInfo.Data using just the field name
+	if requestInfo.HasModifierDataKeys() {
+		record, err := txDao.FindRecordById(collection.Id, recordId)
+		if err != nil || record == nil {
+			return nil, NewNotFoundError("", err)
+		}
+		requestInfo.Data = record.ReplaceModifers(requestInfo.Data)
+	}
+
+	record, fetchErr := txDao.FindRecordById(collection.Id, recordId, txRuleFunc(txDao, collection, requestInfo, collection.UpdateRule))
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	record.SetRevisionActor(requestRevisionActor(requestInfo))
+
+	form := forms.NewRecordUpsert(app, record)
+	form.SetDao(txDao)
+	form.SetFullManageAccess(requestInfo.Admin != nil || hasAuthManageAccess(txDao, record, requestInfo))
+	form.SetRequestInfo(requestInfo)
+
+	if err := loadForm(form); err != nil {
+		return nil, err
+	}
+
+	if err := checkFieldsWriteRules(txDao, form, record, record, requestInfo); err != nil {
+		return nil, NewBadRequestError("Failed to update record.", err)
+	}
+
+	event := new(core.RecordUpdateEvent)
+	event.HttpContext = c
+	event.Collection = collection
+	event.Record = record
+	event.UploadedFiles = form.FilesToUpload()
+
+	submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(m *models.Record) error {
+			event.Record = m
+
+			return app.OnRecordBeforeUpdateRequest().Trigger(event, func(e *core.RecordUpdateEvent) error {
+				if err := next(e.Record); err != nil {
+					return NewBadRequestError("Failed to update record.", err)
+				}
+
+				return nil
+			})
+		}
+	})
+	if submitErr != nil {
+		return nil, submitErr
+	}
+
+	return &recordTxResult{
+		status: http.StatusOK,
+		record: event.Record,
+		afterHook: func() error {
+			return app.OnRecordAfterUpdateRequest().Trigger(event)
+		},
+	}, nil
+}
+
+// txRecordDelete deletes the specified collection record within the
+// provided transaction dao applying the collection delete API rule.
+func txRecordDelete(
+	app core.App,
+	c echo.Context,
+	txDao *daos.Dao,
+	collection *models.Collection,
+	recordId string,
+	requestInfo *models.RequestInfo,
+) (*recordTxResult, error) {
+	if requestInfo.Admin == nil && collection.DeleteRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	record, fetchErr := txDao.FindRecordById(collection.Id, recordId, txRuleFunc(txDao, collection, requestInfo, collection.DeleteRule))
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	// in case of soft delete the record is only updated
+	record.SetRevisionActor(requestRevisionActor(requestInfo))
+
+	event := new(core.RecordDeleteEvent)
+	event.HttpContext = c
+	event.Collection = collection
+	event.Record = record
+
+	deleteErr := app.OnRecordBeforeDeleteRequest().Trigger(event, func(e *core.RecordDeleteEvent) error {
+		if err := txDao.DeleteRecord(e.Record); err != nil {
+			return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
+		}
+
+		return nil
+	})
+	if deleteErr != nil {
+		return nil, deleteErr
+	}
+
+	return &recordTxResult{
+		status: http.StatusNoContent,
+		afterHook: func() error {
+			return app.OnRecordAfterDeleteRequest().Trigger(event)
+		},
+	}, nil
+}
+
+// txRuleFunc returns a query filter function that applies the
+// provided collection API rule (if the request is not from an admin).
+func txRuleFunc(
+	dao *daos.Dao,
+	collection *models.Collection,
+	requestInfo *models.RequestInfo,
+	rule *string,
+) func(q *dbx.SelectQuery) error {
+	return func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin != nil || rule == nil || *rule == "" {
+			return nil
+		}
+
+		resolver := resolvers.NewRecordFieldResolver(dao, collection, requestInfo, true)
+		expr, err := search.FilterData(*rule).BuildExpr(resolver)
+		if err != nil {
+			return err
+		}
+		resolver.UpdateQuery(q)
+		q.AndWhere(expr)
+
+		return nil
+	}
+}
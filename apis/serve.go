@@ -87,6 +87,8 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 		Skipper:      middleware.DefaultSkipper,
 		AllowOrigins: config.AllowedOrigins,
 		AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
+		// expose the records version header for optimistic concurrency control
+		ExposeHeaders: []string{"ETag"},
 	}))
 
 	// start http server
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cobra"
+)
+
+// NewEncryptionCommand creates and returns new command for managing
+// the app encryption key (eg. key rotation).
+func NewEncryptionCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "encryption",
+		Short: "Manages the app encryption key",
+	}
+
+	command.AddCommand(encryptionRotateCommand(app))
+
+	return command
+}
+
+func encryptionRotateCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "rotate",
+		Example: "encryption rotate PB_NEW_ENCRYPTION_KEY",
+		Short:   "Re-encrypts the app settings and the records encrypted fields with the key from the specified env variable",
+		Long: "Re-encrypts the app settings and the records encrypted fields with the key from the specified env variable.\n" +
+			"The current values are decrypted with the key from the app encryption env variable (see --encryptionEnv).\n" +
+			"After successful rotation, the app encryption env variable must be updated with the new key.",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 || args[0] == "" {
+				return errors.New("Missing new encryption key env variable name argument.")
+			}
+
+			newKey := os.Getenv(args[0])
+			if err := models.CheckRecordEncryptionKey(newKey); err != nil {
+				return fmt.Errorf("Invalid new encryption key from env variable %s: %v", args[0], err)
+			}
+
+			oldKey := os.Getenv(app.EncryptionEnv())
+			if oldKey == newKey {
+				return errors.New("The new encryption key must be different from the current one.")
+			}
+
+			var total int
+
+			err := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+				storedSettings, err := txDao.FindSettings(oldKey)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("failed to load the stored app settings: %w", err)
+				}
+
+				if storedSettings != nil {
+					if err := txDao.SaveSettings(storedSettings, newKey); err != nil {
+						return fmt.Errorf("failed to save the app settings: %w", err)
+					}
+				}
+
+				total, err = txDao.ReencryptRecords(oldKey, newKey)
+
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("Failed to rotate the encryption key: %v", err)
+			}
+
+			app.Dao().SetEncryptionKey(newKey)
+
+			color.Green(
+				"Successfully re-encrypted the app settings and %d record(s)!\nDon't forget to update the %s env variable with the new key.",
+				total,
+				app.EncryptionEnv(),
+			)
+
+			return nil
+		},
+	}
+
+	return command
+}
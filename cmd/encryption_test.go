@@ -0,0 +1,87 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestEncryptionRotateCommand(t *testing.T) {
+	// not parallel because it changes the env
+	oldKey := "abcdabcdabcdabcdabcdabcdabcdabcd"
+	newKey := "zyxwzyxwzyxwzyxwzyxwzyxwzyxwzyxw"
+
+	t.Setenv("pb_test_env", oldKey)
+	t.Setenv("PB_TEST_SAME_KEY", oldKey)
+	t.Setenv("PB_TEST_INVALID_KEY", "invalid")
+	t.Setenv("PB_TEST_NEW_KEY", newKey)
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "encrypted_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(&schema.SchemaField{
+			Name:    "secret",
+			Type:    schema.FieldTypeEncrypted,
+			Options: &schema.EncryptedOptions{BlindIndex: true},
+		}),
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record := models.NewRecord(collection)
+	record.Set("secret", "test")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{"missing env variable argument", []string{}, true},
+		{"missing env variable", []string{"PB_TEST_MISSING_KEY"}, true},
+		{"invalid new key", []string{"PB_TEST_INVALID_KEY"}, true},
+		{"same key", []string{"PB_TEST_SAME_KEY"}, true},
+		{"valid new key", []string{"PB_TEST_NEW_KEY"}, false},
+	}
+
+	for _, s := range scenarios {
+		command := cmd.NewEncryptionCommand(app)
+		command.SetArgs(append([]string{"rotate"}, s.args...))
+
+		err := command.Execute()
+
+		hasErr := err != nil
+		if s.expectError != hasErr {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+		}
+	}
+
+	if _, err := app.Dao().FindSettings(oldKey); err == nil {
+		t.Fatal("Expected the settings to be no longer loadable with the old key")
+	}
+
+	if _, err := app.Dao().FindSettings(newKey); err != nil {
+		t.Fatalf("Expected the settings to be loadable with the new key, got %v", err)
+	}
+
+	if v := app.Dao().EncryptionKey(); v != newKey {
+		t.Fatalf("Expected the record encryption key to be changed to %q, got %q", newKey, v)
+	}
+
+	found, err := app.Dao().FindFirstRecordByFilter(collection.Name, "secret = 'test'")
+	if err != nil {
+		t.Fatalf("Expected to find the record by its new blind index, got %v", err)
+	}
+	if v := found.GetString("secret"); v != "test" {
+		t.Fatalf("Expected secret %q, got %q", "test", v)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand creates and returns new command for exporting
+// the records of a single collection (with full access).
+func NewExportCommand(app core.App) *cobra.Command {
+	var format, filter, sort, fields, relations, files, output string
+
+	command := &cobra.Command{
+		Use:     "export",
+		Example: "export posts --format=csv --filter=\"status='active'\" --output=posts.csv",
+		Short:   "Exports the records of a collection as JSON, NDJSON or CSV",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Missing collection name or id argument.")
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", args[0])
+			}
+
+			form := forms.NewRecordsExport(app, collection)
+			form.Format = format
+			form.Filter = filter
+			form.Sort = sort
+			form.Fields = fields
+			form.Relations = relations
+			form.Files = files
+
+			var w io.Writer = command.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("Failed to create the output file: %v", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if err := form.Submit(w); err != nil {
+				return fmt.Errorf("Failed to export the collection records: %v", err)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&format, "format", forms.RecordsExportFormatJSON, "the export format (json, ndjson or csv)")
+	command.Flags().StringVar(&filter, "filter", "", "an optional records filter expression")
+	command.Flags().StringVar(&sort, "sort", "", "an optional records sort expression (eg. -created,id)")
+	command.Flags().StringVar(&fields, "fields", "", "comma separated list of the fields to export (default all)")
+	command.Flags().StringVar(&relations, "relations", forms.RecordsExportRelationsId, "how to render the relation fields (id or expand)")
+	command.Flags().StringVar(&files, "files", forms.RecordsExportFilesName, "how to render the file fields (name or url)")
+	command.Flags().StringVarP(&output, "output", "o", "", "the output file path (default stdout)")
+
+	return command
+}
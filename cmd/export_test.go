@@ -0,0 +1,88 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestExportCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	outputFile := filepath.Join(t.TempDir(), "export.csv")
+
+	scenarios := []struct {
+		name           string
+		args           []string
+		expectError    bool
+		expectedOutput string
+	}{
+		{
+			"missing collection argument",
+			[]string{},
+			true,
+			"",
+		},
+		{
+			"missing collection",
+			[]string{"missing"},
+			true,
+			"",
+		},
+		{
+			"invalid format",
+			[]string{"demo2", "--format=xml"},
+			true,
+			"",
+		},
+		{
+			"export to stdout",
+			[]string{"demo2", "--format=ndjson", "--fields=id", "--filter=active=true", "--sort=title"},
+			false,
+			`{"id":"achvryl401bhse3"}` + "\n" + `{"id":"0yxhwia2amd8gec"}` + "\n",
+		},
+		{
+			// nil list rule collection (the command always has full access)
+			"export to file",
+			[]string{"demo1", "--format=csv", "--fields=id,text", "--filter=id='84nmscqy84lsi1t'", "-o", outputFile},
+			false,
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		var stdout bytes.Buffer
+
+		command := cmd.NewExportCommand(app)
+		command.SetArgs(s.args)
+		command.SetOut(&stdout)
+
+		err := command.Execute()
+
+		hasErr := err != nil
+		if s.expectError != hasErr {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if stdout.String() != s.expectedOutput {
+			t.Errorf("[%s] Expected output %q, got %q", s.name, s.expectedOutput, stdout.String())
+		}
+	}
+
+	raw, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(raw), "id,text\n84nmscqy84lsi1t,") {
+		t.Fatalf("Unexpected output file content %q", raw)
+	}
+}
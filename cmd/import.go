@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/spf13/cobra"
+)
+
+// NewImportCommand creates and returns new command for importing
+// CSV or NDJSON records into a single collection.
+func NewImportCommand(app core.App) *cobra.Command {
+	var format, mode string
+	var dryRun bool
+	var batchSize int
+	var mapping []string
+
+	command := &cobra.Command{
+		Use:     "import",
+		Example: "import posts posts.csv --mode=upsert --map=\"Post title:title,Body:content\"",
+		Short:   "Imports CSV or NDJSON records into a collection",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("Missing collection name or id and file path arguments.")
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", args[0])
+			}
+
+			form := forms.NewRecordsImport(app, collection)
+			form.Mode = mode
+			form.DryRun = dryRun
+			form.BatchSize = batchSize
+
+			if format != "" {
+				form.Format = format
+			} else if ext := strings.ToLower(filepath.Ext(args[1])); ext == ".ndjson" || ext == ".jsonl" {
+				form.Format = forms.RecordsImportFormatNDJSON
+			}
+
+			if len(mapping) > 0 {
+				form.Mapping = make(map[string]string, len(mapping))
+				for _, m := range mapping {
+					column, field, ok := strings.Cut(m, ":")
+					if !ok {
+						return fmt.Errorf("Invalid mapping %q (expected column:field).", m)
+					}
+					form.Mapping[column] = field
+				}
+			}
+
+			f, err := os.Open(args[1])
+			if err != nil {
+				return fmt.Errorf("Failed to open the import file: %v", err)
+			}
+			defer f.Close()
+
+			result, err := form.Submit(f)
+			if err != nil {
+				return fmt.Errorf("Failed to import the collection records: %v", err)
+			}
+
+			encoder := json.NewEncoder(command.OutOrStdout())
+			encoder.SetIndent("", "  ")
+
+			return encoder.Encode(result)
+		},
+	}
+
+	command.Flags().StringVar(&format, "format", "", "the import format (csv or ndjson; default based on the file extension)")
+	command.Flags().StringVar(&mode, "mode", forms.RecordsImportModeInsert, "the import mode (insert or upsert)")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "validate and report the rows without persisting them")
+	command.Flags().IntVar(&batchSize, "batch-size", forms.DefaultRecordsImportBatchSize, "the number of records committed per transaction")
+	command.Flags().StringSliceVar(&mapping, "map", nil, "comma separated list of column:field mappings")
+
+	return command
+}
@@ -0,0 +1,141 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestImportCommand(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	csvFile := filepath.Join(dir, "import.csv")
+	if err := os.WriteFile(csvFile, []byte("Name,active\nnew1,true\nx,false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ndjsonFile := filepath.Join(dir, "import.ndjson")
+	if err := os.WriteFile(ndjsonFile, []byte(`{"title":"new2"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name             string
+		args             []string
+		expectError      bool
+		expectedOutputs  []string
+		expectedCreated  []string
+		expectedMissings []string
+	}{
+		{
+			"missing arguments",
+			[]string{"demo2"},
+			true,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"missing collection",
+			[]string{"missing", csvFile},
+			true,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"missing file",
+			[]string{"demo2", filepath.Join(dir, "missing.csv")},
+			true,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"invalid mapping",
+			[]string{"demo2", csvFile, "--map=Name"},
+			true,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"invalid mode",
+			[]string{"demo2", csvFile, "--mode=replace"},
+			true,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"csv dry run",
+			[]string{"demo2", csvFile, "--map=Name:title", "--dry-run"},
+			false,
+			[]string{`"dryRun": true`, `"created": 1`, `"failed": 1`, `"row": 2`},
+			nil,
+			[]string{"new1"},
+		},
+		{
+			"csv import",
+			[]string{"demo2", csvFile, "--map=Name:title", "--batch-size=1"},
+			false,
+			[]string{`"dryRun": false`, `"created": 1`, `"failed": 1`, `"row": 2`},
+			[]string{"new1"},
+			nil,
+		},
+		{
+			"ndjson import (inferred from the file extension)",
+			[]string{"demo2", ndjsonFile},
+			false,
+			[]string{`"created": 1`, `"failed": 0`},
+			[]string{"new2"},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			var stdout bytes.Buffer
+
+			command := cmd.NewImportCommand(app)
+			command.SetArgs(s.args)
+			command.SetOut(&stdout)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+				return
+			}
+
+			for _, str := range s.expectedOutputs {
+				if !strings.Contains(stdout.String(), str) {
+					t.Errorf("[%s] Cannot find %q in the output \n%s", s.name, str, stdout.String())
+				}
+			}
+
+			for _, title := range s.expectedCreated {
+				if _, err := app.Dao().FindFirstRecordByData("demo2", "title", title); err != nil {
+					t.Errorf("[%s] Expected record %q to be created, got %v", s.name, title, err)
+				}
+			}
+
+			for _, title := range s.expectedMissings {
+				if _, err := app.Dao().FindFirstRecordByData("demo2", "title", title); err == nil {
+					t.Errorf("[%s] Expected record %q to be missing", s.name, title)
+				}
+			}
+		}()
+	}
+}
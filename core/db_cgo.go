@@ -7,6 +7,7 @@ import (
 
 	"github.com/mattn/go-sqlite3"
 	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/search"
 )
 
 func init() {
@@ -31,8 +32,11 @@ func init() {
 					PRAGMA temp_store         = MEMORY;
 					PRAGMA cache_size         = -16000;
 				`, nil)
+				if err != nil {
+					return err
+				}
 
-				return err
+				return conn.RegisterFunc(search.GeoDistanceFunctionName, sqlGeoDistance, true)
 			},
 		},
 	)
@@ -0,0 +1,23 @@
+package core
+
+import (
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+// sqlGeoDistance is the implementation of the custom
+// [search.GeoDistanceFunctionName] SQLite function.
+//
+// Returns NULL if any of the arguments is NULL.
+func sqlGeoDistance(lonA, latA, lonB, latB any) any {
+	if lonA == nil || latA == nil || lonB == nil || latB == nil {
+		return nil
+	}
+
+	return search.GeoDistance(
+		cast.ToFloat64(lonA),
+		cast.ToFloat64(latA),
+		cast.ToFloat64(lonB),
+		cast.ToFloat64(latB),
+	)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
 )
 
 // New creates a new Dao instance with the provided db builder
@@ -45,6 +46,9 @@ type Dao struct {
 	// This field has no effect if an explicit query context is already specified.
 	ModelQueryTimeout time.Duration
 
+	// encryptionKey is the key used to encrypt and decrypt the records encrypted fields values.
+	encryptionKey string
+
 	// write hooks
 	BeforeCreateFunc func(eventDao *Dao, m models.Model, action func() error) error
 	AfterCreateFunc  func(eventDao *Dao, m models.Model) error
@@ -77,6 +81,20 @@ func (dao *Dao) NonconcurrentDB() dbx.Builder {
 	return dao.nonconcurrentDB
 }
 
+// EncryptionKey returns the key used by the Dao to encrypt and
+// decrypt the records encrypted fields values.
+func (dao *Dao) EncryptionKey() string {
+	return dao.encryptionKey
+}
+
+// SetEncryptionKey sets the key used by the Dao to encrypt and
+// decrypt the records encrypted fields values.
+//
+// The key is inherited by the Dao clones and transaction Daos.
+func (dao *Dao) SetEncryptionKey(key string) {
+	dao.encryptionKey = key
+}
+
 // Clone returns a new Dao with the same configuration options as the current one.
 func (dao *Dao) Clone() *Dao {
 	clone := *dao
@@ -137,6 +155,7 @@ func (dao *Dao) RunInTransaction(fn func(txDao *Dao) error) error {
 		txDao := New(txOrDB)
 		txDao.MaxLockRetries = dao.MaxLockRetries
 		txDao.ModelQueryTimeout = dao.ModelQueryTimeout
+		txDao.encryptionKey = dao.encryptionKey
 		txDao.BeforeCreateFunc = dao.BeforeCreateFunc
 		txDao.BeforeUpdateFunc = dao.BeforeUpdateFunc
 		txDao.BeforeDeleteFunc = dao.BeforeDeleteFunc
@@ -150,6 +169,7 @@ func (dao *Dao) RunInTransaction(fn func(txDao *Dao) error) error {
 
 		txError := txOrDB.Transactional(func(tx *dbx.Tx) error {
 			txDao := New(tx)
+			txDao.encryptionKey = dao.encryptionKey
 
 			if dao.BeforeCreateFunc != nil {
 				txDao.BeforeCreateFunc = func(eventDao *Dao, m models.Model, action func() error) error {
@@ -370,3 +390,92 @@ func (dao *Dao) lockRetry(op func(retryDao *Dao) error) error {
 		return op(retryDao)
 	}, dao.MaxLockRetries)
 }
+
+// ErrSavepointFailed is returned by [Dao.RunInSavepoint] (joined with the
+// fn error, if any) when the savepoint couldn't be reverted or released.
+//
+// In this case the transaction state is unknown and it must be rolled back.
+var ErrSavepointFailed = errors.New("failed to revert or release the savepoint")
+
+// RunInSavepoint executes fn within a new savepoint of the current dao
+// transaction and reverts its changes if fn returns an error.
+//
+// The after create/update/delete hooks of the savepoint changes are
+// forwarded to the current dao hooks only if fn succeeds.
+//
+// If the savepoint couldn't be reverted or released, the returned error
+// also wraps [ErrSavepointFailed] and the transaction must be aborted.
+//
+// NB! The dao is expected to be a transaction dao (see [Dao.RunInTransaction]).
+func (dao *Dao) RunInSavepoint(fn func(spDao *Dao) error) error {
+	tx, ok := dao.NonconcurrentDB().(*dbx.Tx)
+	if !ok {
+		return errors.New("failed to start savepoint (the dao is not in a transaction)")
+	}
+
+	afterCalls := []afterCallGroup{}
+
+	spDao := dao.Clone()
+	if dao.AfterCreateFunc != nil {
+		spDao.AfterCreateFunc = func(eventDao *Dao, m models.Model) error {
+			afterCalls = append(afterCalls, afterCallGroup{"create", eventDao, m})
+			return nil
+		}
+	}
+	if dao.AfterUpdateFunc != nil {
+		spDao.AfterUpdateFunc = func(eventDao *Dao, m models.Model) error {
+			afterCalls = append(afterCalls, afterCallGroup{"update", eventDao, m})
+			return nil
+		}
+	}
+	if dao.AfterDeleteFunc != nil {
+		spDao.AfterDeleteFunc = func(eventDao *Dao, m models.Model) error {
+			afterCalls = append(afterCalls, afterCallGroup{"delete", eventDao, m})
+			return nil
+		}
+	}
+
+	savepoint := "__pb_savepoint_" + security.PseudorandomString(5)
+
+	if _, err := tx.NewQuery("SAVEPOINT " + savepoint).Execute(); err != nil {
+		return err
+	}
+
+	if err := fn(spDao); err != nil {
+		if _, rollbackErr := tx.NewQuery("ROLLBACK TO " + savepoint).Execute(); rollbackErr != nil {
+			return errors.Join(err, fmt.Errorf("%w: %w", ErrSavepointFailed, rollbackErr))
+		}
+
+		if _, releaseErr := tx.NewQuery("RELEASE " + savepoint).Execute(); releaseErr != nil {
+			return errors.Join(err, fmt.Errorf("%w: %w", ErrSavepointFailed, releaseErr))
+		}
+
+		return err
+	}
+
+	if _, err := tx.NewQuery("RELEASE " + savepoint).Execute(); err != nil {
+		return fmt.Errorf("%w: %w", ErrSavepointFailed, err)
+	}
+
+	var errs []error
+	for _, call := range afterCalls {
+		var err error
+		switch call.Action {
+		case "create":
+			err = dao.AfterCreateFunc(call.EventDao, call.Model)
+		case "update":
+			err = dao.AfterUpdateFunc(call.EventDao, call.Model)
+		case "delete":
+			err = dao.AfterDeleteFunc(call.EventDao, call.Model)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("after savepoint errors: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -325,6 +325,103 @@ func TestDaoRunInTransaction(t *testing.T) {
 	}
 }
 
+func TestDaoRunInSavepoint(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	// not in a transaction
+	if err := testApp.Dao().RunInSavepoint(func(spDao *daos.Dao) error { return nil }); err == nil {
+		t.Fatal("Expected error outside of a transaction")
+	}
+
+	afterDeleteFuncCalls := 0
+
+	baseDao := testApp.Dao()
+	baseDao.AfterDeleteFunc = func(eventDao *daos.Dao, m models.Model) error {
+		afterDeleteFuncCalls++
+		return nil
+	}
+
+	err := baseDao.RunInTransaction(func(txDao *daos.Dao) error {
+		// failed savepoint
+		spErr := txDao.RunInSavepoint(func(spDao *daos.Dao) error {
+			admin, _ := spDao.FindAdminByEmail("test@example.com")
+			if err := spDao.DeleteAdmin(admin); err != nil {
+				t.Fatal(err)
+			}
+			return errors.New("test error")
+		})
+		if spErr == nil {
+			t.Fatal("Expected the savepoint error to be returned")
+		}
+
+		// successful savepoint
+		return txDao.RunInSavepoint(func(spDao *daos.Dao) error {
+			admin, _ := spDao.FindAdminByEmail("test2@example.com")
+			return spDao.DeleteAdmin(admin)
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if admin, _ := testApp.Dao().FindAdminByEmail("test@example.com"); admin == nil {
+		t.Fatal("Expected admin test@example.com to not be deleted")
+	}
+
+	if admin, _ := testApp.Dao().FindAdminByEmail("test2@example.com"); admin != nil {
+		t.Fatal("Expected admin test2@example.com to be deleted")
+	}
+
+	// only the successful savepoint after hook should be called
+	if afterDeleteFuncCalls != 1 {
+		t.Fatalf("Expected afterDeleteFuncCalls to be called 1 times, got %d", afterDeleteFuncCalls)
+	}
+}
+
+func TestDaoRunInSavepointFailedRollback(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	testErr := errors.New("test error")
+
+	var spErr error
+
+	txErr := testApp.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		spErr = txDao.RunInSavepoint(func(spDao *daos.Dao) error {
+			admin, _ := spDao.FindAdminByEmail("test@example.com")
+			if err := spDao.DeleteAdmin(admin); err != nil {
+				t.Fatal(err)
+			}
+
+			// end the entire transaction to make the savepoint rollback fail
+			if _, err := spDao.NonconcurrentDB().NewQuery("ROLLBACK").Execute(); err != nil {
+				t.Fatal(err)
+			}
+
+			return testErr
+		})
+
+		return spErr
+	})
+
+	if !errors.Is(spErr, testErr) {
+		t.Fatalf("Expected the savepoint error to wrap the fn error, got %v", spErr)
+	}
+
+	if !errors.Is(spErr, daos.ErrSavepointFailed) {
+		t.Fatalf("Expected the savepoint error to wrap ErrSavepointFailed, got %v", spErr)
+	}
+
+	if txErr == nil {
+		t.Fatal("Expected the transaction to fail")
+	}
+
+	if admin, _ := testApp.Dao().FindAdminByEmail("test@example.com"); admin == nil {
+		t.Fatal("Expected admin test@example.com to not be deleted")
+	}
+}
+
 func TestDaoSaveCreate(t *testing.T) {
 	testApp, _ := tests.NewTestApp()
 	defer testApp.Cleanup()
@@ -148,6 +148,16 @@ func (dao *Dao) DeleteCollection(collection *models.Collection) error {
 			if err := txDao.DeleteTable(collection.Name); err != nil {
 				return err
 			}
+
+			if len(collection.SearchFields()) > 0 {
+				if err := txDao.DeleteTable(collection.SearchTableName()); err != nil {
+					return err
+				}
+			}
+
+			if err := txDao.deleteCollectionRevisions(collection.Id); err != nil {
+				return err
+			}
 		}
 
 		// trigger views resave to check for dependencies
@@ -415,6 +425,7 @@ func (dao *Dao) normalizeViewQueryId(query string) (string, error) {
 	if idField != nil && idField.field != nil &&
 		idField.field.Type != schema.FieldTypeJson &&
 		idField.field.Type != schema.FieldTypeNumber &&
+		idField.field.Type != schema.FieldTypeDecimal &&
 		idField.field.Type != schema.FieldTypeBool {
 		needWrapping = false
 	}
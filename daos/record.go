@@ -20,12 +20,60 @@ import (
 	"github.com/spf13/cast"
 )
 
+// ErrRecordETagMismatch is returned when the persisted record
+// state doesn't match the expected version (aka. ETag).
+var ErrRecordETagMismatch = errors.New("the record was modified since it was last fetched")
+
 // RecordQuery returns a new Record select query from a collection model, id or name.
 //
 // In case a collection id or name is provided and that collection doesn't
 // actually exists, the generated query will be created with a cancelled context
 // and will fail once an executor (Row(), One(), All(), etc.) is called.
+//
+// If the collection has soft delete enabled, the trashed records are excluded
+// from the query result with a WHERE condition (so make sure to use
+// AndWhere() and OrWhere() when applying additional filters).
+// Use [Dao.TrashedRecordQuery] if you want to query the trashed records.
 func (dao *Dao) RecordQuery(collectionModelOrIdentifier any) *dbx.SelectQuery {
+	query, collection := dao.recordQuery(collectionModelOrIdentifier)
+
+	if collection != nil && collection.HasSoftDelete() {
+		query.AndWhere(trashExpr(collection.Name, false))
+	}
+
+	return query
+}
+
+// TrashedRecordQuery returns a new select query for the trashed (aka. soft deleted)
+// records of a collection model, id or name.
+//
+// The query will not return any records if the collection doesn't have soft delete enabled.
+func (dao *Dao) TrashedRecordQuery(collectionModelOrIdentifier any) *dbx.SelectQuery {
+	query, collection := dao.recordQuery(collectionModelOrIdentifier)
+
+	if collection != nil && collection.HasSoftDelete() {
+		query.AndWhere(trashExpr(collection.Name, true))
+	} else {
+		query.AndWhere(dbx.NewExp("1=0"))
+	}
+
+	return query
+}
+
+// trashExpr returns an expression that matches the trashed or
+// the active records of the specified record table (or table alias).
+func trashExpr(tableName string, trashed bool) dbx.Expression {
+	op := "="
+	if trashed {
+		op = "!="
+	}
+
+	return dbx.NewExp(fmt.Sprintf("[[%s.%s]] %s ''", tableName, schema.FieldNameDeleted, op))
+}
+
+// recordQuery returns a new Record select query from a collection model, id or name
+// together with the resolved collection (if any) without filtering the trashed records.
+func (dao *Dao) recordQuery(collectionModelOrIdentifier any) (*dbx.SelectQuery, *models.Collection) {
 	var tableName string
 	var collection *models.Collection
 	var collectionErr error
@@ -63,7 +111,7 @@ func (dao *Dao) RecordQuery(collectionModelOrIdentifier any) *dbx.SelectQuery {
 		cancelFunc()
 	}
 
-	return query.WithBuildHook(func(q *dbx.Query) {
+	query.WithBuildHook(func(q *dbx.Query) {
 		q.WithExecHook(execLockRetry(dao.ModelQueryTimeout, dao.MaxLockRetries)).
 			WithOneHook(func(q *dbx.Query, a any, op func(b any) error) error {
 				switch v := a.(type) {
@@ -78,6 +126,9 @@ func (dao *Dao) RecordQuery(collectionModelOrIdentifier any) *dbx.SelectQuery {
 					}
 
 					record := models.NewRecordFromNullStringMap(collection, row)
+					if err := dao.DecryptRecords(record); err != nil {
+						return err
+					}
 
 					*v = *record
 
@@ -99,6 +150,9 @@ func (dao *Dao) RecordQuery(collectionModelOrIdentifier any) *dbx.SelectQuery {
 					}
 
 					records := models.NewRecordsFromNullStringMaps(collection, rows)
+					if err := dao.DecryptRecords(records...); err != nil {
+						return err
+					}
 
 					*v = records
 
@@ -114,6 +168,9 @@ func (dao *Dao) RecordQuery(collectionModelOrIdentifier any) *dbx.SelectQuery {
 					}
 
 					records := models.NewRecordsFromNullStringMaps(collection, rows)
+					if err := dao.DecryptRecords(records...); err != nil {
+						return err
+					}
 
 					nonPointers := make([]models.Record, len(records))
 					for i, r := range records {
@@ -128,6 +185,8 @@ func (dao *Dao) RecordQuery(collectionModelOrIdentifier any) *dbx.SelectQuery {
 				}
 			})
 	})
+
+	return query, collection
 }
 
 // FindRecordById finds the Record model by its id.
@@ -366,6 +425,9 @@ func (dao *Dao) FindFirstRecordByFilter(
 // as a serialized json strings (eg. `["a","b"]`), so the value uniqueness
 // depends on the elements order. Or in other words the following values
 // are considered different: `[]string{"a","b"}` and `[]string{"b","a"}`
+//
+// The trashed (aka. soft deleted) records are also checked because
+// they still hold the collection unique indexes values.
 func (dao *Dao) IsRecordValueUnique(
 	collectionNameOrId string,
 	key string,
@@ -396,8 +458,8 @@ func (dao *Dao) IsRecordValueUnique(
 		expr = dbx.HashExp{inflector.Columnify(key): normalizedVal}
 	}
 
-	query := dao.RecordQuery(collection).
-		Select("count(*)").
+	query, _ := dao.recordQuery(collection)
+	query.Select("count(*)").
 		AndWhere(expr).
 		Limit(1)
 
@@ -410,6 +472,34 @@ func (dao *Dao) IsRecordValueUnique(
 	return query.Row(&exists) == nil && !exists
 }
 
+// CheckRecordETag checks whether the latest persisted state of the
+// provided record matches the specified "If-Match" etag(s).
+//
+// Returns ErrRecordETagMismatch if the record was modified or deleted
+// in the meantime. An empty ifMatch value is always considered valid.
+//
+// To prevent race conditions, the check should be performed
+// within the same transaction as the record save/delete.
+func (dao *Dao) CheckRecordETag(record *models.Record, ifMatch string) error {
+	if ifMatch == "" {
+		return nil
+	}
+
+	latest, err := dao.FindRecordById(record.Collection().Id, record.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRecordETagMismatch
+		}
+		return err
+	}
+
+	if !latest.MatchETag(ifMatch) {
+		return ErrRecordETagMismatch
+	}
+
+	return nil
+}
+
 // FindAuthRecordByToken finds the auth record associated with the provided JWT.
 //
 // Returns an error if the JWT is invalid, expired or not associated to an auth collection record.
@@ -559,19 +649,30 @@ func (dao *Dao) CanAccessRecord(record *models.Record, requestInfo *models.Reque
 		return true, nil
 	}
 
+	return dao.RecordMatchesFilter(record, requestInfo, *accessRule)
+}
+
+// RecordMatchesFilter checks whether the persisted state of the provided
+// record satisfies the filter expression (eg. "endDate > startDate").
+//
+// The requestInfo is used to resolve the "@request.*" filter identifiers and could be nil.
+// Note that unlike [Dao.CanAccessRecord] the filter is always evaluated, even for admins.
+func (dao *Dao) RecordMatchesFilter(record *models.Record, requestInfo *models.RequestInfo, filter string) (bool, error) {
 	var exists bool
 
 	query := dao.RecordQuery(record.Collection()).
 		Select("(1)").
 		AndWhere(dbx.HashExp{record.Collection().Name + ".id": record.Id})
 
-	// parse and apply the access rule filter
+	// parse and apply the filter
 	resolver := resolvers.NewRecordFieldResolver(dao, record.Collection(), requestInfo, true)
-	expr, err := search.FilterData(*accessRule).BuildExpr(resolver)
+	expr, err := search.FilterData(filter).BuildExpr(resolver)
 	if err != nil {
 		return false, err
 	}
-	resolver.UpdateQuery(query)
+	if err := resolver.UpdateQuery(query); err != nil {
+		return false, err
+	}
 	query.AndWhere(expr)
 
 	if err := query.Limit(1).Row(&exists); err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -581,11 +682,106 @@ func (dao *Dao) CanAccessRecord(record *models.Record, requestInfo *models.Reque
 	return exists, nil
 }
 
+// HideRestrictedRecordsFields hides (see [models.Record.Hide]) the schema
+// fields of the provided records whose view rule is not satisfied by the request.
+//
+// In case of a rule error the related field is hidden for all records
+// and the first error is returned after checking the remaining fields.
+//
+// Note: Expects all records to be from the same collection!
+func (dao *Dao) HideRestrictedRecordsFields(records []*models.Record, requestInfo *models.RequestInfo) error {
+	if len(records) == 0 || (requestInfo != nil && requestInfo.Admin != nil) {
+		return nil // nothing to check
+	}
+
+	collection := records[0].Collection()
+
+	restrictedFields := []*schema.SchemaField{}
+	for _, field := range collection.Schema.Fields() {
+		if field.ViewRule != "" {
+			restrictedFields = append(restrictedFields, field)
+		}
+	}
+
+	if len(restrictedFields) == 0 {
+		return nil // no restricted fields
+	}
+
+	recordIds := make([]any, len(records))
+	for i, rec := range records {
+		recordIds[i] = rec.Id
+	}
+
+	tableName := dao.DB().QuoteSimpleColumnName(collection.Name)
+
+	var firstErr error
+
+	for _, field := range restrictedFields {
+		allowedIds := []string{}
+
+		// note: the trashed records are also checked since they could be listed too
+		query := dao.DB().Select(tableName + ".id").
+			From(collection.Name).
+			AndWhere(dbx.In(tableName+".id", recordIds...))
+
+		resolver := resolvers.NewRecordFieldResolver(dao, collection, requestInfo, true)
+		expr, err := search.FilterData(field.ViewRule).BuildExpr(resolver)
+		if err == nil {
+			resolver.UpdateQuery(query)
+			err = query.AndWhere(expr).Column(&allowedIds)
+		}
+
+		if err != nil {
+			for _, rec := range records {
+				rec.Hide(field.Name)
+			}
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to resolve the %q field view rule: %w", field.Name, err)
+			}
+			continue
+		}
+
+		allowed := make(map[string]struct{}, len(allowedIds))
+		for _, id := range allowedIds {
+			allowed[id] = struct{}{}
+		}
+
+		for _, rec := range records {
+			if _, ok := allowed[rec.Id]; !ok {
+				rec.Hide(field.Name)
+			}
+		}
+	}
+
+	return firstErr
+}
+
 // SaveRecord persists the provided Record model in the database.
 //
 // If record.IsNew() is true, the method will perform a create, otherwise an update.
 // To explicitly mark a record for update you can use record.MarkAsNotNew().
+//
+// If the record collection has enabled the "trackHistory" option,
+// a new record revision is also stored (see [models.Revision]).
+//
+// If the record collection has "searchFields", the record
+// full-text search index entry is also updated.
+//
+// On create, the empty sequence fields are assigned with their
+// next sequence value as part of the same transaction.
+//
+// The autodate fields are set to the current datetime based
+// on their "onCreate" and "onUpdate" options.
 func (dao *Dao) SaveRecord(record *models.Record) error {
+	// ensure that the encrypted fields could be encrypted
+	if hasFieldOfType(record.Collection(), schema.FieldTypeEncrypted) {
+		if err := models.CheckRecordEncryptionKey(dao.EncryptionKey()); err != nil {
+			return fmt.Errorf("unable to encrypt the record encrypted fields: %w", err)
+		}
+
+		record.SetEncryptionKey(dao.EncryptionKey())
+	}
+
 	if record.Collection().IsAuth() {
 		if record.Username() == "" {
 			return errors.New("unable to save auth record without username")
@@ -608,7 +804,175 @@ func (dao *Dao) SaveRecord(record *models.Record) error {
 		}
 	}
 
-	return dao.Save(record)
+	setRecordAutodates(record)
+
+	trackHistory := record.Collection().HasTrackHistory()
+	hasSearch := len(record.Collection().SearchFields()) > 0
+	hasComputed := hasFieldOfType(record.Collection(), schema.FieldTypeComputed)
+	isNew := record.IsNew()
+	hasSequence := isNew && hasFieldOfType(record.Collection(), schema.FieldTypeSequence)
+	hasIncrements := !isNew && len(record.DecimalIncrements()) > 0
+	hasDerived := hasDerivedFields(record.Collection())
+
+	if !trackHistory && !hasSearch && !hasComputed && !hasSequence && !hasIncrements && !hasDerived {
+		return dao.Save(record)
+	}
+
+	var assignedSequences []string
+	var derivedPrevValues map[string]any
+
+	err := dao.RunInTransaction(func(txDao *Dao) error {
+		if hasSequence {
+			var err error
+			assignedSequences, err = txDao.assignRecordSequences(record)
+			if err != nil {
+				return err
+			}
+		}
+
+		if hasDerived {
+			var err error
+			derivedPrevValues, err = txDao.assignRecordDerivedValues(record)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := txDao.saveRecordWithDerivedRetry(record, derivedPrevValues); err != nil {
+			return err
+		}
+
+		if hasComputed {
+			if err := txDao.refreshRecordComputedFields(record); err != nil {
+				return err
+			}
+		}
+
+		if hasIncrements {
+			if err := txDao.refreshRecordDecimalIncrements(record); err != nil {
+				return err
+			}
+		}
+
+		if hasSearch {
+			if err := txDao.saveRecordSearchEntry(record); err != nil {
+				return err
+			}
+		}
+
+		if !trackHistory {
+			return nil
+		}
+
+		return txDao.saveRecordRevision(record, isNew)
+	})
+
+	// the sequence and derived values were rolled back together with the transaction
+	if err != nil {
+		for _, name := range assignedSequences {
+			record.Set(name, "")
+		}
+		for name, v := range derivedPrevValues {
+			record.Set(name, v)
+		}
+	}
+
+	return err
+}
+
+// hasFieldOfType checks whether the collection has at least one schema field of the specified type.
+func hasFieldOfType(collection *models.Collection, fieldType string) bool {
+	for _, field := range collection.Schema.Fields() {
+		if field.Type == fieldType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setRecordAutodates sets the record autodate fields to the current
+// datetime based on their options and the record create/update state.
+func setRecordAutodates(record *models.Record) {
+	now := types.NowDateTime()
+	isNew := record.IsNew()
+
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeAutodate {
+			continue
+		}
+
+		field.InitOptions()
+		options, _ := field.Options.(*schema.AutodateOptions)
+		if options == nil {
+			continue
+		}
+
+		if (isNew && options.OnCreate) || (!isNew && options.OnUpdate) {
+			record.Set(field.Name, now)
+		}
+	}
+}
+
+// refreshRecordComputedFields loads the db generated values
+// of the record computed fields into the provided record model.
+func (dao *Dao) refreshRecordComputedFields(record *models.Record) error {
+	cols := []string{}
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Type == schema.FieldTypeComputed {
+			cols = append(cols, field.Name)
+		}
+	}
+
+	row := dbx.NullStringMap{}
+
+	err := dao.DB().Select(cols...).
+		From(record.Collection().Name).
+		AndWhere(dbx.HashExp{schema.FieldNameId: record.Id}).
+		Limit(1).
+		One(row)
+	if err != nil {
+		return fmt.Errorf("failed to refresh the record computed fields: %w", err)
+	}
+
+	for _, col := range cols {
+		if row[col].Valid {
+			record.Set(col, row[col].String)
+		} else {
+			record.Set(col, nil)
+		}
+	}
+
+	return nil
+}
+
+// refreshRecordDecimalIncrements reloads from the db the decimal fields
+// of the provided record that were incremented with the last update.
+func (dao *Dao) refreshRecordDecimalIncrements(record *models.Record) error {
+	cols := []string{}
+	for name := range record.DecimalIncrements() {
+		cols = append(cols, name)
+	}
+
+	row := dbx.NullStringMap{}
+
+	err := dao.DB().Select(cols...).
+		From(record.Collection().Name).
+		AndWhere(dbx.HashExp{schema.FieldNameId: record.Id}).
+		Limit(1).
+		One(row)
+	if err != nil {
+		return fmt.Errorf("failed to refresh the record decimal fields: %w", err)
+	}
+
+	// unscale the stored integers
+	stored := models.NewRecordFromNullStringMap(record.Collection(), row)
+
+	for _, col := range cols {
+		record.Set(col, stored.GetDecimal(col))
+	}
+
+	return nil
 }
 
 // DeleteRecord deletes the provided Record model.
@@ -618,7 +982,28 @@ func (dao *Dao) SaveRecord(record *models.Record) error {
 //
 // The delete operation may fail if the record is part of a required
 // reference in another record (aka. cannot be deleted or unset).
+//
+// If the record collection has soft delete enabled, the record is moved
+// to the trash instead (see [Dao.TrashRecord]). Already trashed records
+// are permanently deleted (see [Dao.PurgeRecord]).
 func (dao *Dao) DeleteRecord(record *models.Record) error {
+	if record.Collection().HasSoftDelete() && !record.IsTrashed() {
+		return dao.TrashRecord(record)
+	}
+
+	return dao.PurgeRecord(record)
+}
+
+// PurgeRecord permanently deletes the provided Record model
+// no matter of its collection soft delete option.
+//
+// This method will also cascade the delete operation to all linked
+// relational records, including the trashed ones (delete or unset,
+// depending on the rel settings).
+//
+// The delete operation may fail if the record is part of a required
+// reference in another record (aka. cannot be deleted or unset).
+func (dao *Dao) PurgeRecord(record *models.Record) error {
 	// fetch rel references (if any)
 	//
 	// note: the select is outside of the transaction to minimize
@@ -651,6 +1036,14 @@ func (dao *Dao) DeleteRecord(record *models.Record) error {
 			return err
 		}
 
+		if err := txDao.deleteRecordSearchEntry(record); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteRecordRevisions(record); err != nil {
+			return err
+		}
+
 		return txDao.cascadeRecordDelete(record, refs)
 	})
 }
@@ -661,19 +1054,7 @@ func (dao *Dao) DeleteRecord(record *models.Record) error {
 func (dao *Dao) cascadeRecordDelete(mainRecord *models.Record, refs map[*models.Collection][]*schema.SchemaField) error {
 	uniqueJsonEachAlias := "__je__" + security.PseudorandomString(4)
 
-	// @todo consider changing refs to a slice
-	//
-	// Sort the refs keys to ensure that the cascade events firing order is always the same.
-	// This is not necessary for the operation to function correctly but it helps having deterministic output during testing.
-	sortedRefKeys := make([]*models.Collection, 0, len(refs))
-	for k := range refs {
-		sortedRefKeys = append(sortedRefKeys, k)
-	}
-	sort.Slice(sortedRefKeys, func(i, j int) bool {
-		return sortedRefKeys[i].Name < sortedRefKeys[j].Name
-	})
-
-	for _, refCollection := range sortedRefKeys {
+	for _, refCollection := range sortedCollectionRefKeys(refs) {
 		fields, ok := refs[refCollection]
 
 		if refCollection.IsView() || !ok {
@@ -684,7 +1065,10 @@ func (dao *Dao) cascadeRecordDelete(mainRecord *models.Record, refs map[*models.
 			recordTableName := inflector.Columnify(refCollection.Name)
 			prefixedFieldName := recordTableName + "." + inflector.Columnify(field.Name)
 
-			query := dao.RecordQuery(refCollection).Distinct(true)
+			// note: the trashed records are also included to ensure
+			// that there are no dangling references on restore
+			query, _ := dao.recordQuery(refCollection)
+			query.Distinct(true)
 
 			if opt, ok := field.Options.(schema.MultiValuer); !ok || !opt.IsMultiple() {
 				query.AndWhere(dbx.HashExp{prefixedFieldName: mainRecord.Id})
@@ -713,6 +1097,9 @@ func (dao *Dao) cascadeRecordDelete(mainRecord *models.Record, refs map[*models.
 				}
 
 				refRecords := models.NewRecordsFromNullStringMaps(refCollection, rows)
+				if err := dao.DecryptRecords(refRecords...); err != nil {
+					return err
+				}
 
 				err := dao.deleteRefRecords(mainRecord, refRecords, field)
 				if err != nil {
@@ -731,6 +1118,24 @@ func (dao *Dao) cascadeRecordDelete(mainRecord *models.Record, refs map[*models.
 	return nil
 }
 
+// sortedCollectionRefKeys returns the sorted by name refs keys.
+//
+// @todo consider changing refs to a slice
+//
+// Sort the refs keys to ensure that the cascade events firing order is always the same.
+// This is not necessary for the operation to function correctly but it helps having deterministic output during testing.
+func sortedCollectionRefKeys(refs map[*models.Collection][]*schema.SchemaField) []*models.Collection {
+	sortedRefKeys := make([]*models.Collection, 0, len(refs))
+	for k := range refs {
+		sortedRefKeys = append(sortedRefKeys, k)
+	}
+	sort.Slice(sortedRefKeys, func(i, j int) bool {
+		return sortedRefKeys[i].Name < sortedRefKeys[j].Name
+	})
+
+	return sortedRefKeys
+}
+
 // deleteRefRecords checks if related records has to be deleted (if `CascadeDelete` is set)
 // OR
 // just unset the record id from any relation field values (if they are not required).
@@ -756,7 +1161,7 @@ func (dao *Dao) deleteRefRecords(mainRecord *models.Record, refRecords []*models
 		// cascade delete the reference
 		// (only if there are no other active references in case of multiple select)
 		if options.CascadeDelete && len(ids) == 0 {
-			if err := dao.DeleteRecord(refRecord); err != nil {
+			if err := dao.PurgeRecord(refRecord); err != nil {
 				return err
 			}
 			// no further actions are needed (the reference is deleted)
@@ -0,0 +1,203 @@
+package daos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+// maxDerivedSuffix is the max collision suffix number
+// tried when generating a unique derived value.
+const maxDerivedSuffix = 1000
+
+// maxDerivedSaveRetries is the max number of save retries
+// on derived value UNIQUE constraint failure.
+const maxDerivedSaveRetries = 3
+
+// hasDerivedFields checks whether the collection has at least one
+// text field with DeriveFrom option (see [schema.TextOptions]).
+func hasDerivedFields(collection *models.Collection) bool {
+	for _, field := range collection.Schema.Fields() {
+		if field.Type != schema.FieldTypeText {
+			continue
+		}
+
+		field.InitOptions()
+		if options, _ := field.Options.(*schema.TextOptions); options != nil && options.DeriveFrom != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assignRecordDerivedValues generates the values of the record
+// text fields with DeriveFrom option (see [schema.TextOptions])
+// and returns the previous values of the generated fields.
+//
+// On update the record changes are compared with its original
+// (aka. initially loaded) state and not with the stored one
+// because the latter may not be accessible (eg. trashed records).
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) assignRecordDerivedValues(record *models.Record) (map[string]any, error) {
+	isNew := record.IsNew()
+
+	prevValues := map[string]any{}
+
+	// the original record state (lazy loaded)
+	var original *models.Record
+
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeText {
+			continue
+		}
+
+		field.InitOptions()
+		options, _ := field.Options.(*schema.TextOptions)
+		if options == nil || options.DeriveFrom == "" {
+			continue
+		}
+
+		current := record.GetString(field.Name)
+
+		shouldDerive := current == ""
+		if !shouldDerive && !isNew && options.DeriveOnUpdate {
+			if original == nil {
+				original = record.OriginalCopy()
+			}
+
+			sourceChanged := record.GetString(options.DeriveFrom) != original.GetString(options.DeriveFrom)
+			valueChanged := current != original.GetString(field.Name)
+			shouldDerive = sourceChanged && !valueChanged
+		}
+
+		if !shouldDerive {
+			continue
+		}
+
+		value, err := dao.uniqueDerivedValue(record, field, options)
+		if err != nil {
+			return prevValues, err
+		}
+
+		prevValues[field.Name] = record.Get(field.Name)
+
+		record.Set(field.Name, value)
+	}
+
+	return prevValues, nil
+}
+
+// saveRecordWithDerivedRetry saves the provided record and regenerates
+// its derived field values in case of a UNIQUE constraint failure
+// (eg. because of a concurrent save with the same derived value).
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) saveRecordWithDerivedRetry(record *models.Record, derived map[string]any) error {
+	retryDao := dao
+
+	for i := 0; ; i++ {
+		err := retryDao.Save(record)
+		if err == nil || i >= maxDerivedSaveRetries {
+			return err
+		}
+
+		fields := derivedUniqueFailureFields(record, derived, err)
+		if len(fields) == 0 {
+			return err
+		}
+
+		for _, field := range fields {
+			options, _ := field.Options.(*schema.TextOptions)
+
+			value, err := dao.uniqueDerivedValue(record, field, options)
+			if err != nil {
+				return err
+			}
+
+			record.Set(field.Name, value)
+		}
+
+		if i == 0 {
+			// clone the dao without the before hooks to avoid triggering
+			// the already fired before callbacks multiple times
+			retryDao = dao.Clone()
+			retryDao.BeforeCreateFunc = nil
+			retryDao.BeforeUpdateFunc = nil
+		}
+	}
+}
+
+// derivedUniqueFailureFields returns the derived record fields
+// that are listed in the provided UNIQUE constraint error (if any).
+func derivedUniqueFailureFields(record *models.Record, derived map[string]any, err error) []*schema.SchemaField {
+	msg := strings.ToLower(err.Error())
+	if !strings.Contains(msg, "unique constraint failed") {
+		return nil
+	}
+
+	// blank space to unify multi-columns lookup
+	msg = strings.ReplaceAll(strings.TrimSpace(msg), ",", " ") + " "
+
+	result := []*schema.SchemaField{}
+
+	collection := record.Collection()
+	for name := range derived {
+		if !strings.Contains(msg, strings.ToLower(collection.Name+"."+name+" ")) {
+			continue
+		}
+
+		if field := collection.Schema.GetFieldByName(name); field != nil {
+			result = append(result, field)
+		}
+	}
+
+	return result
+}
+
+// uniqueDerivedValue generates a new unique slug for the provided
+// derived text field, appending a numeric suffix on collision (eg. "hello-world-2").
+func (dao *Dao) uniqueDerivedValue(record *models.Record, field *schema.SchemaField, options *schema.TextOptions) (string, error) {
+	var maxLength int // 0 means no limit
+	if options.Max != nil {
+		maxLength = *options.Max
+	}
+
+	base := truncateSlug(inflector.Slugify(record.GetString(options.DeriveFrom)), maxLength)
+	if base == "" {
+		return "", nil
+	}
+
+	value := base
+
+	for i := 2; !dao.IsRecordValueUnique(record.Collection().Id, field.Name, value, record.Id); i++ {
+		if i > maxDerivedSuffix {
+			return "", fmt.Errorf("failed to generate unique %q value from %q", field.Name, base)
+		}
+
+		suffix := "-" + strconv.Itoa(i)
+
+		if maxLength > 0 {
+			value = truncateSlug(base, max(maxLength-len(suffix), 1)) + suffix
+		} else {
+			value = base + suffix
+		}
+	}
+
+	return value, nil
+}
+
+// truncateSlug truncates the provided ascii slug to maxLength
+// characters (0 means no limit), trimming any trailing separator.
+func truncateSlug(slug string, maxLength int) string {
+	if maxLength > 0 && len(slug) > maxLength {
+		slug = slug[:maxLength]
+	}
+
+	return strings.TrimRight(slug, "-")
+}
@@ -0,0 +1,216 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestSaveRecordWithDerivedFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	max := 12
+
+	collection := &models.Collection{
+		Name: "derive_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "title",
+				Type: schema.FieldTypeText,
+			},
+			&schema.SchemaField{
+				Name:    "slug",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{DeriveFrom: "title"},
+			},
+			&schema.SchemaField{
+				Name: "shortSlug",
+				Type: schema.FieldTypeText,
+				Options: &schema.TextOptions{
+					DeriveFrom:     "title",
+					DeriveOnUpdate: true,
+					Max:            &max,
+				},
+			},
+		),
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	save := func(record *models.Record) {
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	check := func(record *models.Record, expectedSlug, expectedShortSlug string) {
+		t.Helper()
+
+		if v := record.GetString("slug"); v != expectedSlug {
+			t.Fatalf("Expected slug %q, got %q", expectedSlug, v)
+		}
+
+		if v := record.GetString("shortSlug"); v != expectedShortSlug {
+			t.Fatalf("Expected shortSlug %q, got %q", expectedShortSlug, v)
+		}
+	}
+
+	// create
+	r1 := models.NewRecord(collection)
+	r1.Set("title", "Žluťoučký kůň!")
+	save(r1)
+	check(r1, "zlutoucky-kun", "zlutoucky-ku")
+
+	// collision
+	r2 := models.NewRecord(collection)
+	r2.Set("title", "Zlutoucky kun")
+	save(r2)
+	check(r2, "zlutoucky-kun-2", "zlutoucky-2")
+
+	r3 := models.NewRecord(collection)
+	r3.Set("title", "Zlutoucky kun")
+	save(r3)
+	check(r3, "zlutoucky-kun-3", "zlutoucky-3")
+
+	// explicit value
+	r4 := models.NewRecord(collection)
+	r4.Set("title", "Zlutoucky kun")
+	r4.Set("slug", "custom")
+	save(r4)
+	check(r4, "custom", "zlutoucky-4")
+
+	// empty source
+	r5 := models.NewRecord(collection)
+	r5.Set("title", "!!!")
+	save(r5)
+	check(r5, "", "")
+
+	// reload to simulate a regular update of a persisted record
+	// (the changes are compared with the initially loaded state)
+	reload := func(record *models.Record) *models.Record {
+		t.Helper()
+
+		reloaded, err := app.Dao().FindRecordById(collection.Id, record.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return reloaded
+	}
+
+	// update of the source field (only shortSlug has DeriveOnUpdate)
+	r1 = reload(r1)
+	r1.Set("title", "Hello world")
+	save(r1)
+	check(r1, "zlutoucky-kun", "hello-world")
+
+	// update with explicitly changed derived value
+	r1 = reload(r1)
+	r1.Set("title", "Hello again")
+	r1.Set("shortSlug", "manual")
+	save(r1)
+	check(r1, "zlutoucky-kun", "manual")
+
+	// resave without changes
+	r1 = reload(r1)
+	save(r1)
+	check(r1, "zlutoucky-kun", "manual")
+
+	// update with cleared derived value
+	r1.Set("slug", "")
+	save(r1)
+	check(r1, "hello-again", "manual")
+
+	// update of a trashed record
+	collection.Options = types.JsonMap{"softDelete": true}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	r2 = reload(r2)
+	if err := app.Dao().TrashRecord(r2); err != nil {
+		t.Fatal(err)
+	}
+	trashed, err := app.Dao().FindTrashedRecordById(collection.Id, r2.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trashed.Set("title", "Trashed title")
+	save(trashed)
+	check(trashed, "zlutoucky-kun-2", "trashed-titl")
+}
+
+func TestSaveRecordWithDerivedFieldsUniqueRetry(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "derive_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "title",
+				Type: schema.FieldTypeText,
+			},
+			&schema.SchemaField{
+				Name:    "slug",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{DeriveFrom: "title"},
+			},
+		),
+		Indexes: types.JsonArray[string]{
+			"CREATE UNIQUE INDEX idx_derive_test_slug ON derive_test (slug)",
+		},
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	dao := daos.New(app.Dao().DB())
+
+	// simulate a concurrent insert with the same derived value
+	beforeCalls := 0
+	dao.BeforeCreateFunc = func(eventDao *daos.Dao, m models.Model, action func() error) error {
+		beforeCalls++
+
+		_, err := eventDao.DB().NewQuery("INSERT INTO derive_test (id, title, slug) VALUES ('concurrent12345', 'Hello', 'hello')").Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return action()
+	}
+
+	record := models.NewRecord(collection)
+	record.Set("title", "Hello")
+
+	if err := dao.SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := record.GetString("slug"); v != "hello-2" {
+		t.Fatalf("Expected slug %q, got %q", "hello-2", v)
+	}
+
+	if beforeCalls != 1 {
+		t.Fatalf("Expected the before create hook to be called once, got %d", beforeCalls)
+	}
+
+	stored, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := stored.GetString("slug"); v != "hello-2" {
+		t.Fatalf("Expected stored slug %q, got %q", "hello-2", v)
+	}
+}
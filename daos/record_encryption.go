@@ -0,0 +1,186 @@
+package daos
+
+import (
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// reencryptBatchSize is the max number of records loaded at once during re-encryption.
+const reencryptBatchSize = 500
+
+// ReencryptRecords decrypts the encrypted fields values of all
+// collections records with oldKey and encrypts them again with newKey.
+//
+// The values are updated directly in the db (aka. without triggering the
+// model hooks) and the method returns the total number of updated records.
+//
+// Note that this method doesn't change the Dao encryption key
+// (see [Dao.SetEncryptionKey]).
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) ReencryptRecords(oldKey, newKey string) (int, error) {
+	if err := models.CheckRecordEncryptionKey(newKey); err != nil {
+		return 0, fmt.Errorf("invalid new encryption key: %w", err)
+	}
+
+	collections := []*models.Collection{}
+	err := dao.CollectionQuery().
+		AndWhere(dbx.Not(dbx.HashExp{"type": models.CollectionTypeView})).
+		OrderBy("created ASC").
+		All(&collections)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+
+	for _, collection := range collections {
+		fields := []*schema.SchemaField{}
+		for _, field := range collection.Schema.Fields() {
+			if field.Type == schema.FieldTypeEncrypted {
+				fields = append(fields, field)
+			}
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		updated, err := dao.reencryptCollectionRecords(collection, fields, oldKey, newKey)
+		if err != nil {
+			return total, err
+		}
+
+		total += updated
+	}
+
+	return total, nil
+}
+
+// syncEncryptedBlindIndexes adds or removes the blind index of the
+// existing records encrypted fields values whose "blindIndex" option was changed.
+func (dao *Dao) syncEncryptedBlindIndexes(newCollection, oldCollection *models.Collection) error {
+	fields := []*schema.SchemaField{}
+
+	for _, field := range newCollection.Schema.Fields() {
+		if field.Type != schema.FieldTypeEncrypted {
+			continue
+		}
+
+		oldField := oldCollection.Schema.GetFieldById(field.Id)
+		if oldField == nil || oldField.Type != schema.FieldTypeEncrypted {
+			continue // new field
+		}
+
+		if hasBlindIndex(field) != hasBlindIndex(oldField) {
+			fields = append(fields, field)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	key := dao.EncryptionKey()
+
+	_, err := dao.reencryptCollectionRecords(newCollection, fields, key, key)
+
+	return err
+}
+
+func hasBlindIndex(field *schema.SchemaField) bool {
+	field.InitOptions()
+
+	options, _ := field.Options.(*schema.EncryptedOptions)
+
+	return options != nil && options.BlindIndex
+}
+
+// DecryptRecords decrypts the encrypted fields values of the provided
+// db loaded records with the Dao encryption key.
+//
+// The records fetched with [Dao.RecordQuery] are already decrypted and
+// it is intended to be used only with records initialized manually from
+// raw db rows (eg. with [models.NewRecordsFromNullStringMaps]).
+func (dao *Dao) DecryptRecords(records ...*models.Record) error {
+	for _, record := range records {
+		if err := record.DecryptFields(dao.EncryptionKey()); err != nil {
+			return fmt.Errorf("failed to load record %q: %w", record.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func (dao *Dao) reencryptCollectionRecords(
+	collection *models.Collection,
+	fields []*schema.SchemaField,
+	oldKey string,
+	newKey string,
+) (int, error) {
+	columns := make([]string, 0, len(fields)+1)
+	columns = append(columns, schema.FieldNameId)
+	for _, field := range fields {
+		columns = append(columns, field.Name)
+	}
+
+	var total int
+	var lastId string
+
+	for {
+		rows := []dbx.NullStringMap{}
+
+		err := dao.DB().Select(columns...).
+			From(collection.Name).
+			AndWhere(dbx.NewExp("[[id]] > {:lastId}", dbx.Params{"lastId": lastId})).
+			OrderBy("id ASC").
+			Limit(reencryptBatchSize).
+			All(&rows)
+		if err != nil {
+			return total, err
+		}
+
+		for _, row := range rows {
+			lastId = row[schema.FieldNameId].String
+
+			values := dbx.Params{}
+
+			for _, field := range fields {
+				stored := row[field.Name].String
+				if stored == "" {
+					continue
+				}
+
+				plain, err := models.DecryptFieldValue(stored, oldKey)
+				if err != nil {
+					return total, fmt.Errorf("failed to decrypt %s.%s of record %q: %w", collection.Name, field.Name, lastId, err)
+				}
+
+				values[field.Name], err = models.EncryptFieldValue(field, plain, newKey)
+				if err != nil {
+					return total, fmt.Errorf("failed to encrypt %s.%s of record %q: %w", collection.Name, field.Name, lastId, err)
+				}
+			}
+
+			if len(values) == 0 {
+				continue
+			}
+
+			_, err := dao.DB().Update(collection.Name, values, dbx.HashExp{schema.FieldNameId: lastId}).Execute()
+			if err != nil {
+				return total, err
+			}
+
+			total++
+		}
+
+		if len(rows) < reencryptBatchSize {
+			break
+		}
+	}
+
+	return total, nil
+}
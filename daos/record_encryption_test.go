@@ -0,0 +1,290 @@
+package daos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+const testEncryptionKey = "abcdabcdabcdabcdabcdabcdabcdabcd"
+
+func createEncryptedTestCollection(t *testing.T, dao *daos.Dao) *models.Collection {
+	collection := &models.Collection{
+		Name: "encrypted_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "title",
+				Type: schema.FieldTypeText,
+			},
+			&schema.SchemaField{
+				Name: "secret1",
+				Type: schema.FieldTypeEncrypted,
+			},
+			&schema.SchemaField{
+				Name:    "secret2",
+				Type:    schema.FieldTypeEncrypted,
+				Options: &schema.EncryptedOptions{BlindIndex: true},
+			},
+		),
+	}
+
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func findRawEncryptedValues(t *testing.T, dao *daos.Dao, recordId string) dbx.NullStringMap {
+	row := dbx.NullStringMap{}
+
+	err := dao.DB().Select("secret1", "secret2").
+		From("encrypted_test").
+		AndWhere(dbx.HashExp{"id": recordId}).
+		One(&row)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return row
+}
+
+func TestSaveRecordWithEncryptedFieldsAndMissingKey(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Dao().SetEncryptionKey("")
+
+	collection := createEncryptedTestCollection(t, app.Dao())
+
+	record := models.NewRecord(collection)
+	record.Set("secret1", "test")
+
+	if err := app.Dao().SaveRecord(record); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	// records without encrypted fields should be still saved
+	demo2, _ := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err := app.Dao().SaveRecord(demo2); err != nil {
+		t.Fatalf("Expected demo2 record to be saved, got %v", err)
+	}
+}
+
+func TestSaveRecordWithEncryptedFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Dao().SetEncryptionKey(testEncryptionKey)
+
+	collection := createEncryptedTestCollection(t, app.Dao())
+	enableTrackHistory(t, app.Dao(), collection.Name)
+	collection, _ = app.Dao().FindCollectionByNameOrId(collection.Name)
+
+	record := models.NewRecord(collection)
+	record.Set("title", "test")
+	record.Set("secret1", "secret_a")
+	record.Set("secret2", "secret_b")
+
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := findRawEncryptedValues(t, app.Dao(), record.Id)
+
+	for field, plain := range map[string]string{"secret1": "secret_a", "secret2": "secret_b"} {
+		if raw[field].String == "" || strings.Contains(raw[field].String, plain) {
+			t.Fatalf("Expected %s to be stored encrypted, got %q", field, raw[field].String)
+		}
+	}
+
+	expectedPrefix := models.FieldBlindIndex("secret_b", testEncryptionKey) + "$"
+	if !strings.HasPrefix(raw["secret2"].String, expectedPrefix) {
+		t.Fatalf("Expected secret2 to be prefixed with its blind index, got %q", raw["secret2"].String)
+	}
+
+	// the values should be transparently decrypted on read
+	found, err := app.Dao().FindRecordById(collection.Name, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := found.GetString("secret1"); v != "secret_a" {
+		t.Fatalf("Expected secret1 %q, got %q", "secret_a", v)
+	}
+	if v := found.GetString("secret2"); v != "secret_b" {
+		t.Fatalf("Expected secret2 %q, got %q", "secret_b", v)
+	}
+
+	// blind index lookup
+	found, err = app.Dao().FindFirstRecordByFilter(collection.Name, "secret2 = {:secret}", dbx.Params{"secret": "secret_b"})
+	if err != nil || found.Id != record.Id {
+		t.Fatalf("Expected to find the record by its blind index, got %v (%v)", found, err)
+	}
+
+	// the encrypted fields shouldn't be stored in the record revisions
+	rev, err := app.Dao().FindLatestRecordRevision(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rev.Data["secret1"]; ok {
+		t.Fatalf("Expected secret1 to be excluded from the revision data, got %v", rev.Data)
+	}
+	if _, ok := rev.Data["secret2"]; ok {
+		t.Fatalf("Expected secret2 to be excluded from the revision data, got %v", rev.Data)
+	}
+	if v := rev.Data.Get("title"); v != "test" {
+		t.Fatalf("Expected revision title %q, got %v", "test", v)
+	}
+}
+
+func TestReencryptRecords(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Dao().SetEncryptionKey(testEncryptionKey)
+
+	collection := createEncryptedTestCollection(t, app.Dao())
+
+	record1 := models.NewRecord(collection)
+	record1.Set("secret1", "a")
+	record1.Set("secret2", "b")
+	if err := app.Dao().SaveRecord(record1); err != nil {
+		t.Fatal(err)
+	}
+
+	// record with empty encrypted fields
+	record2 := models.NewRecord(collection)
+	record2.Set("title", "test")
+	if err := app.Dao().SaveRecord(record2); err != nil {
+		t.Fatal(err)
+	}
+
+	newKey := strings.Repeat("z", 32)
+
+	if _, err := app.Dao().ReencryptRecords(testEncryptionKey, "invalid"); err == nil {
+		t.Fatal("Expected invalid new key error")
+	}
+
+	if _, err := app.Dao().ReencryptRecords(strings.Repeat("x", 32), newKey); err == nil {
+		t.Fatal("Expected decrypt error with invalid old key")
+	}
+
+	total, err := app.Dao().ReencryptRecords(testEncryptionKey, newKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected 1 updated record, got %d", total)
+	}
+
+	raw := findRawEncryptedValues(t, app.Dao(), record1.Id)
+
+	for field, plain := range map[string]string{"secret1": "a", "secret2": "b"} {
+		if _, err := models.DecryptFieldValue(raw[field].String, testEncryptionKey); err == nil {
+			t.Fatalf("Expected %s to be no longer decryptable with the old key", field)
+		}
+
+		v, err := models.DecryptFieldValue(raw[field].String, newKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != plain {
+			t.Fatalf("Expected %s %q, got %q", field, plain, v)
+		}
+	}
+
+	expectedPrefix := models.FieldBlindIndex("b", newKey) + "$"
+	if !strings.HasPrefix(raw["secret2"].String, expectedPrefix) {
+		t.Fatalf("Expected secret2 to be prefixed with the new key blind index, got %q", raw["secret2"].String)
+	}
+}
+
+func TestFindRecordWithEncryptedFieldsAndInvalidKey(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Dao().SetEncryptionKey(testEncryptionKey)
+
+	collection := createEncryptedTestCollection(t, app.Dao())
+
+	record := models.NewRecord(collection)
+	record.Set("secret1", "a")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	rawBefore := findRawEncryptedValues(t, app.Dao(), record.Id)
+
+	app.Dao().SetEncryptionKey(strings.Repeat("x", 32))
+
+	if _, err := app.Dao().FindRecordById(collection.Name, record.Id); err == nil {
+		t.Fatal("Expected FindRecordById decrypt error")
+	}
+
+	if _, err := app.Dao().FindRecordsByFilter(collection.Name, "id != ''", "", 0, 0); err == nil {
+		t.Fatal("Expected FindRecordsByFilter decrypt error")
+	}
+
+	rawAfter := findRawEncryptedValues(t, app.Dao(), record.Id)
+	if rawBefore["secret1"].String != rawAfter["secret1"].String {
+		t.Fatalf("Expected the stored secret1 value to remain unchanged, got %q", rawAfter["secret1"].String)
+	}
+}
+
+func TestSaveCollectionEncryptedBlindIndexChange(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Dao().SetEncryptionKey(testEncryptionKey)
+
+	collection := createEncryptedTestCollection(t, app.Dao())
+
+	record := models.NewRecord(collection)
+	record.Set("secret1", "a")
+	record.Set("secret2", "b")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// toggle the blind indexes
+	collection.Schema.GetFieldByName("secret1").Options = &schema.EncryptedOptions{BlindIndex: true}
+	collection.Schema.GetFieldByName("secret2").Options = &schema.EncryptedOptions{BlindIndex: false}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := findRawEncryptedValues(t, app.Dao(), record.Id)
+
+	expectedPrefix := models.FieldBlindIndex("a", testEncryptionKey) + "$"
+	if !strings.HasPrefix(raw["secret1"].String, expectedPrefix) {
+		t.Fatalf("Expected secret1 to be backfilled with its blind index, got %q", raw["secret1"].String)
+	}
+
+	if strings.Contains(raw["secret2"].String, "$") {
+		t.Fatalf("Expected secret2 blind index to be removed, got %q", raw["secret2"].String)
+	}
+
+	found, err := app.Dao().FindFirstRecordByFilter(collection.Name, "secret1 = 'a'")
+	if err != nil || found.Id != record.Id {
+		t.Fatalf("Expected to find the existing record by the backfilled blind index, got %v (%v)", found, err)
+	}
+	if v := found.GetString("secret2"); v != "b" {
+		t.Fatalf("Expected secret2 %q, got %q", "b", v)
+	}
+}
@@ -0,0 +1,130 @@
+package daos
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// HasFullTextSearchSupport checks whether the underlying SQLite
+// build supports the FTS5 extension required by the collections "searchFields" option.
+//
+// Note that the CGO mattn/go-sqlite3 driver requires the "sqlite_fts5" build tag.
+func (dao *Dao) HasFullTextSearchSupport() bool {
+	var enabled bool
+
+	err := dao.DB().NewQuery("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Row(&enabled)
+
+	return err == nil && enabled
+}
+
+// syncRecordSearchTable creates, recreates or drops the collection
+// FTS5 search table based on the collection "searchFields" option change.
+//
+// The search table is (re)populated from the record table
+// every time when its indexed fields are changed.
+//
+// If `oldCollection` is null, then only `newCollection` is used to create the search table.
+func (dao *Dao) syncRecordSearchTable(newCollection *models.Collection, oldCollection *models.Collection) error {
+	newFields := newCollection.SearchFields()
+	tableName := newCollection.SearchTableName()
+
+	if oldCollection == nil && len(newFields) == 0 {
+		return nil // nothing to create
+	}
+
+	if oldCollection != nil &&
+		strings.Join(oldCollection.SearchFields(), ",") == strings.Join(newFields, ",") &&
+		(len(newFields) == 0 || dao.HasTable(tableName)) {
+		return nil // no change
+	}
+
+	if err := dao.DeleteTable(tableName); err != nil {
+		return fmt.Errorf("failed to drop the search table %s - %w", tableName, err)
+	}
+
+	if len(newFields) == 0 {
+		return nil // no indexed fields
+	}
+
+	cols := make([]string, 0, len(newFields)+1)
+	cols = append(cols, "[["+schema.FieldNameId+"]] UNINDEXED")
+	for _, name := range newFields {
+		cols = append(cols, "[["+name+"]]")
+	}
+
+	_, err := dao.DB().NewQuery(fmt.Sprintf(
+		"CREATE VIRTUAL TABLE {{%s}} USING fts5(%s, tokenize='unicode61 remove_diacritics 2')",
+		tableName,
+		strings.Join(cols, ", "),
+	)).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to create the search table %s - %w", tableName, err)
+	}
+
+	// populate with the existing records (including the trashed ones)
+	insertCols := make([]string, 0, len(newFields)+1)
+	selectCols := make([]string, 0, len(newFields)+1)
+	insertCols = append(insertCols, "[["+schema.FieldNameId+"]]")
+	selectCols = append(selectCols, "[["+schema.FieldNameId+"]]")
+	for _, name := range newFields {
+		insertCols = append(insertCols, "[["+name+"]]")
+		selectCols = append(selectCols, "COALESCE([["+name+"]], '')")
+	}
+
+	_, err = dao.DB().NewQuery(fmt.Sprintf(
+		"INSERT INTO {{%s}} (%s) SELECT %s FROM {{%s}}",
+		tableName,
+		strings.Join(insertCols, ", "),
+		strings.Join(selectCols, ", "),
+		newCollection.Name,
+	)).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to populate the search table %s - %w", tableName, err)
+	}
+
+	return nil
+}
+
+// saveRecordSearchEntry upserts the full-text indexed field
+// values of the provided record in its collection search table.
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) saveRecordSearchEntry(record *models.Record) error {
+	fields := record.Collection().SearchFields()
+	if len(fields) == 0 {
+		return nil // no indexed fields
+	}
+
+	if err := dao.deleteRecordSearchEntry(record); err != nil {
+		return err
+	}
+
+	values := make(dbx.Params, len(fields)+1)
+	values[schema.FieldNameId] = record.Id
+	for _, name := range fields {
+		values[name] = record.GetString(name)
+	}
+
+	_, err := dao.DB().Insert(record.Collection().SearchTableName(), values).Execute()
+
+	return err
+}
+
+// deleteRecordSearchEntry deletes the full-text indexed field
+// values of the provided record from its collection search table.
+func (dao *Dao) deleteRecordSearchEntry(record *models.Record) error {
+	if len(record.Collection().SearchFields()) == 0 {
+		return nil // no indexed fields
+	}
+
+	_, err := dao.DB().Delete(
+		record.Collection().SearchTableName(),
+		dbx.HashExp{schema.FieldNameId: record.Id},
+	).Execute()
+
+	return err
+}
@@ -0,0 +1,161 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func findSearchEntries(t *testing.T, dao *daos.Dao, collection *models.Collection) map[string]string {
+	rows := []dbx.NullStringMap{}
+
+	err := dao.DB().Select("*").From(collection.SearchTableName()).All(&rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := make(map[string]string, len(rows))
+	for _, row := range rows {
+		result[row["id"].String] = row["title"].String
+	}
+
+	return result
+}
+
+func TestRecordSearchTableSync(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if !app.Dao().HasFullTextSearchSupport() {
+		t.Skip("FTS5 is not supported by the current SQLite build")
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if app.Dao().HasTable(collection.SearchTableName()) {
+		t.Fatalf("Didn't expect search table %q to exist", collection.SearchTableName())
+	}
+
+	// enable
+	collection.SetOptions(map[string]any{"searchFields": []string{"title"}})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := findSearchEntries(t, app.Dao(), collection)
+	expectedEntries := map[string]string{
+		"llvuca81nly1qls": "test1",
+		"achvryl401bhse3": "test2",
+		"0yxhwia2amd8gec": "test3",
+	}
+	if len(entries) != len(expectedEntries) {
+		t.Fatalf("Expected %d search entries, got %v", len(expectedEntries), entries)
+	}
+	for id, title := range expectedEntries {
+		if entries[id] != title {
+			t.Fatalf("Expected search entry %q to be %q, got %q", id, title, entries[id])
+		}
+	}
+
+	// rename the collection (the search table should remain)
+	collection.Name = "demo2_renamed"
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	if total := len(findSearchEntries(t, app.Dao(), collection)); total != 3 {
+		t.Fatalf("Expected 3 search entries after rename, got %d", total)
+	}
+
+	// disable
+	collection.SetOptions(map[string]any{})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasTable(collection.SearchTableName()) {
+		t.Fatalf("Expected search table %q to be dropped", collection.SearchTableName())
+	}
+
+	// new collection
+	newCollection := &models.Collection{
+		Name: "new_search",
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+		),
+	}
+	newCollection.SetOptions(map[string]any{"searchFields": []string{"title"}})
+	if err := app.Dao().SaveCollection(newCollection); err != nil {
+		t.Fatal(err)
+	}
+	if !app.Dao().HasTable(newCollection.SearchTableName()) {
+		t.Fatalf("Expected search table %q to be created", newCollection.SearchTableName())
+	}
+
+	// delete collection
+	if err := app.Dao().DeleteCollection(newCollection); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasTable(newCollection.SearchTableName()) {
+		t.Fatalf("Expected search table %q to be dropped", newCollection.SearchTableName())
+	}
+}
+
+func TestRecordSearchEntries(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if !app.Dao().HasFullTextSearchSupport() {
+		t.Skip("FTS5 is not supported by the current SQLite build")
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.SetOptions(map[string]any{"searchFields": []string{"title"}})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// create
+	record := models.NewRecord(collection)
+	record.Set("title", "new")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := findSearchEntries(t, app.Dao(), collection)[record.Id]; v != "new" {
+		t.Fatalf("Expected the created record search entry to be %q, got %q", "new", v)
+	}
+
+	// update
+	record.Set("title", "updated")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	entries := findSearchEntries(t, app.Dao(), collection)
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 search entries, got %v", entries)
+	}
+	if v := entries[record.Id]; v != "updated" {
+		t.Fatalf("Expected the updated record search entry to be %q, got %q", "updated", v)
+	}
+
+	// delete
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	entries = findSearchEntries(t, app.Dao(), collection)
+	if _, ok := entries[record.Id]; ok || len(entries) != 3 {
+		t.Fatalf("Expected the deleted record search entry to be removed, got %v", entries)
+	}
+}
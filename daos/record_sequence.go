@@ -0,0 +1,82 @@
+package daos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// NextSequenceValue increments and returns the next value of the
+// specified collection sequence field and scope (starting from 1).
+//
+// NB! To guarantee gapless sequences, the method is expected to be
+// called inside the same transaction that persists the value.
+func (dao *Dao) NextSequenceValue(collectionId string, fieldId string, scope string) (int, error) {
+	var value int
+
+	err := dao.DB().NewQuery(`
+		INSERT INTO {{_sequences}} ([[collectionId]], [[fieldId]], [[scope]], [[value]])
+		VALUES ({:collectionId}, {:fieldId}, {:scope}, 1)
+		ON CONFLICT ([[collectionId]], [[fieldId]], [[scope]]) DO UPDATE SET [[value]] = [[value]] + 1
+		RETURNING [[value]]
+	`).Bind(dbx.Params{
+		"collectionId": collectionId,
+		"fieldId":      fieldId,
+		"scope":        scope,
+	}).Row(&value)
+
+	return value, err
+}
+
+// assignRecordSequences assigns the next sequence value to the
+// empty sequence fields of the provided record and returns the
+// names of the assigned fields.
+func (dao *Dao) assignRecordSequences(record *models.Record) ([]string, error) {
+	assigned := []string{}
+
+	date := record.Created.Time()
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeSequence {
+			continue
+		}
+
+		// already assigned (the plain sequence numbers are 0 by default)
+		if current := record.GetString(field.Name); current != "" && current != "0" {
+			continue
+		}
+
+		field.InitOptions()
+		options, _ := field.Options.(*schema.SequenceOptions)
+		if options == nil {
+			options = &schema.SequenceOptions{}
+		}
+
+		var scope string
+		if options.Scope != "" {
+			scope = record.GetString(options.Scope)
+		}
+
+		next, err := dao.NextSequenceValue(record.Collection().Id, field.Id, scope)
+		if err != nil {
+			return assigned, fmt.Errorf("failed to generate the next %q sequence value: %w", field.Name, err)
+		}
+
+		value, err := schema.FormatSequence(options.Pattern, next, date)
+		if err != nil {
+			return assigned, fmt.Errorf("failed to format the %q sequence value: %w", field.Name, err)
+		}
+
+		record.Set(field.Name, value)
+
+		assigned = append(assigned, field.Name)
+	}
+
+	return assigned, nil
+}
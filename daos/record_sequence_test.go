@@ -0,0 +1,276 @@
+package daos_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createSequenceTestCollection(t *testing.T, dao *daos.Dao) *models.Collection {
+	collection := &models.Collection{
+		Name: "sequence_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "tenant",
+				Type: schema.FieldTypeText,
+			},
+			&schema.SchemaField{
+				Name: "number",
+				Type: schema.FieldTypeSequence,
+			},
+			&schema.SchemaField{
+				Name: "invoice",
+				Type: schema.FieldTypeSequence,
+				Options: &schema.SequenceOptions{
+					Scope:   "tenant",
+					Pattern: "INV-{year}-{seq:05}",
+				},
+			},
+		),
+	}
+
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func TestNextSequenceValue(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createSequenceTestCollection(t, app.Dao())
+	fieldId := collection.Schema.GetFieldByName("number").Id
+
+	scenarios := []struct {
+		scope    string
+		expected int
+	}{
+		{"", 1},
+		{"", 2},
+		{"a", 1},
+		{"", 3},
+		{"a", 2},
+		{"b", 1},
+	}
+
+	for i, s := range scenarios {
+		value, err := app.Dao().NextSequenceValue(collection.Id, fieldId, s.scope)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if value != s.expected {
+			t.Fatalf("[%d] Expected %d, got %d", i, s.expected, value)
+		}
+	}
+}
+
+func TestSaveRecordWithSequenceFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createSequenceTestCollection(t, app.Dao())
+
+	year := time.Now().UTC().Year()
+
+	scenarios := []struct {
+		tenant          string
+		expectedNumber  string
+		expectedInvoice string
+	}{
+		{"a", "1", fmt.Sprintf("INV-%d-00001", year)},
+		{"a", "2", fmt.Sprintf("INV-%d-00002", year)},
+		{"b", "3", fmt.Sprintf("INV-%d-00001", year)},
+		{"a", "4", fmt.Sprintf("INV-%d-00003", year)},
+	}
+
+	var records []*models.Record
+
+	for i, s := range scenarios {
+		record := models.NewRecord(collection)
+		record.Set("tenant", s.tenant)
+
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if v := record.GetString("number"); v != s.expectedNumber {
+			t.Fatalf("[%d] Expected number %q, got %q", i, s.expectedNumber, v)
+		}
+
+		if v := record.GetString("invoice"); v != s.expectedInvoice {
+			t.Fatalf("[%d] Expected invoice %q, got %q", i, s.expectedInvoice, v)
+		}
+
+		records = append(records, record)
+	}
+
+	// updates shouldn't change the assigned values
+	records[0].Set("tenant", "b")
+	if err := app.Dao().SaveRecord(records[0]); err != nil {
+		t.Fatal(err)
+	}
+	found, err := app.Dao().FindRecordById(collection.Id, records[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := found.GetString("number"); v != "1" {
+		t.Fatalf("Expected the number to remain %q, got %q", "1", v)
+	}
+
+	// explicitly set values shouldn't be replaced
+	record := models.NewRecord(collection)
+	record.Set("number", 100)
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("number"); v != "100" {
+		t.Fatalf("Expected number %q, got %q", "100", v)
+	}
+	if v := record.GetString("invoice"); v != fmt.Sprintf("INV-%d-00001", year) {
+		t.Fatalf("Expected the first empty tenant invoice, got %q", v)
+	}
+}
+
+func TestSaveRecordWithSequenceFieldsRollback(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createSequenceTestCollection(t, app.Dao())
+
+	// failed create
+	failErr := errors.New("test")
+	err := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		record := models.NewRecord(collection)
+		if err := txDao.SaveRecord(record); err != nil {
+			return err
+		}
+		if v := record.GetString("number"); v != "1" {
+			t.Fatalf("Expected number %q, got %q", "1", v)
+		}
+		return failErr
+	})
+	if !errors.Is(err, failErr) {
+		t.Fatalf("Expected the test error, got %v", err)
+	}
+
+	// failed create due to duplicated id
+	existing := models.NewRecord(collection)
+	existing.Set("number", 100)
+	if err := app.Dao().SaveRecord(existing); err != nil {
+		t.Fatal(err)
+	}
+	duplicated := models.NewRecord(collection)
+	duplicated.SetId(existing.Id)
+	if err := app.Dao().SaveRecord(duplicated); err == nil {
+		t.Fatal("Expected duplicated id error")
+	}
+	if v := duplicated.GetString("number"); v != "0" {
+		t.Fatalf("Expected the assigned number to be reset, got %q", v)
+	}
+
+	// the rolled back values should be reused
+	record := models.NewRecord(collection)
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("number"); v != "1" {
+		t.Fatalf("Expected gapless number %q, got %q", "1", v)
+	}
+}
+
+func TestSequenceFieldsSortAndFilter(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "sequence_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "number",
+				Type: schema.FieldTypeSequence,
+			},
+			&schema.SchemaField{
+				Name:    "code",
+				Type:    schema.FieldTypeSequence,
+				Options: &schema.SequenceOptions{Pattern: "N{seq}"},
+			},
+		),
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 11; i++ {
+		if err := app.Dao().SaveRecord(models.NewRecord(collection)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		filter   string
+		sort     string
+		field    string
+		expected []string
+	}{
+		{"number > 8", "-number", "number", []string{"11", "10", "9"}},
+		{"number >= '10'", "number", "number", []string{"10", "11"}},
+		{"code > 'N8'", "code", "code", []string{"N9", "N10", "N11"}},
+		{"code = 'N10' || code = 11", "-code", "code", []string{"N11", "N10"}},
+		{"code ~ 'N1'", "-code", "code", []string{"N11", "N10", "N1"}},
+		{"code = 'invalid'", "code", "code", []string{}},
+	}
+
+	check := func(filter, sort, field string, expected []string) {
+		t.Helper()
+
+		records, err := app.Dao().FindRecordsByFilter(collection.Id, filter, sort, 0, 0)
+		if err != nil {
+			t.Fatalf("[%s] %v", filter, err)
+		}
+
+		values := make([]string, len(records))
+		for i, r := range records {
+			values[i] = r.GetString(field)
+		}
+
+		if fmt.Sprint(values) != fmt.Sprint(expected) {
+			t.Fatalf("[%s] Expected %v, got %v", filter, expected, values)
+		}
+	}
+
+	for _, s := range scenarios {
+		check(s.filter, s.sort, s.field, s.expected)
+	}
+
+	// swap the fields pattern
+	collection.Schema.GetFieldByName("number").Options = &schema.SequenceOptions{Pattern: "N{seq}"}
+	collection.Schema.GetFieldByName("code").Options = &schema.SequenceOptions{}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// the existing plain numbers should remain unchanged but sorted numerically
+	check("number > 8", "-number", "number", []string{"11", "10", "9"})
+
+	// the existing formatted values should be replaced with their plain number
+	check("code > 8", "-code", "code", []string{"11", "10", "9"})
+}
@@ -22,56 +22,23 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 		// create
 		// -----------------------------------------------------------
 		if oldCollection == nil {
-			cols := map[string]string{
-				schema.FieldNameId:      "TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL",
-				schema.FieldNameCreated: "TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL",
-				schema.FieldNameUpdated: "TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL",
-			}
-
-			if newCollection.IsAuth() {
-				cols[schema.FieldNameUsername] = "TEXT NOT NULL"
-				cols[schema.FieldNameEmail] = "TEXT DEFAULT '' NOT NULL"
-				cols[schema.FieldNameEmailVisibility] = "BOOLEAN DEFAULT FALSE NOT NULL"
-				cols[schema.FieldNameVerified] = "BOOLEAN DEFAULT FALSE NOT NULL"
-				cols[schema.FieldNameTokenKey] = "TEXT NOT NULL"
-				cols[schema.FieldNamePasswordHash] = "TEXT NOT NULL"
-				cols[schema.FieldNameLastResetSentAt] = "TEXT DEFAULT '' NOT NULL"
-				cols[schema.FieldNameLastVerificationSentAt] = "TEXT DEFAULT '' NOT NULL"
-			}
-
 			// ensure that the new collection has an id
 			if !newCollection.HasId() {
 				newCollection.RefreshId()
 				newCollection.MarkAsNew()
 			}
 
-			tableName := newCollection.Name
-
-			// add schema field definitions
-			for _, field := range newCollection.Schema.Fields() {
-				cols[field.Name] = field.ColDefinition()
+			// create table
+			if _, err := txDao.DB().CreateTable(newCollection.Name, recordTableColumns(newCollection)).Execute(); err != nil {
+				return err
 			}
 
-			// create table
-			if _, err := txDao.DB().CreateTable(tableName, cols).Execute(); err != nil {
+			if err := txDao.createAuthRecordTableIndexes(newCollection); err != nil {
 				return err
 			}
 
-			// add named unique index on the email and tokenKey columns
-			if newCollection.IsAuth() {
-				_, err := txDao.DB().NewQuery(fmt.Sprintf(
-					`
-					CREATE UNIQUE INDEX _%s_username_idx ON {{%s}} ([[username]]);
-					CREATE UNIQUE INDEX _%s_email_idx ON {{%s}} ([[email]]) WHERE [[email]] != '';
-					CREATE UNIQUE INDEX _%s_tokenKey_idx ON {{%s}} ([[tokenKey]]);
-					`,
-					newCollection.Id, tableName,
-					newCollection.Id, tableName,
-					newCollection.Id, tableName,
-				)).Execute()
-				if err != nil {
-					return err
-				}
+			if err := txDao.syncRecordSearchTable(newCollection, nil); err != nil {
+				return err
 			}
 
 			return txDao.createCollectionIndexes(newCollection)
@@ -99,12 +66,36 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 			}
 		}
 
+		// drop the deleted or changed computed columns
+		// (the changed ones are added back after the other columns changes)
+		droppedComputedFields := map[string]struct{}{}
+		for _, oldField := range oldSchema.Fields() {
+			if oldField.Type != schema.FieldTypeComputed {
+				continue
+			}
+
+			if f := newSchema.GetFieldById(oldField.Id); f != nil && f.ColDefinition() == oldField.ColDefinition() {
+				continue // no definition change
+			}
+
+			_, err := txDao.DB().DropColumn(newTableName, oldField.Name).Execute()
+			if err != nil {
+				return fmt.Errorf("failed to drop column %s - %w", oldField.Name, err)
+			}
+
+			droppedComputedFields[oldField.Id] = struct{}{}
+		}
+
 		// check for deleted columns
 		for _, oldField := range oldSchema.Fields() {
 			if f := newSchema.GetFieldById(oldField.Id); f != nil {
 				continue // exist
 			}
 
+			if _, ok := droppedComputedFields[oldField.Id]; ok {
+				continue // already dropped
+			}
+
 			_, err := txDao.DB().DropColumn(newTableName, oldField.Name).Execute()
 			if err != nil {
 				return fmt.Errorf("failed to drop column %s - %w", oldField.Name, err)
@@ -115,8 +106,17 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 
 		// check for new or renamed columns
 		toRename := map[string]string{}
+		newComputedFields := []*schema.SchemaField{}
 		for _, field := range newSchema.Fields() {
 			oldField := oldSchema.GetFieldById(field.Id)
+
+			if _, ok := droppedComputedFields[field.Id]; ok || (oldField == nil && field.Type == schema.FieldTypeComputed) {
+				// added after the other columns changes since
+				// its expression could reference any of them
+				newComputedFields = append(newComputedFields, field)
+				continue
+			}
+
 			// Note:
 			// We are using a temporary column name when adding or renaming columns
 			// to ensure that there are no name collisions in case there is
@@ -158,10 +158,277 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 			return err
 		}
 
+		if err := txDao.syncSoftDeleteColumn(newCollection, oldCollection); err != nil {
+			return err
+		}
+
+		if err := txDao.syncEncryptedBlindIndexes(newCollection, oldCollection); err != nil {
+			return err
+		}
+
+		requireRebuild, err := txDao.syncSequenceNumberColumns(newCollection, oldCollection)
+		if err != nil {
+			return err
+		}
+
+		// add the new computed columns
+		//
+		// note: SQLite doesn't support adding STORED generated columns
+		// to an existing table so in this case the table is recreated
+		for _, field := range newComputedFields {
+			if options, _ := field.Options.(*schema.ComputedOptions); options != nil && options.Stored {
+				requireRebuild = true
+				continue
+			}
+
+			_, err := txDao.DB().AddColumn(newTableName, field.Name, field.ColDefinition()).Execute()
+			if err != nil {
+				return fmt.Errorf("failed to add column %s - %w", field.Name, err)
+			}
+		}
+		if requireRebuild {
+			if err := txDao.rebuildRecordTable(newCollection); err != nil {
+				return err
+			}
+		}
+
+		if err := txDao.syncRecordSearchTable(newCollection, oldCollection); err != nil {
+			return err
+		}
+
 		return txDao.createCollectionIndexes(newCollection)
 	})
 }
 
+// recordTableColumns returns the record table column definitions of the provided collection.
+func recordTableColumns(collection *models.Collection) map[string]string {
+	cols := map[string]string{
+		schema.FieldNameId:      "TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL",
+		schema.FieldNameCreated: "TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL",
+		schema.FieldNameUpdated: "TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL",
+	}
+
+	if collection.IsAuth() {
+		cols[schema.FieldNameUsername] = "TEXT NOT NULL"
+		cols[schema.FieldNameEmail] = "TEXT DEFAULT '' NOT NULL"
+		cols[schema.FieldNameEmailVisibility] = "BOOLEAN DEFAULT FALSE NOT NULL"
+		cols[schema.FieldNameVerified] = "BOOLEAN DEFAULT FALSE NOT NULL"
+		cols[schema.FieldNameTokenKey] = "TEXT NOT NULL"
+		cols[schema.FieldNamePasswordHash] = "TEXT NOT NULL"
+		cols[schema.FieldNameLastResetSentAt] = "TEXT DEFAULT '' NOT NULL"
+		cols[schema.FieldNameLastVerificationSentAt] = "TEXT DEFAULT '' NOT NULL"
+	}
+
+	if collection.HasSoftDelete() {
+		cols[schema.FieldNameDeleted] = softDeleteColDefinition
+	}
+
+	// add schema field definitions
+	for _, field := range collection.Schema.Fields() {
+		cols[field.Name] = field.ColDefinition()
+
+		if col := schema.SequenceNumberColumn(field); col != "" {
+			cols[col] = schema.SequenceNumberColDefinition
+		}
+	}
+
+	return cols
+}
+
+// createAuthRecordTableIndexes adds named unique index on the
+// username, email and tokenKey columns of an auth collection table.
+func (dao *Dao) createAuthRecordTableIndexes(collection *models.Collection) error {
+	if !collection.IsAuth() {
+		return nil
+	}
+
+	_, err := dao.DB().NewQuery(fmt.Sprintf(
+		`
+		CREATE UNIQUE INDEX _%s_username_idx ON {{%s}} ([[username]]);
+		CREATE UNIQUE INDEX _%s_email_idx ON {{%s}} ([[email]]) WHERE [[email]] != '';
+		CREATE UNIQUE INDEX _%s_tokenKey_idx ON {{%s}} ([[tokenKey]]);
+		`,
+		collection.Id, collection.Name,
+		collection.Id, collection.Name,
+		collection.Id, collection.Name,
+	)).Execute()
+
+	return err
+}
+
+// rebuildRecordTable recreates the collection record table from its
+// current schema and copies the existing records data into it.
+//
+// This is usually used when there is no ALTER TABLE alternative
+// for the schema change (eg. adding a STORED generated column).
+//
+// Note that the collection indexes are expected to be recreated by the caller.
+func (dao *Dao) rebuildRecordTable(collection *models.Collection) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		// temporary disable the views and triggers checks when
+		// replacing the table (they are still valid after the rename)
+		if _, err := txDao.DB().NewQuery("PRAGMA legacy_alter_table = ON").Execute(); err != nil {
+			return err
+		}
+		// executed with defer to make sure that the pragma is always reverted
+		defer txDao.DB().NewQuery("PRAGMA legacy_alter_table = OFF").Execute()
+
+		cols := recordTableColumns(collection)
+		tempName := "_" + collection.Name + security.PseudorandomString(5)
+
+		if _, err := txDao.DB().CreateTable(tempName, cols).Execute(); err != nil {
+			return fmt.Errorf("failed to create the temp table %s - %w", tempName, err)
+		}
+
+		copyCols := make([]string, 0, len(cols))
+		for name := range cols {
+			if field := collection.Schema.GetFieldByName(name); field != nil && field.Type == schema.FieldTypeComputed {
+				continue // generated
+			}
+			copyCols = append(copyCols, "[["+name+"]]")
+		}
+
+		_, err := txDao.DB().NewQuery(fmt.Sprintf(
+			"INSERT INTO {{%s}} (%s) SELECT %s FROM {{%s}}",
+			tempName,
+			strings.Join(copyCols, ", "),
+			strings.Join(copyCols, ", "),
+			collection.Name,
+		)).Execute()
+		if err != nil {
+			return fmt.Errorf("failed to copy the %s records - %w", collection.Name, err)
+		}
+
+		if _, err := txDao.DB().DropTable(collection.Name).Execute(); err != nil {
+			return err
+		}
+
+		if _, err := txDao.DB().RenameTable("{{"+tempName+"}}", "{{"+collection.Name+"}}").Execute(); err != nil {
+			return err
+		}
+
+		return txDao.createAuthRecordTableIndexes(collection)
+	})
+}
+
+// syncSequenceNumberColumns adds, drops or fills the raw number columns
+// of the sequence fields based on their pattern option change
+// (see [schema.SequenceNumberColumn]).
+//
+// Returns true if the record table has to be rebuilt to
+// apply the changed sequence fields column type affinity.
+func (dao *Dao) syncSequenceNumberColumns(newCollection, oldCollection *models.Collection) (bool, error) {
+	var requireRebuild bool
+
+	// drop the raw number columns of the deleted fields
+	for _, oldField := range oldCollection.Schema.Fields() {
+		oldCol := schema.SequenceNumberColumn(oldField)
+		if oldCol == "" || newCollection.Schema.GetFieldById(oldField.Id) != nil {
+			continue
+		}
+
+		if _, err := dao.DB().DropColumn(newCollection.Name, oldCol).Execute(); err != nil {
+			return false, fmt.Errorf("failed to drop column %s - %w", oldCol, err)
+		}
+	}
+
+	for _, field := range newCollection.Schema.Fields() {
+		if field.Type != schema.FieldTypeSequence {
+			continue
+		}
+
+		newCol := schema.SequenceNumberColumn(field)
+
+		var oldCol string
+		oldField := oldCollection.Schema.GetFieldById(field.Id)
+		if oldField != nil {
+			oldCol = schema.SequenceNumberColumn(oldField)
+			requireRebuild = requireRebuild || oldField.ColDefinition() != field.ColDefinition()
+		}
+
+		switch {
+		case oldCol == "" && newCol != "":
+			_, err := dao.DB().AddColumn(newCollection.Name, newCol, schema.SequenceNumberColDefinition).Execute()
+			if err != nil {
+				return false, fmt.Errorf("failed to add column %s - %w", newCol, err)
+			}
+
+			// the existing values are plain numbers
+			_, err = dao.DB().NewQuery(fmt.Sprintf(
+				"UPDATE {{%s}} SET [[%s]] = CAST([[%s]] AS INTEGER)",
+				newCollection.Name,
+				newCol,
+				field.Name,
+			)).Execute()
+			if err != nil {
+				return false, fmt.Errorf("failed to fill column %s - %w", newCol, err)
+			}
+		case oldCol != "" && newCol == "":
+			// replace the formatted values with their plain number
+			_, err := dao.DB().NewQuery(fmt.Sprintf(
+				"UPDATE {{%s}} SET [[%s]] = [[%s]]",
+				newCollection.Name,
+				field.Name,
+				oldCol,
+			)).Execute()
+			if err != nil {
+				return false, fmt.Errorf("failed to replace the formatted %s values - %w", field.Name, err)
+			}
+
+			_, err = dao.DB().DropColumn(newCollection.Name, oldCol).Execute()
+			if err != nil {
+				return false, fmt.Errorf("failed to drop column %s - %w", oldCol, err)
+			}
+		}
+	}
+
+	return requireRebuild, nil
+}
+
+// softDeleteColDefinition is the column definition of the soft delete timestamp column.
+const softDeleteColDefinition = "TEXT DEFAULT '' NOT NULL"
+
+// syncSoftDeleteColumn adds or drops the record table soft delete
+// column based on the collection "softDelete" option change.
+//
+// Disabling the soft delete option fails if there are trashed records.
+func (dao *Dao) syncSoftDeleteColumn(newCollection, oldCollection *models.Collection) error {
+	isOld := oldCollection.HasSoftDelete()
+	isNew := newCollection.HasSoftDelete()
+
+	if isOld == isNew {
+		return nil // no change
+	}
+
+	if isNew {
+		_, err := dao.DB().AddColumn(newCollection.Name, schema.FieldNameDeleted, softDeleteColDefinition).Execute()
+		if err != nil {
+			return fmt.Errorf("failed to add column %s - %w", schema.FieldNameDeleted, err)
+		}
+
+		return nil
+	}
+
+	var total int
+	err := dao.DB().Select("count(*)").
+		From(newCollection.Name).
+		AndWhere(dbx.NewExp("[[" + schema.FieldNameDeleted + "]] != ''")).
+		Row(&total)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("the collection %q has %d trashed record(s) that must be restored or purged before disabling the soft delete", newCollection.Name, total)
+	}
+
+	_, err = dao.DB().DropColumn(newCollection.Name, schema.FieldNameDeleted).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to drop column %s - %w", schema.FieldNameDeleted, err)
+	}
+
+	return nil
+}
+
 func (dao *Dao) normalizeSingleVsMultipleFieldChanges(newCollection, oldCollection *models.Collection) error {
 	if newCollection.IsView() || oldCollection == nil {
 		return nil // view or not an update
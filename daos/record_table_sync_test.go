@@ -321,3 +321,105 @@ func TestSingleVsMultipleValuesNormalization(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncRecordTableSchemaComputedFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	titleOf := func(col string) string {
+		var result string
+		err := app.Dao().DB().Select(col).
+			From(collection.Name).
+			AndWhere(dbx.HashExp{"id": "llvuca81nly1qls"}).
+			Row(&result)
+		if err != nil {
+			t.Fatalf("Failed to fetch %s: %v", col, err)
+		}
+		return result
+	}
+
+	// add virtual computed field
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "upper_title",
+		Type: schema.FieldTypeComputed,
+		Options: &schema.ComputedOptions{
+			Expression: "upper(title)",
+			ValueType:  schema.FieldTypeText,
+		},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatalf("Failed to add virtual computed field: %v", err)
+	}
+	if v := titleOf("upper_title"); v != "TEST1" {
+		t.Fatalf("Expected upper_title %q, got %q", "TEST1", v)
+	}
+
+	// add stored computed field (requires table rebuild)
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "title_len",
+		Type: schema.FieldTypeComputed,
+		Options: &schema.ComputedOptions{
+			Expression: "length(title)",
+			ValueType:  schema.FieldTypeNumber,
+			Stored:     true,
+		},
+	})
+	collection.Indexes = types.JsonArray[string]{"create index idx_title_len on demo2 (title_len)"}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatalf("Failed to add stored computed field: %v", err)
+	}
+	if v := titleOf("title_len"); v != "5" {
+		t.Fatalf("Expected title_len %q, got %q", "5", v)
+	}
+	if total, _ := app.Dao().FindRecordsByFilter(collection.Id, "title != ''", "", 0, 0); len(total) != 3 {
+		t.Fatalf("Expected the existing records to be preserved, got %d", len(total))
+	}
+	if indexes, _ := app.Dao().TableIndexes(collection.Name); len(indexes) != 1 {
+		t.Fatalf("Expected 1 index, got %v", indexes)
+	}
+	var legacyAlterTable int
+	app.Dao().DB().NewQuery("PRAGMA legacy_alter_table").Row(&legacyAlterTable)
+	if legacyAlterTable != 0 {
+		t.Fatal("Expected legacy_alter_table to be reverted")
+	}
+
+	// rename and change the virtual computed field expression
+	field := collection.Schema.GetFieldByName("upper_title")
+	field.Name = "title_mod"
+	field.Options = &schema.ComputedOptions{
+		Expression: "lower(title) || '!'",
+		ValueType:  schema.FieldTypeText,
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatalf("Failed to change computed field: %v", err)
+	}
+	if v := titleOf("title_mod"); v != "test1!" {
+		t.Fatalf("Expected title_mod %q, got %q", "test1!", v)
+	}
+
+	// delete the computed fields
+	collection.Indexes = nil
+	collection.Schema.RemoveField(collection.Schema.GetFieldByName("title_mod").Id)
+	collection.Schema.RemoveField(collection.Schema.GetFieldByName("title_len").Id)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatalf("Failed to delete the computed fields: %v", err)
+	}
+
+	expectedColumns := []string{"id", "created", "updated", "title", "active"}
+	cols, _ := app.Dao().TableColumns(collection.Name)
+	if len(cols) != len(expectedColumns) {
+		t.Fatalf("Expected columns %v, got %v", expectedColumns, cols)
+	}
+	for _, c := range cols {
+		if !list.ExistInSlice(c, expectedColumns) {
+			t.Fatalf("Couldn't find column %s in %v", c, expectedColumns)
+		}
+	}
+}
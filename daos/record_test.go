@@ -846,6 +846,168 @@ func TestCanAccessRecord(t *testing.T) {
 	}
 }
 
+func TestRecordMatchesFilter(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById("demo1", "imy661ixudk5izi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		requestInfo *models.RequestInfo
+		filter      string
+		expected    bool
+		expectError bool
+	}{
+		{
+			"nil request info with matched filter",
+			nil,
+			"id = 'imy661ixudk5izi'",
+			true,
+			false,
+		},
+		{
+			"nil request info with mismatched filter",
+			nil,
+			"id != 'imy661ixudk5izi'",
+			false,
+			false,
+		},
+		{
+			"invalid filter",
+			nil,
+			"id ?!@ 1",
+			false,
+			true,
+		},
+		{
+			"as admin with mismatched filter",
+			&models.RequestInfo{Admin: admin},
+			"id = ''", // the filter should be still evaluated
+			false,
+			false,
+		},
+		{
+			"with matched request data filter",
+			&models.RequestInfo{Data: map[string]any{"test": 2}},
+			"@request.data.test > 1 && id = 'imy661ixudk5izi'",
+			true,
+			false,
+		},
+		{
+			"with mismatched request data filter",
+			&models.RequestInfo{Data: map[string]any{"test": 1}},
+			"@request.data.test > 1 && id = 'imy661ixudk5izi'",
+			false,
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		result, err := app.Dao().RecordMatchesFilter(record, s.requestInfo, s.filter)
+
+		if result != s.expected {
+			t.Errorf("[%s] Expected %v, got %v", s.name, s.expected, result)
+		}
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+		}
+	}
+}
+
+func TestHideRestrictedRecordsFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authRecord, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.GetFieldByName("name").ViewRule = "id = @request.auth.id"
+	collection.Schema.GetFieldByName("rel").ViewRule = "id ?!@ 1"
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name          string
+		requestInfo   *models.RequestInfo
+		expectedNames []string // the ids of the records with visible name
+		expectError   bool
+	}{
+		{
+			"as admin",
+			&models.RequestInfo{Admin: admin},
+			[]string{"4q1xlclmfloku33", "oap640cot4yru2s", "bgs820n361vj1qd"},
+			false,
+		},
+		{
+			"as guest",
+			&models.RequestInfo{},
+			[]string{},
+			true,
+		},
+		{
+			"as auth record",
+			&models.RequestInfo{AuthRecord: authRecord},
+			[]string{"4q1xlclmfloku33"},
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			records, err := app.Dao().FindRecordsByIds("users", []string{"4q1xlclmfloku33", "oap640cot4yru2s", "bgs820n361vj1qd"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = app.Dao().HideRestrictedRecordsFields(records, s.requestInfo)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr to be %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, r := range records {
+				expectVisible := list.ExistInSlice(r.Id, s.expectedNames)
+				if r.IsHidden("name") == expectVisible {
+					t.Errorf("Expected record %q name visibility to be %v", r.Id, expectVisible)
+				}
+
+				// the invalid rule field should be hidden for everyone except admins
+				if r.IsHidden("rel") != (s.requestInfo.Admin == nil) {
+					t.Errorf("Expected record %q rel hidden state to be %v", r.Id, s.requestInfo.Admin == nil)
+				}
+			}
+		})
+	}
+}
+
 func TestIsRecordValueUnique(t *testing.T) {
 	t.Parallel()
 
@@ -897,6 +1059,56 @@ func TestIsRecordValueUnique(t *testing.T) {
 	}
 }
 
+func TestCheckRecordETag(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	etag := record.ETag()
+
+	missing := record.CleanCopy()
+	missing.Id = "missing"
+
+	scenarios := []struct {
+		record      *models.Record
+		ifMatch     string
+		expectError error
+	}{
+		{record, "", nil},
+		{record, `"invalid"`, daos.ErrRecordETagMismatch},
+		{record, etag, nil},
+		{record, "*", nil},
+		{missing, "*", daos.ErrRecordETagMismatch},
+	}
+
+	for i, s := range scenarios {
+		err := app.Dao().CheckRecordETag(s.record, s.ifMatch)
+		if err != s.expectError {
+			t.Errorf("(%d) Expected error %v, got %v", i, s.expectError, err)
+		}
+	}
+
+	// change the persisted record state
+	record.Set("title", "test1_updated")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().CheckRecordETag(record, etag); err != daos.ErrRecordETagMismatch {
+		t.Fatalf("Expected ErrRecordETagMismatch for the old etag, got %v", err)
+	}
+
+	if err := app.Dao().CheckRecordETag(record, record.ETag()); err != nil {
+		t.Fatalf("Expected nil error for the new etag, got %v", err)
+	}
+}
+
 func TestFindAuthRecordByToken(t *testing.T) {
 	t.Parallel()
 
@@ -1102,6 +1314,149 @@ func TestSaveRecord(t *testing.T) {
 	}
 }
 
+func TestSaveRecordWithComputedFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, _ := app.Dao().FindCollectionByNameOrId("demo2")
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "summary",
+		Type: schema.FieldTypeComputed,
+		Options: &schema.ComputedOptions{
+			Expression: "title || ':' || active",
+			ValueType:  schema.FieldTypeText,
+		},
+	})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "title_len",
+		Type: schema.FieldTypeComputed,
+		Options: &schema.ComputedOptions{
+			Expression: "length(title)",
+			ValueType:  schema.FieldTypeNumber,
+			Stored:     true,
+		},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// create
+	record := models.NewRecord(collection)
+	record.Set("title", "abc")
+	record.Set("summary", "ignored")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("summary"); v != "abc:0" {
+		t.Fatalf("Expected summary %q, got %q", "abc:0", v)
+	}
+	if v := record.GetFloat("title_len"); v != 3 {
+		t.Fatalf("Expected title_len %v, got %v", 3, v)
+	}
+
+	// update
+	record.Set("title", "abcdef")
+	record.Set("active", true)
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("summary"); v != "abcdef:1" {
+		t.Fatalf("Expected summary %q, got %q", "abcdef:1", v)
+	}
+	if v := record.GetFloat("title_len"); v != 6 {
+		t.Fatalf("Expected title_len %v, got %v", 6, v)
+	}
+
+	// filter and sort by the computed fields
+	records, err := app.Dao().FindRecordsByFilter(collection.Id, "title_len > 5", "-title_len", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Id != record.Id {
+		t.Fatalf("Expected only record %q, got %v", record.Id, records)
+	}
+}
+
+func TestSaveRecordWithAutodateFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, _ := app.Dao().FindCollectionByNameOrId("demo2")
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "published",
+		Type:    schema.FieldTypeAutodate,
+		Options: &schema.AutodateOptions{OnCreate: true},
+	})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "edited",
+		Type:    schema.FieldTypeAutodate,
+		Options: &schema.AutodateOptions{OnUpdate: true},
+	})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "touched",
+		Type:    schema.FieldTypeAutodate,
+		Options: &schema.AutodateOptions{OnCreate: true, OnUpdate: true},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// create
+	before := types.NowDateTime()
+	record := models.NewRecord(collection)
+	record.Set("title", "abc")
+	record.Set("published", "2000-01-01 00:00:00.000Z")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	published := record.GetDateTime("published")
+	if published.Time().Before(before.Time()) {
+		t.Fatalf("Expected published to be set on create, got %q", published)
+	}
+	if v := record.GetDateTime("edited"); !v.IsZero() {
+		t.Fatalf("Expected edited to be empty on create, got %q", v)
+	}
+	touched := record.GetDateTime("touched")
+	if touched.Time().Before(before.Time()) {
+		t.Fatalf("Expected touched to be set on create, got %q", touched)
+	}
+
+	// update
+	time.Sleep(5 * time.Millisecond)
+	record.Set("title", "abcdef")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := found.GetDateTime("published"); v.String() != published.String() {
+		t.Fatalf("Expected published to remain %q on update, got %q", published, v)
+	}
+	if v := found.GetDateTime("edited"); !v.Time().After(touched.Time()) {
+		t.Fatalf("Expected edited to be set on update, got %q", v)
+	}
+	if v := found.GetDateTime("touched"); !v.Time().After(touched.Time()) {
+		t.Fatalf("Expected touched to be updated, got %q", v)
+	}
+
+	// filter and sort by the autodate fields
+	records, err := app.Dao().FindRecordsByFilter(collection.Id, "edited != ''", "-touched", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Id != record.Id {
+		t.Fatalf("Expected only record %q, got %v", record.Id, records)
+	}
+}
+
 func TestSaveRecordWithIdFromOtherCollection(t *testing.T) {
 	t.Parallel()
 
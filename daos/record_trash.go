@@ -0,0 +1,248 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// FindTrashedRecordById finds a trashed (aka. soft deleted) Record model by its id.
+func (dao *Dao) FindTrashedRecordById(
+	collectionNameOrId string,
+	recordId string,
+	optFilters ...func(q *dbx.SelectQuery) error,
+) (*models.Record, error) {
+	collection, err := dao.FindCollectionByNameOrId(collectionNameOrId)
+	if err != nil {
+		return nil, err
+	}
+
+	query := dao.TrashedRecordQuery(collection).
+		AndWhere(dbx.HashExp{collection.Name + ".id": recordId})
+
+	for _, filter := range optFilters {
+		if filter == nil {
+			continue
+		}
+		if err := filter(query); err != nil {
+			return nil, err
+		}
+	}
+
+	record := &models.Record{}
+
+	if err := query.Limit(1).One(record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// TrashRecord moves the provided Record model to the trash
+// by setting its "deleted" timestamp.
+//
+// The operation is cascaded to all active records from soft delete
+// collections that reference the provided record with a
+// `CascadeDelete` relation field (and have no other active references).
+// References from collections without soft delete are left untouched
+// and they are handled on purge (see [Dao.PurgeRecord]).
+//
+// Note that trashing is a record update and therefore it triggers
+// the update model hooks (the record files are also preserved).
+func (dao *Dao) TrashRecord(record *models.Record) error {
+	if !record.Collection().HasSoftDelete() {
+		return fmt.Errorf("the collection %q doesn't have soft delete enabled", record.Collection().Name)
+	}
+
+	if record.IsTrashed() {
+		return errors.New("the record is already trashed")
+	}
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		return txDao.trashRecordWithRefs(record, types.NowDateTime().String())
+	})
+}
+
+// RestoreRecord restores the provided trashed Record model
+// together with the records that were cascade trashed with it.
+//
+// The restore operation fails if the record has a required or
+// `CascadeDelete` relation field whose references are all trashed
+// (they must be restored first).
+func (dao *Dao) RestoreRecord(record *models.Record) error {
+	if !record.IsTrashed() {
+		return errors.New("the record is not trashed")
+	}
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		return txDao.restoreRecordWithRefs(record)
+	})
+}
+
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) trashRecordWithRefs(record *models.Record, deletedAt string) error {
+	record.Set(schema.FieldNameDeleted, deletedAt)
+
+	if err := dao.SaveRecord(record); err != nil {
+		record.Set(schema.FieldNameDeleted, "")
+		return err
+	}
+
+	return dao.eachSoftCascadeRef(record, false, func(refRecord *models.Record, field *schema.SchemaField) error {
+		// trash the reference only if there are no other active references
+		ids := list.SubtractSlice(refRecord.GetStringSlice(field.Name), []string{record.Id})
+		if len(ids) > 0 {
+			options, _ := field.Options.(*schema.RelationOptions)
+
+			var total int
+			err := dao.RecordQuery(options.CollectionId).
+				Select("count(*)").
+				AndWhere(dbx.In(schema.FieldNameId, list.ToInterfaceSlice(ids)...)).
+				Row(&total)
+			if err != nil {
+				return err
+			}
+
+			if total > 0 {
+				return nil
+			}
+		}
+
+		return dao.trashRecordWithRefs(refRecord, deletedAt)
+	})
+}
+
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) restoreRecordWithRefs(record *models.Record) error {
+	if err := dao.checkRestoreRelations(record); err != nil {
+		return err
+	}
+
+	deletedAt := record.GetString(schema.FieldNameDeleted)
+
+	record.Set(schema.FieldNameDeleted, "")
+
+	if err := dao.SaveRecord(record); err != nil {
+		record.Set(schema.FieldNameDeleted, deletedAt)
+		return err
+	}
+
+	return dao.eachSoftCascadeRef(record, true, func(refRecord *models.Record, field *schema.SchemaField) error {
+		// restore only the references that were trashed together with the record
+		if refRecord.GetString(schema.FieldNameDeleted) != deletedAt {
+			return nil
+		}
+
+		return dao.restoreRecordWithRefs(refRecord)
+	})
+}
+
+// checkRestoreRelations checks whether the provided record has a required or
+// cascade delete relation field that references only trashed or missing records.
+func (dao *Dao) checkRestoreRelations(record *models.Record) error {
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeRelation {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.RelationOptions)
+		if options == nil || (!field.Required && !options.CascadeDelete) {
+			continue
+		}
+
+		ids := record.GetStringSlice(field.Name)
+		if len(ids) == 0 {
+			continue
+		}
+
+		var total int
+		err := dao.RecordQuery(options.CollectionId).
+			Select("count(*)").
+			AndWhere(dbx.In(schema.FieldNameId, list.ToInterfaceSlice(ids)...)).
+			Row(&total)
+		if err != nil {
+			return err
+		}
+
+		if total == 0 {
+			return fmt.Errorf("the record cannot be restored because all of its %q relation references are trashed or missing", field.Name)
+		}
+	}
+
+	return nil
+}
+
+// eachSoftCascadeRef calls fn for every active or trashed record from
+// a soft delete collection that references the main record with a
+// `CascadeDelete` relation field.
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) eachSoftCascadeRef(
+	mainRecord *models.Record,
+	trashed bool,
+	fn func(refRecord *models.Record, field *schema.SchemaField) error,
+) error {
+	refs, err := dao.FindCollectionReferences(mainRecord.Collection())
+	if err != nil {
+		return err
+	}
+
+	uniqueJsonEachAlias := "__je__" + security.PseudorandomString(4)
+
+	for _, refCollection := range sortedCollectionRefKeys(refs) {
+		if !refCollection.HasSoftDelete() {
+			continue
+		}
+
+		for _, field := range refs[refCollection] {
+			options, _ := field.Options.(*schema.RelationOptions)
+			if options == nil || !options.CascadeDelete {
+				continue
+			}
+
+			recordTableName := inflector.Columnify(refCollection.Name)
+			prefixedFieldName := recordTableName + "." + inflector.Columnify(field.Name)
+
+			var query *dbx.SelectQuery
+			if trashed {
+				query = dao.TrashedRecordQuery(refCollection)
+			} else {
+				query = dao.RecordQuery(refCollection)
+			}
+			query.Distinct(true)
+
+			if !options.IsMultiple() {
+				query.AndWhere(dbx.HashExp{prefixedFieldName: mainRecord.Id})
+			} else {
+				query.InnerJoin(fmt.Sprintf(
+					`json_each(CASE WHEN json_valid([[%s]]) THEN [[%s]] ELSE json_array([[%s]]) END) as {{%s}}`,
+					prefixedFieldName, prefixedFieldName, prefixedFieldName, uniqueJsonEachAlias,
+				), dbx.HashExp{uniqueJsonEachAlias + ".value": mainRecord.Id})
+			}
+
+			if refCollection.Id == mainRecord.Collection().Id {
+				query.AndWhere(dbx.Not(dbx.HashExp{recordTableName + ".id": mainRecord.Id}))
+			}
+
+			refRecords := []*models.Record{}
+			if err := query.All(&refRecords); err != nil {
+				return err
+			}
+
+			for _, refRecord := range refRecords {
+				if err := fn(refRecord, field); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func enableSoftDelete(t *testing.T, dao *daos.Dao, collectionNames ...string) {
+	for _, name := range collectionNames {
+		collection, err := dao.FindCollectionByNameOrId(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		collection.Options["softDelete"] = true
+
+		if err := dao.SaveCollection(collection); err != nil {
+			t.Fatalf("Failed to enable %q soft delete: %v", name, err)
+		}
+	}
+}
+
+func TestTrashedRecordQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// soft delete disabled
+	total := 0
+	if err := app.Dao().TrashedRecordQuery("demo2").Select("count(*)").Row(&total); err != nil || total != 0 {
+		t.Fatalf("Expected 0 trashed records, got %d (%v)", total, err)
+	}
+
+	enableSoftDelete(t, app.Dao(), "demo2")
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().RecordQuery("demo2").Select("count(*)").Row(&total); err != nil || total != 2 {
+		t.Fatalf("Expected 2 active records, got %d (%v)", total, err)
+	}
+
+	if err := app.Dao().TrashedRecordQuery("demo2").Select("count(*)").Row(&total); err != nil || total != 1 {
+		t.Fatalf("Expected 1 trashed record, got %d (%v)", total, err)
+	}
+}
+
+func TestTrashAndRestoreRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// trash a record from a collection without soft delete
+	demo2Record, _ := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err := app.Dao().TrashRecord(demo2Record); err == nil {
+		t.Fatal("Expected TrashRecord to fail for a collection without soft delete")
+	}
+
+	enableSoftDelete(t, app.Dao(), "demo3", "demo4")
+
+	record, err := app.Dao().FindRecordById("demo3", "7nwo8tuiatetxdm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// trash (+ cascade)
+	// ---
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+
+	if !record.IsTrashed() {
+		t.Fatal("Expected the record to be marked as trashed")
+	}
+
+	if err := app.Dao().TrashRecord(record); err == nil {
+		t.Fatal("Expected TrashRecord to fail for already trashed record")
+	}
+
+	if _, err := app.Dao().FindRecordById("demo3", record.Id); err == nil {
+		t.Fatal("Expected the trashed record to be excluded from the active records")
+	}
+
+	trashed, err := app.Dao().FindTrashedRecordById("demo3", record.Id)
+	if err != nil {
+		t.Fatalf("Expected to find the trashed record, got %v", err)
+	}
+
+	child, err := app.Dao().FindTrashedRecordById("demo4", "qzaqccwrmva4o1n")
+	if err != nil {
+		t.Fatalf("Expected the cascade reference to be trashed, got %v", err)
+	}
+
+	if v := child.GetString("deleted"); v != trashed.GetString("deleted") {
+		t.Fatalf("Expected the cascade reference deleted timestamp %q, got %q", trashed.GetString("deleted"), v)
+	}
+
+	// non-cascade references should be left untouched
+	if _, err := app.Dao().FindRecordById("demo4", "i9naidtvr6qsgb4"); err != nil {
+		t.Fatalf("Expected the non-cascade reference to remain active, got %v", err)
+	}
+
+	// relation filters should ignore the trashed references
+	filtered, err := app.Dao().FindRecordsByFilter("demo4", "rel_many_no_cascade_required.title = 'test3'", "", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 0 {
+		t.Fatalf("Expected no records matching a trashed relation, got %d", len(filtered))
+	}
+
+	// restore
+	// ---
+	if err := app.Dao().RestoreRecord(child); err == nil {
+		t.Fatal("Expected the child restore to fail because its cascade reference is trashed")
+	}
+
+	active, _ := app.Dao().FindRecordById("demo3", "1tmknxy2868d869")
+	if err := app.Dao().RestoreRecord(active); err == nil {
+		t.Fatal("Expected the restore of an active record to fail")
+	}
+
+	if err := app.Dao().RestoreRecord(trashed); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+
+	for collection, id := range map[string]string{"demo3": trashed.Id, "demo4": child.Id} {
+		restored, err := app.Dao().FindRecordById(collection, id)
+		if err != nil {
+			t.Fatalf("Expected %s record %q to be restored, got %v", collection, id, err)
+		}
+		if restored.IsTrashed() {
+			t.Fatalf("Expected %s record %q to not be marked as trashed", collection, id)
+		}
+	}
+}
+
+func TestPurgeRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	enableSoftDelete(t, app.Dao(), "demo3", "demo4")
+
+	record, _ := app.Dao().FindRecordById("demo3", "mk5fmymtx4wsprk")
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindTrashedRecordById("demo4", "qzaqccwrmva4o1n"); err != nil {
+		t.Fatalf("Expected the cascade reference to be trashed, got %v", err)
+	}
+
+	// deleting an already trashed record should purge it
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		collection string
+		id         string
+	}{
+		{"demo3", "mk5fmymtx4wsprk"},
+		{"demo4", "qzaqccwrmva4o1n"}, // purged with the cascade
+	}
+
+	for _, c := range checks {
+		var found []*models.Record
+		if err := app.Dao().TrashedRecordQuery(c.collection).AndWhere(dbx.HashExp{"id": c.id}).All(&found); err != nil {
+			t.Fatal(err)
+		}
+		if len(found) != 0 {
+			t.Fatalf("Expected %s record %q to be purged", c.collection, c.id)
+		}
+	}
+}
+
+func TestIsRecordValueUniqueWithTrashedRecords(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	enableSoftDelete(t, app.Dao(), "demo2")
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.Dao().IsRecordValueUnique("demo2", "title", record.GetString("title")) {
+		t.Fatal("Expected the trashed record value to not be unique")
+	}
+
+	if !app.Dao().IsRecordValueUnique("demo2", "title", record.GetString("title"), record.Id) {
+		t.Fatal("Expected the trashed record value to be unique for the record itself")
+	}
+}
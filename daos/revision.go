@@ -0,0 +1,178 @@
+package daos
+
+import (
+	"errors"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RevisionQuery returns a new Revision select query.
+func (dao *Dao) RevisionQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Revision{})
+}
+
+// RecordRevisionQuery returns a new Revision select query
+// filtered by the provided record.
+func (dao *Dao) RecordRevisionQuery(record *models.Record) *dbx.SelectQuery {
+	return dao.RevisionQuery().AndWhere(dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	})
+}
+
+// FindRevisionById finds a single Revision model by its id.
+func (dao *Dao) FindRevisionById(id string) (*models.Revision, error) {
+	model := &models.Revision{}
+
+	err := dao.RevisionQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindRecordRevisionById finds a single Revision model
+// by its id and the record it belongs to.
+func (dao *Dao) FindRecordRevisionById(record *models.Record, id string) (*models.Revision, error) {
+	model := &models.Revision{}
+
+	err := dao.RecordRevisionQuery(record).
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindLatestRecordRevision returns the last created Revision model
+// of the provided record.
+func (dao *Dao) FindLatestRecordRevision(record *models.Record) (*models.Revision, error) {
+	model := &models.Revision{}
+
+	err := dao.RecordRevisionQuery(record).
+		OrderBy("created DESC", "rowid DESC").
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveRevision upserts the provided Revision model.
+func (dao *Dao) SaveRevision(model *models.Revision) error {
+	if model.CollectionId == "" || model.RecordId == "" {
+		return errors.New("missing required Revision collectionId or recordId")
+	}
+
+	return dao.Save(model)
+}
+
+// DeleteRevision deletes the provided Revision model.
+func (dao *Dao) DeleteRevision(model *models.Revision) error {
+	return dao.Delete(model)
+}
+
+// deleteRecordRevisions deletes all revisions of the provided record.
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) deleteRecordRevisions(record *models.Record) error {
+	_, err := dao.DB().Delete((&models.Revision{}).TableName(), dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	}).Execute()
+
+	return err
+}
+
+// deleteCollectionRevisions deletes all revisions of the
+// records from the collection with the provided id.
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) deleteCollectionRevisions(collectionId string) error {
+	_, err := dao.DB().Delete((&models.Revision{}).TableName(), dbx.HashExp{
+		"collectionId": collectionId,
+	}).Execute()
+
+	return err
+}
+
+// saveRecordRevision stores a snapshot of the provided record
+// schema fields together with the changes compared to its last
+// revision (or its original state if there are no revisions yet).
+//
+// Updates without changes are skipped.
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) saveRecordRevision(record *models.Record, isNew bool) error {
+	revision := &models.Revision{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+		Data:         types.JsonMap(revisionData(record)),
+		Changes:      types.JsonArray[string]{},
+	}
+	revision.SetActor(record.RevisionActor())
+
+	if isNew {
+		revision.Action = models.RevisionActionCreate
+
+		for _, field := range record.Collection().Schema.Fields() {
+			if field.Type != schema.FieldTypeEncrypted {
+				revision.Changes = append(revision.Changes, field.Name)
+			}
+		}
+	} else {
+		revision.Action = models.RevisionActionUpdate
+
+		var oldData map[string]any
+		if latest, err := dao.FindLatestRecordRevision(record); err == nil {
+			oldData = latest.Data
+		} else {
+			oldData = revisionData(record.OriginalCopy())
+		}
+
+		diff := models.DiffRevisionData(oldData, revision.Data)
+
+		// preserve the schema fields order
+		for _, field := range record.Collection().Schema.Fields() {
+			if _, ok := diff[field.Name]; ok {
+				revision.Changes = append(revision.Changes, field.Name)
+			}
+		}
+
+		if len(revision.Changes) == 0 {
+			return nil // nothing has changed
+		}
+	}
+
+	return dao.SaveRevision(revision)
+}
+
+// revisionData returns the record schema data that is stored in its revisions.
+//
+// The encrypted fields are excluded to avoid storing their plain values.
+func revisionData(record *models.Record) map[string]any {
+	data := record.SchemaData()
+
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Type == schema.FieldTypeEncrypted {
+			delete(data, field.Name)
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,240 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func enableTrackHistory(t *testing.T, dao *daos.Dao, collectionName string) {
+	collection, err := dao.FindCollectionByNameOrId(collectionName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Options["trackHistory"] = true
+
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatalf("Failed to enable %q history tracking: %v", collectionName, err)
+	}
+}
+
+func TestRevisionQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_revisions}}.* FROM `_revisions`"
+
+	sql := app.Dao().RevisionQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestSaveRecordWithoutTrackHistory(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, _ := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	record.Set("title", "new_title")
+
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	if err := app.Dao().RevisionQuery().Select("count(*)").Row(&total); err != nil || total != 0 {
+		t.Fatalf("Expected no revisions, got %d (%v)", total, err)
+	}
+}
+
+func TestSaveRecordWithTrackHistory(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	enableTrackHistory(t, app.Dao(), "demo2")
+
+	admin, _ := app.Dao().FindAdminByEmail("test@example.com")
+
+	// create
+	// ---
+	collection, _ := app.Dao().FindCollectionByNameOrId("demo2")
+	record := models.NewRecord(collection)
+	record.Set("title", "rev1")
+	record.SetRevisionActor(admin)
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	rev1, err := app.Dao().FindLatestRecordRevision(record)
+	if err != nil {
+		t.Fatalf("Expected the create revision, got %v", err)
+	}
+	if rev1.Action != models.RevisionActionCreate {
+		t.Fatalf("Expected action %q, got %q", models.RevisionActionCreate, rev1.Action)
+	}
+	if rev1.ActorType != models.RevisionActorAdmin || rev1.ActorId != admin.Id {
+		t.Fatalf("Expected the admin actor, got %q %q", rev1.ActorType, rev1.ActorId)
+	}
+	if v := rev1.Data.Get("title"); v != "rev1" {
+		t.Fatalf("Expected title %q, got %v", "rev1", v)
+	}
+
+	// update without changes
+	// ---
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	if err := app.Dao().RecordRevisionQuery(record).Select("count(*)").Row(&total); err != nil || total != 1 {
+		t.Fatalf("Expected 1 revision, got %d (%v)", total, err)
+	}
+
+	// update with changes
+	// ---
+	authRecord, _ := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	record.Set("title", "rev2")
+	record.SetRevisionActor(authRecord)
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	rev2, err := app.Dao().FindLatestRecordRevision(record)
+	if err != nil {
+		t.Fatalf("Expected the update revision, got %v", err)
+	}
+	if rev2.Id == rev1.Id || rev2.Action != models.RevisionActionUpdate {
+		t.Fatalf("Expected a new update revision, got %v", rev2)
+	}
+	if len(rev2.Changes) != 1 || rev2.Changes[0] != "title" {
+		t.Fatalf("Expected changes [title], got %v", rev2.Changes)
+	}
+	if rev2.ActorType != models.RevisionActorAuthRecord ||
+		rev2.ActorCollectionId != authRecord.Collection().Id ||
+		rev2.ActorId != authRecord.Id {
+		t.Fatalf("Expected the auth record actor, got %q %q %q", rev2.ActorType, rev2.ActorCollectionId, rev2.ActorId)
+	}
+
+	// find
+	// ---
+	if _, err := app.Dao().FindRevisionById(rev1.Id); err != nil {
+		t.Fatalf("Expected to find revision %q, got %v", rev1.Id, err)
+	}
+
+	if _, err := app.Dao().FindRecordRevisionById(record, rev2.Id); err != nil {
+		t.Fatalf("Expected to find record revision %q, got %v", rev2.Id, err)
+	}
+
+	otherRecord, _ := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if _, err := app.Dao().FindRecordRevisionById(otherRecord, rev2.Id); err == nil {
+		t.Fatal("Expected the revision lookup of another record to fail")
+	}
+
+	// delete
+	// ---
+	if err := app.Dao().DeleteRevision(rev1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindRevisionById(rev1.Id); err == nil {
+		t.Fatal("Expected the revision to be deleted")
+	}
+}
+
+func TestPurgeRecordDeletesRevisions(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	enableTrackHistory(t, app.Dao(), "demo2")
+
+	record, _ := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	record.Set("title", "rev1")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	otherRecord, _ := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	otherRecord.Set("title", "rev2")
+	if err := app.Dao().SaveRecord(otherRecord); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().PurgeRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	if err := app.Dao().RecordRevisionQuery(record).Select("count(*)").Row(&total); err != nil || total != 0 {
+		t.Fatalf("Expected the purged record revisions to be deleted, got %d (%v)", total, err)
+	}
+
+	if err := app.Dao().RecordRevisionQuery(otherRecord).Select("count(*)").Row(&total); err != nil || total != 1 {
+		t.Fatalf("Expected the other record revision to be preserved, got %d (%v)", total, err)
+	}
+}
+
+func TestDeleteCollectionDeletesRevisions(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "new_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Type: schema.FieldTypeText,
+				Name: "test",
+			},
+		),
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	enableTrackHistory(t, app.Dao(), "new_test")
+	enableTrackHistory(t, app.Dao(), "demo2")
+
+	record := models.NewRecord(collection)
+	record.Set("test", "rev1")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	otherRecord, _ := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	otherRecord.Set("title", "rev2")
+	if err := app.Dao().SaveRecord(otherRecord); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+
+	err := app.Dao().RevisionQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"collectionId": collection.Id}).
+		Row(&total)
+	if err != nil || total != 0 {
+		t.Fatalf("Expected the deleted collection revisions to be deleted, got %d (%v)", total, err)
+	}
+
+	if err := app.Dao().RecordRevisionQuery(otherRecord).Select("count(*)").Row(&total); err != nil || total != 1 {
+		t.Fatalf("Expected the other collection revision to be preserved, got %d (%v)", total, err)
+	}
+}
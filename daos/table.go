@@ -21,11 +21,13 @@ func (dao *Dao) HasTable(tableName string) bool {
 	return err == nil && exists
 }
 
-// TableColumns returns all column names of a single table by its name.
+// TableColumns returns all column names of a single table by its name
+// (including the generated columns).
 func (dao *Dao) TableColumns(tableName string) ([]string, error) {
 	columns := []string{}
 
-	err := dao.DB().NewQuery("SELECT name FROM PRAGMA_TABLE_INFO({:tableName})").
+	// note: hidden=1 are the virtual table hidden columns
+	err := dao.DB().NewQuery("SELECT name FROM PRAGMA_TABLE_XINFO({:tableName}) WHERE hidden != 1").
 		Bind(dbx.Params{"tableName": tableName}).
 		Column(&columns)
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/core"
@@ -17,6 +18,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
 )
 
 var collectionNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_]*$`)
@@ -138,8 +140,17 @@ func (form *CollectionUpsert) Validate() error {
 			validation.By(form.checkMinSchemaFields),
 			validation.By(form.ensureNoSystemFieldsChange),
 			validation.By(form.ensureNoFieldsTypeChange),
+			validation.By(form.ensureNoDecimalScaleChange),
 			validation.By(form.checkRelationFields),
 			validation.When(isAuth, validation.By(form.ensureNoAuthFieldName)),
+			validation.By(form.ensureNoSoftDeleteFieldName),
+			validation.By(form.checkFieldRules),
+			validation.By(form.checkComputedFields),
+			validation.By(form.checkEncryptedFields),
+			validation.By(form.checkSequenceFields),
+			validation.By(form.checkEditorFields),
+			validation.By(form.checkDerivedFields),
+			validation.By(form.checkFieldDefaults),
 		),
 		validation.Field(&form.ListRule, validation.By(form.checkRule)),
 		validation.Field(&form.ViewRule, validation.By(form.checkRule)),
@@ -226,6 +237,43 @@ func (form *CollectionUpsert) ensureNoFieldsTypeChange(value any) error {
 	return nil
 }
 
+// ensureNoDecimalScaleChange ensures that the scale of the existing
+// decimal fields is not changed (the values are stored as scaled integers).
+func (form *CollectionUpsert) ensureNoDecimalScaleChange(value any) error {
+	v, _ := value.(schema.Schema)
+
+	for i, field := range v.Fields() {
+		if field.Type != schema.FieldTypeDecimal {
+			continue
+		}
+
+		oldField := form.collection.Schema.GetFieldById(field.Id)
+		if oldField == nil || oldField.Type != field.Type {
+			continue
+		}
+
+		oldField.InitOptions()
+		field.InitOptions()
+
+		oldOptions, _ := oldField.Options.(*schema.DecimalOptions)
+		newOptions, _ := field.Options.(*schema.DecimalOptions)
+		if oldOptions == nil || newOptions == nil || oldOptions.Scale == newOptions.Scale {
+			continue
+		}
+
+		return validation.Errors{fmt.Sprint(i): validation.Errors{
+			"options": validation.Errors{
+				"scale": validation.NewError(
+					"validation_decimal_scale_change",
+					"The scale of an existing decimal field cannot be changed.",
+				),
+			}},
+		}
+	}
+
+	return nil
+}
+
 func (form *CollectionUpsert) checkRelationFields(value any) error {
 	v, _ := value.(schema.Schema)
 
@@ -321,6 +369,32 @@ func (form *CollectionUpsert) ensureNoAuthFieldName(value any) error {
 	return nil
 }
 
+func (form *CollectionUpsert) ensureNoSoftDeleteFieldName(value any) error {
+	v, _ := value.(schema.Schema)
+
+	if form.Type == models.CollectionTypeView || !cast.ToBool(form.Options["softDelete"]) {
+		return nil // no soft delete column
+	}
+
+	errs := validation.Errors{}
+	for i, field := range v.Fields() {
+		if field.Name == schema.FieldNameDeleted {
+			errs[fmt.Sprint(i)] = validation.Errors{
+				"name": validation.NewError(
+					"validation_reserved_soft_delete_field_name",
+					"The field name is reserved for the soft delete state and cannot be used.",
+				),
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
 func (form *CollectionUpsert) checkMinSchemaFields(value any) error {
 	v, _ := value.(schema.Schema)
 
@@ -376,6 +450,292 @@ func (form *CollectionUpsert) checkRule(value any) error {
 	return nil
 }
 
+// checkFieldRules validates the schema fields view and write rules.
+func (form *CollectionUpsert) checkFieldRules(value any) error {
+	v, _ := value.(schema.Schema)
+
+	for i, field := range v.Fields() {
+		if err := form.checkRule(&field.ViewRule); err != nil {
+			return validation.Errors{fmt.Sprint(i): validation.Errors{"viewRule": err}}
+		}
+
+		if err := form.checkRule(&field.WriteRule); err != nil {
+			return validation.Errors{fmt.Sprint(i): validation.Errors{"writeRule": err}}
+		}
+	}
+
+	return nil
+}
+
+// checkEncryptedFields ensures that there is a valid
+// records encryption key if the schema has encrypted fields.
+func (form *CollectionUpsert) checkEncryptedFields(value any) error {
+	v, _ := value.(schema.Schema)
+
+	keyErr := models.CheckRecordEncryptionKey(form.dao.EncryptionKey())
+	if keyErr == nil {
+		return nil
+	}
+
+	for i, field := range v.Fields() {
+		if field.Type != schema.FieldTypeEncrypted {
+			continue
+		}
+
+		return validation.Errors{strconv.Itoa(i): validation.Errors{
+			"type": validation.NewError(
+				"validation_invalid_encryption_key",
+				fmt.Sprintf("The encrypted fields require a valid app encryption key (%s).", keyErr),
+			),
+		}}
+	}
+
+	return nil
+}
+
+// checkSequenceFields validates the sequence fields scope
+// against the other collection fields.
+func (form *CollectionUpsert) checkSequenceFields(value any) error {
+	v, _ := value.(schema.Schema)
+
+	for i, field := range v.Fields() {
+		if field.Type != schema.FieldTypeSequence {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.SequenceOptions)
+		if options == nil || options.Scope == "" {
+			continue
+		}
+
+		scopeField := v.GetFieldByName(options.Scope)
+
+		isValid := scopeField != nil
+		if isValid {
+			switch scopeField.Type {
+			case schema.FieldTypeText,
+				schema.FieldTypeNumber,
+				schema.FieldTypeBool,
+				schema.FieldTypeEmail,
+				schema.FieldTypeUrl,
+				schema.FieldTypeDate,
+				schema.FieldTypeSelect,
+				schema.FieldTypeRelation:
+				multiValuer, ok := scopeField.Options.(schema.MultiValuer)
+				isValid = !ok || !multiValuer.IsMultiple()
+			default:
+				isValid = false
+			}
+		}
+
+		if !isValid {
+			return validation.Errors{strconv.Itoa(i): validation.Errors{
+				"options": validation.Errors{
+					"scope": validation.NewError(
+						"validation_invalid_sequence_scope",
+						"The scope must be the name of a single value text, number, bool, email, url, date, select or relation field.",
+					),
+				}},
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkEditorFields ensures that the editor fields plain text companion
+// is a text field that is not used by another editor field.
+func (form *CollectionUpsert) checkEditorFields(value any) error {
+	v, _ := value.(schema.Schema)
+
+	used := map[string]struct{}{}
+
+	for i, field := range v.Fields() {
+		if field.Type != schema.FieldTypeEditor {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.EditorOptions)
+		if options == nil || options.PlainTextField == "" {
+			continue
+		}
+
+		textField := v.GetFieldByName(options.PlainTextField)
+
+		_, isUsed := used[options.PlainTextField]
+
+		if isUsed || textField == nil || textField.Type != schema.FieldTypeText {
+			return validation.Errors{strconv.Itoa(i): validation.Errors{
+				"options": validation.Errors{
+					"plainTextField": validation.NewError(
+						"validation_invalid_plain_text_field",
+						"The plain text field must be the name of a text field that is not used by another editor field.",
+					),
+				}},
+			}
+		}
+
+		used[options.PlainTextField] = struct{}{}
+	}
+
+	return nil
+}
+
+// checkDerivedFields ensures that the text fields DeriveFrom
+// option references another single value text-like field.
+func (form *CollectionUpsert) checkDerivedFields(value any) error {
+	v, _ := value.(schema.Schema)
+
+	for i, field := range v.Fields() {
+		if field.Type != schema.FieldTypeText {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.TextOptions)
+		if options == nil || options.DeriveFrom == "" {
+			continue
+		}
+
+		sourceField := v.GetFieldByName(options.DeriveFrom)
+
+		isValid := sourceField != nil && sourceField.Name != field.Name
+		if isValid {
+			switch sourceField.Type {
+			case schema.FieldTypeText,
+				schema.FieldTypeNumber,
+				schema.FieldTypeEmail,
+				schema.FieldTypeUrl,
+				schema.FieldTypeSelect:
+				multiValuer, ok := sourceField.Options.(schema.MultiValuer)
+				isValid = !ok || !multiValuer.IsMultiple()
+			default:
+				isValid = false
+			}
+		}
+
+		if !isValid {
+			return validation.Errors{strconv.Itoa(i): validation.Errors{
+				"options": validation.Errors{
+					"deriveFrom": validation.NewError(
+						"validation_invalid_derive_from",
+						"The derive from field must be the name of another single value text, number, email, url or select field.",
+					),
+				}},
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkComputedFields validates the computed fields expressions
+// against the other (non-computed) collection fields.
+func (form *CollectionUpsert) checkComputedFields(value any) error {
+	v, _ := value.(schema.Schema)
+
+	if form.Type == models.CollectionTypeView {
+		return nil // the view schema is autogenerated
+	}
+
+	allowed := map[string]struct{}{}
+	for _, name := range schema.BaseModelFieldNames() {
+		allowed[name] = struct{}{}
+	}
+	if form.Type == models.CollectionTypeAuth {
+		for _, name := range schema.AuthFieldNames() {
+			allowed[name] = struct{}{}
+		}
+	}
+	for _, field := range v.Fields() {
+		// the encrypted and decimal (scaled integer) fields db values are not usable in expressions
+		if field.Type != schema.FieldTypeComputed &&
+			field.Type != schema.FieldTypeEncrypted &&
+			field.Type != schema.FieldTypeDecimal {
+			allowed[field.Name] = struct{}{}
+		}
+	}
+
+	for i, field := range v.Fields() {
+		if field.Type != schema.FieldTypeComputed {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.ComputedOptions)
+		if options == nil {
+			continue // the options are validated separately
+		}
+
+		parsed, err := schema.ParseComputedExpression(options.Expression)
+		if err != nil {
+			continue // the expression syntax is validated separately
+		}
+
+		invalidErr := func(msg string) error {
+			return validation.Errors{fmt.Sprint(i): validation.Errors{
+				"options": validation.Errors{
+					"expression": validation.NewError("validation_invalid_computed_expression", msg),
+				}},
+			}
+		}
+
+		cols := make([]string, 0, len(parsed.Identifiers()))
+		for _, name := range parsed.Identifiers() {
+			if _, ok := allowed[name]; !ok {
+				return invalidErr(fmt.Sprintf("Unknown or computed field %q.", name))
+			}
+			cols = append(cols, "NULL AS [["+name+"]]")
+		}
+
+		// dry run the expression to catch the remaining db errors
+		// (eg. invalid number of function arguments)
+		query := "SELECT (" + parsed.SQL() + ")"
+		if len(cols) > 0 {
+			query += " FROM (SELECT " + strings.Join(cols, ", ") + ")"
+		}
+		if _, err := form.dao.DB().NewQuery(query).Execute(); err != nil {
+			return invalidErr("Invalid expression. Raw error: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// checkFieldDefaults validates the static schema fields
+// default values against their own field options.
+func (form *CollectionUpsert) checkFieldDefaults(value any) error {
+	v, _ := value.(schema.Schema)
+
+	if form.Type == models.CollectionTypeView {
+		return nil // the view schema is autogenerated
+	}
+
+	for i, field := range v.Fields() {
+		defaultValue, ok := field.DefaultValue()
+		if !ok || field.DefaultMacro() != "" {
+			continue // no default or macro (the macros are validated with the field options)
+		}
+
+		// validate only the default value without the other field constraints
+		dummyField := *field
+		dummyField.Required = false
+		dummy := &models.Collection{Schema: schema.NewSchema(&dummyField)}
+
+		err := validators.NewRecordDataValidator(form.dao, models.NewRecord(dummy), nil).
+			Validate(map[string]any{field.Name: defaultValue})
+		if err != nil {
+			if errs, ok := err.(validation.Errors); ok && errs[field.Name] != nil {
+				err = errs[field.Name]
+			}
+
+			return validation.Errors{fmt.Sprint(i): validation.Errors{
+				"options": validation.Errors{"default": err},
+			}}
+		}
+	}
+
+	return nil
+}
+
 func (form *CollectionUpsert) checkIndexes(value any) error {
 	v, _ := value.(types.JsonArray[string])
 
@@ -418,6 +778,27 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 	v, _ := value.(types.JsonMap)
 
 	switch form.Type {
+	case models.CollectionTypeBase:
+		options := models.CollectionBaseOptions{}
+		if err := decodeOptions(v, &options); err != nil {
+			return err
+		}
+
+		// check the generic validations
+		if err := options.Validate(); err != nil {
+			return err
+		}
+
+		// additional form specific validations
+		if err := form.checkSoftDelete(options.SoftDelete); err != nil {
+			return validation.Errors{"softDelete": err}
+		}
+		if err := form.checkSearchFields(options.SearchFields); err != nil {
+			return validation.Errors{"searchFields": err}
+		}
+		if err := form.checkValidationRules(options.ValidationRules); err != nil {
+			return validation.Errors{"validationRules": err}
+		}
 	case models.CollectionTypeAuth:
 		options := models.CollectionAuthOptions{}
 		if err := decodeOptions(v, &options); err != nil {
@@ -433,6 +814,15 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 		if err := form.checkRule(options.ManageRule); err != nil {
 			return validation.Errors{"manageRule": err}
 		}
+		if err := form.checkSoftDelete(options.SoftDelete); err != nil {
+			return validation.Errors{"softDelete": err}
+		}
+		if err := form.checkSearchFields(options.SearchFields); err != nil {
+			return validation.Errors{"searchFields": err}
+		}
+		if err := form.checkValidationRules(options.ValidationRules); err != nil {
+			return validation.Errors{"validationRules": err}
+		}
 	case models.CollectionTypeView:
 		options := models.CollectionViewOptions{}
 		if err := decodeOptions(v, &options); err != nil {
@@ -458,6 +848,95 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 	return nil
 }
 
+// checkSoftDelete ensures that the soft delete option
+// is not disabled while there are still trashed records.
+func (form *CollectionUpsert) checkSoftDelete(softDelete bool) error {
+	if softDelete || form.collection.IsNew() {
+		return nil
+	}
+
+	// note: refetch the collection because the form options
+	// could share the same map with the form collection
+	oldCollection, err := form.dao.FindCollectionByNameOrId(form.collection.Id)
+	if err != nil || !oldCollection.HasSoftDelete() {
+		return nil // not previously enabled
+	}
+
+	var total int
+	err = form.dao.TrashedRecordQuery(oldCollection).Select("count(*)").Row(&total)
+	if err != nil || total > 0 {
+		return validation.NewError(
+			"validation_soft_delete_trash_not_empty",
+			"The trashed records must be restored or purged before disabling the soft delete.",
+		)
+	}
+
+	return nil
+}
+
+// checkSearchFields ensures that the full-text search fields
+// are unique existing text, editor, email or url schema fields.
+func (form *CollectionUpsert) checkSearchFields(names []string) error {
+	if len(names) > 0 && !form.dao.HasFullTextSearchSupport() {
+		return validation.NewError(
+			"validation_search_unsupported",
+			"The current SQLite build doesn't support full-text search (FTS5).",
+		)
+	}
+
+	for i, name := range names {
+		field := form.Schema.GetFieldByName(name)
+
+		if field == nil || !list.ExistInSlice(field.Type, searchableFieldTypes) {
+			return validation.NewError(
+				"validation_invalid_search_field",
+				fmt.Sprintf("The search field %q must be an existing text, editor, email or url field.", name),
+			)
+		}
+
+		if list.ExistInSlice(name, names[:i]) {
+			return validation.NewError(
+				"validation_duplicated_search_field",
+				fmt.Sprintf("Duplicated search field %q.", name),
+			)
+		}
+	}
+
+	return nil
+}
+
+// checkValidationRules ensures that the collection validation rules
+// have valid filter expressions and existing target fields.
+func (form *CollectionUpsert) checkValidationRules(rules []models.CollectionValidationRule) error {
+	fieldNames := schema.BaseModelFieldNames()
+	if form.Type == models.CollectionTypeAuth {
+		fieldNames = append(fieldNames, schema.AuthFieldNames()...)
+	}
+
+	for i, rule := range rules {
+		if err := form.checkRule(&rule.Expression); err != nil {
+			return validation.Errors{strconv.Itoa(i): validation.Errors{"expression": err}}
+		}
+
+		if form.Schema.GetFieldByName(rule.Field) == nil && !list.ExistInSlice(rule.Field, fieldNames) {
+			return validation.Errors{strconv.Itoa(i): validation.Errors{"field": validation.NewError(
+				"validation_invalid_rule_field",
+				fmt.Sprintf("The rule field %q must be an existing collection field.", rule.Field),
+			)}}
+		}
+	}
+
+	return nil
+}
+
+// searchableFieldTypes lists the field types that could be full-text indexed.
+var searchableFieldTypes = []string{
+	schema.FieldTypeText,
+	schema.FieldTypeEditor,
+	schema.FieldTypeEmail,
+	schema.FieldTypeUrl,
+}
+
 func decodeOptions(options types.JsonMap, result any) error {
 	raw, err := options.MarshalJSON()
 	if err != nil {
@@ -814,3 +814,674 @@ func TestCollectionUpsertWithCustomId(t *testing.T) {
 		}
 	}
 }
+
+func TestCollectionUpsertSoftDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submit := func(jsonData string) validation.Errors {
+		form := forms.NewCollectionUpsert(app, collection)
+		if err := json.Unmarshal([]byte(jsonData), form); err != nil {
+			t.Fatalf("Failed to load form data: %v", err)
+		}
+
+		err := form.Submit()
+		if err == nil {
+			return nil
+		}
+
+		errs, ok := err.(validation.Errors)
+		if !ok {
+			t.Fatalf("Failed to parse errors %v", err)
+		}
+
+		return errs
+	}
+
+	// reserved "deleted" field name
+	errs := submit(`{"options":{"softDelete":true},"schema":[{"name":"deleted","type":"text"}]}`)
+	if _, ok := errs["schema"]; !ok {
+		t.Fatalf("Expected schema error, got %v", errs)
+	}
+
+	// enable
+	if errs := submit(`{"options":{"softDelete":true}}`); errs != nil {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+	if !collection.HasSoftDelete() {
+		t.Fatal("Expected soft delete to be enabled")
+	}
+
+	record, _ := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// disable with nonempty trash
+	errs = submit(`{"options":{"softDelete":false}}`)
+	if _, ok := errs["options"]; !ok {
+		t.Fatalf("Expected options error, got %v", errs)
+	}
+
+	// disable with empty trash
+	if err := app.Dao().PurgeRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if errs := submit(`{"options":{"softDelete":false}}`); errs != nil {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+	if collection.HasSoftDelete() {
+		t.Fatal("Expected soft delete to be disabled")
+	}
+}
+
+func TestCollectionUpsertSearchFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submit := func(jsonData string) validation.Errors {
+		form := forms.NewCollectionUpsert(app, collection)
+		if err := json.Unmarshal([]byte(jsonData), form); err != nil {
+			t.Fatalf("Failed to load form data: %v", err)
+		}
+
+		err := form.Submit()
+		if err == nil {
+			return nil
+		}
+
+		errs, ok := err.(validation.Errors)
+		if !ok {
+			t.Fatalf("Failed to parse errors %v", err)
+		}
+
+		return errs
+	}
+
+	searchFieldsErrorCode := func(errs validation.Errors) string {
+		optionsErrs, _ := errs["options"].(validation.Errors)
+		searchFieldsErr, _ := optionsErrs["searchFields"].(validation.Error)
+		if searchFieldsErr == nil {
+			return ""
+		}
+		return searchFieldsErr.Code()
+	}
+
+	if !app.Dao().HasFullTextSearchSupport() {
+		errs := submit(`{"options":{"searchFields":["title"]}}`)
+		if code := searchFieldsErrorCode(errs); code != "validation_search_unsupported" {
+			t.Fatalf("Expected validation_search_unsupported error, got %v", errs)
+		}
+		t.Skip("FTS5 is not supported by the current SQLite build")
+	}
+
+	scenarios := []struct {
+		data         string
+		expectedCode string
+	}{
+		{`{"options":{"searchFields":["missing"]}}`, "validation_invalid_search_field"},
+		{`{"options":{"searchFields":["active"]}}`, "validation_invalid_search_field"},
+		{`{"options":{"searchFields":["title","title"]}}`, "validation_duplicated_search_field"},
+		{`{"options":{"searchFields":["title"]}}`, ""},
+		{`{"options":{}}`, ""},
+	}
+
+	for i, s := range scenarios {
+		errs := submit(s.data)
+
+		if code := searchFieldsErrorCode(errs); code != s.expectedCode {
+			t.Fatalf("[%d] Expected error code %q, got %v", i, s.expectedCode, errs)
+		}
+
+		if s.expectedCode != "" {
+			continue
+		}
+
+		hasTable := app.Dao().HasTable(collection.SearchTableName())
+		if hasTable != (len(collection.SearchFields()) > 0) {
+			t.Fatalf("[%d] Expected search table exists to be %v", i, !hasTable)
+		}
+	}
+}
+
+func TestCollectionUpsertFieldRules(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		viewRule       string
+		writeRule      string
+		expectedErrKey string
+	}{
+		{"", "", ""},
+		{"title = 'test' && @request.auth.id != ''", "@request.data.title != ''", ""},
+		{"missing = 1", "", "viewRule"},
+		{"", "title ?!@ 1", "writeRule"},
+	}
+
+	for i, s := range scenarios {
+		collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form := forms.NewCollectionUpsert(app, collection)
+
+		field := form.Schema.GetFieldByName("active")
+		field.ViewRule = s.viewRule
+		field.WriteRule = s.writeRule
+
+		err = form.Validate()
+
+		errs, _ := err.(validation.Errors)
+		schemaErrs, _ := errs["schema"].(validation.Errors)
+
+		var fieldErrs validation.Errors
+		for _, e := range schemaErrs {
+			fieldErrs, _ = e.(validation.Errors)
+		}
+
+		if s.expectedErrKey == "" {
+			if err != nil {
+				t.Errorf("[%d] Expected no errors, got %v", i, err)
+			}
+			continue
+		}
+
+		if _, ok := fieldErrs[s.expectedErrKey]; !ok {
+			t.Errorf("[%d] Expected %q field error, got %v", i, s.expectedErrKey, err)
+		}
+	}
+}
+
+func TestCollectionUpsertComputedFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		collection  string
+		expression  string
+		expectError bool
+	}{
+		{"demo2", "upper(title)", false},
+		{"demo2", "id || ' ' || created || ' ' || active", false},
+		{"demo2", "missing + 1", true},
+		{"demo2", "computed || 'a'", true}, // self reference
+		{"demo2", "email", true},           // auth field in non-auth collection
+		{"demo2", "title +", true},
+		{"demo2", "substr()", true},
+		{"demo2", "random()", true},
+		{"users", "email || username || name", false},
+	}
+
+	for i, s := range scenarios {
+		collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form := forms.NewCollectionUpsert(app, collection)
+		form.Schema.AddField(&schema.SchemaField{
+			Name: "computed",
+			Type: schema.FieldTypeComputed,
+			Options: &schema.ComputedOptions{
+				Expression: s.expression,
+				ValueType:  schema.FieldTypeText,
+			},
+		})
+
+		err = form.Validate()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			errs, _ := err.(validation.Errors)
+			if _, ok := errs["schema"]; !ok || len(errs) != 1 {
+				t.Errorf("[%d] Expected only schema error, got %v", i, err)
+			}
+		}
+	}
+}
+
+func TestCollectionUpsertFieldDefaults(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		field       string
+		value       any
+		expectError bool
+	}{
+		{"text", "test", false},
+		{"text", "@now", false},
+		{"text", "@missing", true},
+		{"number", 123, false},
+		{"number", "123", true},
+		{"number", "abc", true},
+		{"bool", true, false},
+		{"bool", "@now", true},
+		{"email", "test@example.com", false},
+		{"email", "invalid", true},
+		{"select_one", []string{"optionB"}, false},
+		{"select_one", []string{"missing"}, true},
+		{"rel_one", "84nmscqy84lsi1t", false},
+		{"rel_one", "missing", true},
+		{"file_one", "test.txt", false}, // not supported (ignored)
+		{"json", map[string]any{"a": 1}, false},
+	}
+
+	for i, s := range scenarios {
+		collection, err := app.Dao().FindCollectionByNameOrId("demo1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form := forms.NewCollectionUpsert(app, collection)
+
+		// set the default through the serialized field options
+		// (similar to a regular collection upsert request)
+		field := form.Schema.GetFieldByName(s.field)
+		rawOptions, _ := json.Marshal(field.Options)
+		options := map[string]any{}
+		json.Unmarshal(rawOptions, &options)
+		options["default"] = s.value
+		field.Options = options
+
+		err = field.InitOptions()
+		if err == nil {
+			err = form.Validate()
+		}
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if errs, ok := err.(validation.Errors); ok {
+			schemaErrs, _ := errs["schema"].(validation.Errors)
+
+			var optionsErrs validation.Errors
+			for _, e := range schemaErrs {
+				fieldErrs, _ := e.(validation.Errors)
+				optionsErrs, _ = fieldErrs["options"].(validation.Errors)
+			}
+
+			if _, ok := optionsErrs["default"]; !ok {
+				t.Errorf("[%d] Expected options.default field error, got %v", i, err)
+			}
+		}
+	}
+}
+
+func TestCollectionUpsertEncryptedFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		key         string
+		expression  string
+		expectError bool
+	}{
+		{"", "", true},
+		{"invalid", "", true},
+		{"abcdabcdabcdabcdabcdabcdabcdabcd", "", false},
+		// encrypted fields can't be referenced in computed expressions
+		{"abcdabcdabcdabcdabcdabcdabcdabcd", "upper(secret)", true},
+	}
+
+	for i, s := range scenarios {
+		app.Dao().SetEncryptionKey(s.key)
+
+		collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form := forms.NewCollectionUpsert(app, collection)
+		form.Schema.AddField(&schema.SchemaField{
+			Name:    "secret",
+			Type:    schema.FieldTypeEncrypted,
+			Options: &schema.EncryptedOptions{BlindIndex: true},
+		})
+		if s.expression != "" {
+			form.Schema.AddField(&schema.SchemaField{
+				Name: "computed",
+				Type: schema.FieldTypeComputed,
+				Options: &schema.ComputedOptions{
+					Expression: s.expression,
+					ValueType:  schema.FieldTypeText,
+				},
+			})
+		}
+
+		err = form.Validate()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			errs, _ := err.(validation.Errors)
+			if _, ok := errs["schema"]; !ok || len(errs) != 1 {
+				t.Errorf("[%d] Expected only schema error, got %v", i, err)
+			}
+		}
+	}
+}
+
+func TestCollectionUpsertSequenceFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		collection  string
+		scope       string
+		expectError bool
+	}{
+		{"demo2", "", false},
+		{"demo2", "missing", true},
+		{"demo2", "seq", true}, // self reference
+		{"demo2", "title", false},
+		{"demo1", "file_one", true},
+		{"demo1", "json", true},
+		{"demo1", "select_many", true},
+		{"demo1", "select_one", false},
+		{"demo1", "rel_many", true},
+		{"demo1", "rel_one", false},
+	}
+
+	for i, s := range scenarios {
+		collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form := forms.NewCollectionUpsert(app, collection)
+		form.Schema.AddField(&schema.SchemaField{
+			Name:    "seq",
+			Type:    schema.FieldTypeSequence,
+			Options: &schema.SequenceOptions{Scope: s.scope},
+		})
+
+		err = form.Validate()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			errs, _ := err.(validation.Errors)
+			if _, ok := errs["schema"]; !ok || len(errs) != 1 {
+				t.Errorf("[%d] Expected only schema error, got %v", i, err)
+			}
+		}
+	}
+}
+
+func TestCollectionUpsertDecimalFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "price",
+		Type:    schema.FieldTypeDecimal,
+		Options: &schema.DecimalOptions{Precision: 10, Scale: 2},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		options     *schema.DecimalOptions
+		expression  string
+		expectError bool
+	}{
+		{"unchanged options", &schema.DecimalOptions{Precision: 10, Scale: 2}, "", false},
+		{"changed precision", &schema.DecimalOptions{Precision: 12, Scale: 2}, "", false},
+		{"changed scale", &schema.DecimalOptions{Precision: 10, Scale: 3}, "", true},
+		{"computed expression with decimal field", &schema.DecimalOptions{Precision: 10, Scale: 2}, "price * 2", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewCollectionUpsert(app, collection)
+			form.Schema.GetFieldByName("price").Options = s.options
+			if s.expression != "" {
+				form.Schema.AddField(&schema.SchemaField{
+					Name: "total",
+					Type: schema.FieldTypeComputed,
+					Options: &schema.ComputedOptions{
+						Expression: s.expression,
+						ValueType:  schema.FieldTypeNumber,
+					},
+				})
+			}
+
+			err = form.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				errs, _ := err.(validation.Errors)
+				if _, ok := errs["schema"]; !ok || len(errs) != 1 {
+					t.Fatalf("Expected only schema error, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertEditorFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		plainTextField string
+		extraField     *schema.SchemaField
+		expectError    bool
+	}{
+		{"no plain text field", "", nil, false},
+		{"missing plain text field", "missing", nil, true},
+		{"non-text plain text field", "active", nil, true},
+		{"text plain text field", "title", nil, false},
+		{
+			"plain text field used by another editor field",
+			"title",
+			&schema.SchemaField{
+				Name:    "content2",
+				Type:    schema.FieldTypeEditor,
+				Options: &schema.EditorOptions{PlainTextField: "title"},
+			},
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewCollectionUpsert(app, collection)
+			form.Schema.AddField(&schema.SchemaField{
+				Name:    "content",
+				Type:    schema.FieldTypeEditor,
+				Options: &schema.EditorOptions{PlainTextField: s.plainTextField},
+			})
+			if s.extraField != nil {
+				form.Schema.AddField(s.extraField)
+			}
+
+			err = form.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				errs, _ := err.(validation.Errors)
+				if _, ok := errs["schema"]; !ok || len(errs) != 1 {
+					t.Fatalf("Expected only schema error, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertDerivedFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		collection  string
+		deriveFrom  string
+		expectError bool
+	}{
+		{"demo2", "", false},
+		{"demo2", "missing", true},
+		{"demo2", "slug", true}, // self reference
+		{"demo2", "active", true},
+		{"demo2", "title", false},
+		{"demo1", "file_one", true},
+		{"demo1", "json", true},
+		{"demo1", "select_many", true},
+		{"demo1", "select_one", false},
+		{"demo1", "number", false},
+		{"demo1", "rel_one", true},
+	}
+
+	for i, s := range scenarios {
+		collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form := forms.NewCollectionUpsert(app, collection)
+		form.Schema.AddField(&schema.SchemaField{
+			Name:    "slug",
+			Type:    schema.FieldTypeText,
+			Options: &schema.TextOptions{DeriveFrom: s.deriveFrom},
+		})
+
+		err = form.Validate()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			errs, _ := err.(validation.Errors)
+			if _, ok := errs["schema"]; !ok || len(errs) != 1 {
+				t.Errorf("[%d] Expected only schema error, got %v", i, err)
+			}
+		}
+	}
+}
+
+func TestCollectionUpsertValidationRules(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		collection  string
+		expression  string
+		field       string
+		expectError bool
+	}{
+		{"demo2", "", "title", true},
+		{"demo2", "title ?!@ 1", "title", true},
+		{"demo2", "missing = 1", "title", true},
+		{"demo2", "title != ''", "", true},
+		{"demo2", "title != ''", "missing", true},
+		{"demo2", "title != ''", "email", true}, // auth only field
+		{"demo2", "title != '' || @request.auth.id != ''", "title", false},
+		{"demo2", "active = true", "id", false},
+		{"users", "email != '' || username != ''", "email", false},
+	}
+
+	for i, s := range scenarios {
+		collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form := forms.NewCollectionUpsert(app, collection)
+		form.Options["validationRules"] = []any{
+			map[string]any{"expression": s.expression, "field": s.field, "message": "test"},
+		}
+
+		err = form.Validate()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			errs, _ := err.(validation.Errors)
+			if _, ok := errs["options"]; !ok || len(errs) != 1 {
+				t.Errorf("[%d] Expected only options error, got %v", i, err)
+			}
+		}
+	}
+}
@@ -19,24 +19,32 @@ import (
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/mailer"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
 // username value regex pattern
 var usernameRegex = regexp.MustCompile(`^[\w][\w\.\-]*$`)
 
+var errDryRunRollback = errors.New("record upsert dry run rollback")
+
 // RecordUpsert is a [models.Record] upsert (create/update) form.
 type RecordUpsert struct {
 	app          core.App
 	dao          *daos.Dao
 	manageAccess bool
+	ifMatch      string
 	record       *models.Record
+	requestInfo  *models.RequestInfo
 
 	filesToUpload map[string][]*filesystem.File
 	filesToDelete []string // names list
 
+	decimalIncrements map[string]types.Decimal // the decimal fields "+" and "-" modifiers net delta
+
 	// base model fields
 	Id string `json:"id"`
 
@@ -62,11 +70,12 @@ type RecordUpsert struct {
 // you can change the default Dao via [SetDao()].
 func NewRecordUpsert(app core.App, record *models.Record) *RecordUpsert {
 	form := &RecordUpsert{
-		app:           app,
-		dao:           app.Dao(),
-		record:        record,
-		filesToDelete: []string{},
-		filesToUpload: map[string][]*filesystem.File{},
+		app:               app,
+		dao:               app.Dao(),
+		record:            record,
+		filesToDelete:     []string{},
+		filesToUpload:     map[string][]*filesystem.File{},
+		decimalIncrements: map[string]types.Decimal{},
 	}
 
 	form.loadFormDefaults()
@@ -91,6 +100,40 @@ func (form *RecordUpsert) SetDao(dao *daos.Dao) {
 	form.dao = dao
 }
 
+// SetIfMatch sets the expected version (aka. "If-Match" etag(s)) of
+// the form record. If set, on Submit the latest persisted record state
+// is checked against it and [daos.ErrRecordETagMismatch] is returned
+// in case the record was modified in the meantime.
+//
+// It has no effect when creating a new record.
+func (form *RecordUpsert) SetIfMatch(ifMatch string) {
+	form.ifMatch = ifMatch
+}
+
+// SetRequestInfo sets the request info used to resolve the "@request.*"
+// identifiers of the collection validation rules and assigns the request
+// related default values (eg. "@request.auth.id") to the empty fields
+// of the new form record.
+//
+// The default values are not applied when updating an existing record.
+func (form *RecordUpsert) SetRequestInfo(requestInfo *models.RequestInfo) {
+	form.requestInfo = requestInfo
+
+	if !form.record.IsNew() {
+		return
+	}
+
+	for _, field := range form.record.Collection().Schema.Fields() {
+		if !strings.HasPrefix(field.DefaultMacro(), "@request.") || !validation.IsEmpty(form.data[field.Name]) {
+			continue
+		}
+
+		if v, ok := models.FieldDefaultValue(field, requestInfo); ok {
+			form.data[field.Name] = field.PrepareValue(v)
+		}
+	}
+}
+
 func (form *RecordUpsert) loadFormDefaults() {
 	form.Id = form.record.Id
 
@@ -384,6 +427,8 @@ func (form *RecordUpsert) LoadData(requestData map[string]any) error {
 		}
 	}
 
+	form.loadDecimalIncrements(requestData)
+
 	// replace modifiers (if any)
 	requestData = form.record.ReplaceModifers(requestData)
 
@@ -402,10 +447,32 @@ func (form *RecordUpsert) LoadData(requestData map[string]any) error {
 		return err
 	}
 
+	plainTextFields := editorPlainTextFields(form.record.Collection())
+
 	for _, field := range form.record.Collection().Schema.Fields() {
+		if field.IsAutoManaged() {
+			continue // read-only (generated by the db)
+		}
+
 		key := field.Name
+
+		if _, ok := plainTextFields[key]; ok {
+			continue // read-only (extracted from the editor field on save)
+		}
+
 		value := field.PrepareValue(extendedData[key])
 
+		if field.Type == schema.FieldTypeEditor {
+			sanitized, err := sanitizeEditorValue(field, value)
+			if err != nil {
+				return validation.Errors{key: validation.NewError(
+					"validation_invalid_html",
+					"Failed to sanitize the html value.",
+				)}
+			}
+			value = sanitized
+		}
+
 		if field.Type != schema.FieldTypeFile {
 			form.data[key] = value
 			continue
@@ -458,6 +525,42 @@ func (form *RecordUpsert) LoadData(requestData map[string]any) error {
 	return nil
 }
 
+// loadDecimalIncrements extracts the net delta of the decimal fields
+// "+" and "-" modifiers from the provided request data so that they
+// could be applied atomically by the db on update.
+func (form *RecordUpsert) loadDecimalIncrements(requestData map[string]any) {
+	for _, field := range form.record.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeDecimal {
+			continue
+		}
+
+		key := field.Name
+
+		if _, ok := requestData[key]; ok {
+			delete(form.decimalIncrements, key) // explicitly assigned value
+			continue
+		}
+
+		addValue, hasAdd := requestData[key+schema.FieldValueModifierAdd]
+		subtractValue, hasSubtract := requestData[key+schema.FieldValueModifierSubtract]
+		if !hasAdd && !hasSubtract {
+			continue
+		}
+
+		delta := types.Decimal{}
+		if hasAdd {
+			addDelta, _ := field.PrepareValue(addValue).(types.Decimal)
+			delta = delta.Add(addDelta)
+		}
+		if hasSubtract {
+			subtractDelta, _ := field.PrepareValue(subtractValue).(types.Decimal)
+			delta = delta.Sub(subtractDelta)
+		}
+
+		form.decimalIncrements[key] = delta
+	}
+}
+
 // Validate makes the form validatable by implementing [validation.Validatable] interface.
 func (form *RecordUpsert) Validate() error {
 	// base form fields validator
@@ -679,6 +782,49 @@ func (form *RecordUpsert) ValidateAndFill() error {
 	// bulk load the remaining form data
 	form.record.Load(form.data)
 
+	// apply the decimal modifiers as db increments of the stored value
+	// to prevent overwriting the concurrent changes of the same field
+	for key, delta := range form.decimalIncrements {
+		form.record.SetDecimalIncrement(key, delta)
+	}
+
+	return form.fillEditorPlainTextFields()
+}
+
+// fillEditorPlainTextFields extracts the plain text version of the
+// record editor fields into their PlainTextField companion (if any).
+//
+// The extracted text is truncated to the companion text field max length constraint.
+func (form *RecordUpsert) fillEditorPlainTextFields() error {
+	collection := form.record.Collection()
+
+	for _, field := range collection.Schema.Fields() {
+		if field.Type != schema.FieldTypeEditor {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.EditorOptions)
+		if options == nil || options.PlainTextField == "" {
+			continue
+		}
+
+		plain, err := mailer.HTML2Text(form.record.GetString(field.Name))
+		if err != nil {
+			return err
+		}
+
+		if textField := collection.Schema.GetFieldByName(options.PlainTextField); textField != nil {
+			textOptions, _ := textField.Options.(*schema.TextOptions)
+			if textOptions != nil && textOptions.Max != nil {
+				if runes := []rune(plain); len(runes) > *textOptions.Max {
+					plain = strings.TrimSpace(string(runes[:*textOptions.Max]))
+				}
+			}
+		}
+
+		form.record.Set(options.PlainTextField, plain)
+	}
+
 	return nil
 }
 
@@ -687,43 +833,68 @@ func (form *RecordUpsert) ValidateAndFill() error {
 //
 // This method doesn't handle file uploads/deletes or trigger any app events!
 func (form *RecordUpsert) DrySubmit(callback func(txDao *daos.Dao) error) error {
-	isNew := form.record.IsNew()
-
 	if err := form.ValidateAndFill(); err != nil {
 		return err
 	}
 
-	var dryDao *daos.Dao
-	if form.dao.ConcurrentDB() == form.dao.NonconcurrentDB() {
-		// it is already in a transaction and therefore use the app concurrent db pool
-		// to prevent "transaction has already been committed or rolled back" error
-		dryDao = daos.New(form.app.Dao().ConcurrentDB())
-	} else {
-		// otherwise use the form noncurrent dao db pool
-		dryDao = daos.New(form.dao.NonconcurrentDB())
-	}
+	return form.dryRun(form.record, func(txDao *daos.Dao) error {
+		if err := form.checkValidationRules(txDao, form.record); err != nil {
+			return err
+		}
 
-	return dryDao.RunInTransaction(func(txDao *daos.Dao) error {
-		tx, ok := txDao.DB().(*dbx.Tx)
-		if !ok {
-			return errors.New("failed to get transaction db")
+		if callback != nil {
+			return callback(txDao)
 		}
-		defer tx.Rollback()
 
-		if err := txDao.SaveRecord(form.record); err != nil {
+		return nil
+	})
+}
+
+// dryRun saves the provided (already filled) record within a transaction,
+// calls the callback and reverts the changes.
+func (form *RecordUpsert) dryRun(record *models.Record, callback func(txDao *daos.Dao) error) error {
+	isNew := record.IsNew()
+
+	dryAction := func(txDao *daos.Dao) error {
+		if err := txDao.SaveRecord(record); err != nil {
 			return form.prepareError(err)
 		}
 
 		// restore record isNew state
 		if isNew {
-			form.record.MarkAsNew()
+			record.MarkAsNew()
 		}
 
-		if callback != nil {
-			return callback(txDao)
+		return callback(txDao)
+	}
+
+	// it is already in a transaction and therefore use a savepoint
+	// so that the dry changes are applied on top of the current
+	// transaction state and are reverted without affecting it
+	if _, ok := form.dao.NonconcurrentDB().(*dbx.Tx); ok {
+		err := form.dao.WithoutHooks().RunInSavepoint(func(spDao *daos.Dao) error {
+			if err := dryAction(spDao); err != nil {
+				return err
+			}
+
+			return errDryRunRollback // always revert
+		})
+		if errors.Is(err, errDryRunRollback) && !errors.Is(err, daos.ErrSavepointFailed) {
+			return nil
 		}
 
-		return nil
+		return err
+	}
+
+	// otherwise use the form noncurrent dao db pool
+	return form.dao.WithoutHooks().RunInTransaction(func(txDao *daos.Dao) error {
+		tx, ok := txDao.DB().(*dbx.Tx)
+		if !ok {
+			return errors.New("failed to get transaction db")
+		}
+		defer tx.Rollback()
+
+		return dryAction(txDao)
 	})
 }
 
@@ -790,8 +961,46 @@ func (form *RecordUpsert) Submit(interceptors ...InterceptorFunc[*models.Record]
 		// ---
 
 		// persist the record model
-		if err := dao.SaveRecord(form.record); err != nil {
-			return form.prepareError(err)
+		var saveErr error
+		checkETag := form.ifMatch != "" && !form.record.IsNew()
+		hasValidationRules := len(form.record.Collection().ValidationRules()) > 0
+		if checkETag || hasValidationRules {
+			isNew := form.record.IsNew()
+
+			// check the record version, save it and check the collection
+			// validation rules against the saved record state (aka. after the
+			// model hooks and the db generated values) in a single transaction
+			saveErr = dao.RunInTransaction(func(txDao *daos.Dao) error {
+				// use a savepoint to revert the record changes
+				// in case the form dao is already in a transaction
+				return txDao.RunInSavepoint(func(spDao *daos.Dao) error {
+					if checkETag {
+						if err := spDao.CheckRecordETag(form.record, form.ifMatch); err != nil {
+							return err
+						}
+					}
+
+					if err := spDao.SaveRecord(form.record); err != nil {
+						return err
+					}
+
+					return form.checkValidationRules(spDao, form.record)
+				})
+			})
+
+			var validationErrs validation.Errors
+			if errors.As(saveErr, &validationErrs) {
+				// restore the record isNew state and cleanup the uploaded files
+				if isNew {
+					form.record.MarkAsNew()
+				}
+				form.deleteFilesByNamesList(form.filesToUploadNames())
+			}
+		} else {
+			saveErr = dao.SaveRecord(form.record)
+		}
+		if saveErr != nil {
+			return form.prepareError(saveErr)
 		}
 
 		// delete old files (if any)
@@ -850,6 +1059,19 @@ func (form *RecordUpsert) processFilesToUpload() error {
 	return nil
 }
 
+// filesToUploadNames returns the names of all form files to upload.
+func (form *RecordUpsert) filesToUploadNames() []string {
+	names := []string{}
+
+	for _, files := range form.filesToUpload {
+		for _, file := range files {
+			names = append(names, file.Name)
+		}
+	}
+
+	return names
+}
+
 func (form *RecordUpsert) processFilesToDelete() (err error) {
 	form.filesToDelete, err = form.deleteFilesByNamesList(form.filesToDelete)
 	return
@@ -923,3 +1145,67 @@ func (form *RecordUpsert) prepareError(err error) error {
 
 	return err
 }
+
+// editorPlainTextFields returns the names of the collection
+// text fields used as editor fields plain text companion.
+func editorPlainTextFields(collection *models.Collection) map[string]struct{} {
+	result := map[string]struct{}{}
+
+	for _, field := range collection.Schema.Fields() {
+		if field.Type != schema.FieldTypeEditor {
+			continue
+		}
+
+		field.InitOptions()
+		if options, _ := field.Options.(*schema.EditorOptions); options != nil && options.PlainTextField != "" {
+			result[options.PlainTextField] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// sanitizeEditorValue applies the editor field sanitization policy (if any) to value.
+func sanitizeEditorValue(field *schema.SchemaField, value any) (any, error) {
+	options, _ := field.Options.(*schema.EditorOptions)
+	if options == nil {
+		return value, nil
+	}
+
+	policy := options.SanitizePolicy()
+	if policy == nil {
+		return value, nil
+	}
+
+	return policy.Sanitize(cast.ToString(value))
+}
+
+// checkValidationRules evaluates the record collection validation rules
+// against its persisted state in the provided (usually dry) transaction dao.
+//
+// Returns [validation.Errors] keyed by the rules target field
+// (only the first failed rule message per field is reported).
+func (form *RecordUpsert) checkValidationRules(txDao *daos.Dao, record *models.Record) error {
+	errs := validation.Errors{}
+
+	for _, rule := range record.Collection().ValidationRules() {
+		if errs[rule.Field] != nil {
+			continue // already failed
+		}
+
+		ok, err := txDao.RecordMatchesFilter(record, form.requestInfo, rule.Expression)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate the %q validation rule: %w", rule.Expression, err)
+		}
+
+		if !ok {
+			errs[rule.Field] = validation.NewError("validation_rule_failed", rule.Message)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/forms"
@@ -231,6 +232,60 @@ func TestRecordUpsertLoadData(t *testing.T) {
 	}
 }
 
+func TestRecordUpsertSetRequestInfo(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.GetFieldByName("title").Options.(*schema.TextOptions).Default = schema.DefaultMacroRequestAuthId
+
+	authRecord, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	requestInfo := &models.RequestInfo{AuthRecord: authRecord}
+
+	// new record
+	form1 := forms.NewRecordUpsert(app, models.NewRecord(collection))
+	if v := form1.Data()["title"]; v != "" {
+		t.Fatalf("Expected empty title before SetRequestInfo, got %v", v)
+	}
+	form1.SetRequestInfo(requestInfo)
+	if v := form1.Data()["title"]; v != authRecord.Id {
+		t.Fatalf("Expected title %q, got %v", authRecord.Id, v)
+	}
+
+	// new record with already loaded value
+	form2 := forms.NewRecordUpsert(app, models.NewRecord(collection))
+	form2.LoadData(map[string]any{"title": "test_new"})
+	form2.SetRequestInfo(requestInfo)
+	if v := form2.Data()["title"]; v != "test_new" {
+		t.Fatalf("Expected title %q, got %v", "test_new", v)
+	}
+
+	// guest request
+	form3 := forms.NewRecordUpsert(app, models.NewRecord(collection))
+	form3.SetRequestInfo(&models.RequestInfo{})
+	if v := form3.Data()["title"]; v != "" {
+		t.Fatalf("Expected empty title, got %v", v)
+	}
+
+	// existing record
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("title", "")
+	form4 := forms.NewRecordUpsert(app, record)
+	form4.SetRequestInfo(requestInfo)
+	if v := form4.Data()["title"]; v != "" {
+		t.Fatalf("Expected the existing record title to remain empty, got %v", v)
+	}
+}
+
 func TestRecordUpsertDrySubmitFailure(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -604,6 +659,53 @@ func TestRecordUpsertSubmitInterceptors(t *testing.T) {
 	}
 }
 
+func TestRecordUpsertIfMatch(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	staleETag := record.ETag()
+
+	// concurrent modification
+	other := record.CleanCopy()
+	other.Set("title", "test1_other")
+	if err := app.Dao().SaveRecord(other); err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordUpsert(app, record)
+	form.Data()["title"] = "test1_stale"
+	form.SetIfMatch(staleETag)
+
+	if err := form.Submit(); !errors.Is(err, daos.ErrRecordETagMismatch) {
+		t.Fatalf("Expected ErrRecordETagMismatch, got %v", err)
+	}
+
+	latest, _ := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if v := latest.GetString("title"); v != "test1_other" {
+		t.Fatalf("Expected the record to not be updated, got title %q", v)
+	}
+
+	// retry with the latest etag
+	form = forms.NewRecordUpsert(app, latest)
+	form.Data()["title"] = "test1_new"
+	form.SetIfMatch(latest.ETag())
+
+	if err := form.Submit(); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	latest, _ = app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if v := latest.GetString("title"); v != "test1_new" {
+		t.Fatalf("Expected the record title to be updated, got %q", v)
+	}
+}
+
 func TestRecordUpsertWithCustomId(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -1210,3 +1312,441 @@ func TestRecordUpsertUploadFailure(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordUpsertSequenceFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "number",
+		Type:    schema.FieldTypeSequence,
+		Options: &schema.SequenceOptions{Pattern: "N-{seq:03}"},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// create
+	record := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title":  "new",
+		"number": "custom",
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("number"); v != "N-001" {
+		t.Fatalf("Expected number %q, got %q", "N-001", v)
+	}
+
+	// update
+	form = forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"number": "custom",
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("number"); v != "N-001" {
+		t.Fatalf("Expected number %q, got %q", "N-001", v)
+	}
+}
+
+func TestRecordUpsertAutodateFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "published",
+		Type:    schema.FieldTypeAutodate,
+		Options: &schema.AutodateOptions{OnCreate: true},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// create
+	record := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title":     "new",
+		"published": "2000-01-01 00:00:00.000Z",
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	published := record.GetDateTime("published")
+	if published.IsZero() || published.Time().Year() == 2000 {
+		t.Fatalf("Expected published to be set to the current date, got %q", published)
+	}
+
+	// update
+	form = forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"published": "2000-01-01 00:00:00.000Z",
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetDateTime("published"); v.String() != published.String() {
+		t.Fatalf("Expected published to remain %q, got %q", published, v)
+	}
+}
+
+func TestRecordUpsertDecimalFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "price",
+		Type:    schema.FieldTypeDecimal,
+		Options: &schema.DecimalOptions{Precision: 10, Scale: 2},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// create
+	record := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title": "new",
+		"price": "0.1",
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// update with modifier
+	form = forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"price+": "0.2",
+	})
+
+	// simulate concurrent change of the same field (1.00 as scaled integer)
+	_, err = app.Dao().DB().Update(
+		collection.Name,
+		dbx.Params{"price": 100},
+		dbx.HashExp{"id": record.Id},
+	).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := stored.GetDecimal("price").String(); v != "1.20" {
+		t.Fatalf("Expected the stored price to be %q, got %q", "1.20", v)
+	}
+	if v := record.GetDecimal("price").String(); v != "1.20" {
+		t.Fatalf("Expected the refreshed record price to be %q, got %q", "1.20", v)
+	}
+
+	// explicit value (overwrites the concurrent changes)
+	form = forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"price": "5",
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err = app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := stored.GetDecimal("price").String(); v != "5.00" {
+		t.Fatalf("Expected the stored price to be %q, got %q", "5.00", v)
+	}
+
+	// scale violation
+	form = forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"price-": "0.001",
+	})
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected scale constraint error")
+	}
+}
+
+func TestRecordUpsertEditorFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	max := 10
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "content",
+		Type: schema.FieldTypeEditor,
+		Options: &schema.EditorOptions{
+			Sanitize:       schema.EditorSanitizeBasic,
+			PlainTextField: "excerpt",
+		},
+	})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "excerpt",
+		Type:    schema.FieldTypeText,
+		Options: &schema.TextOptions{Max: &max},
+	})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:     "raw",
+		Type:     schema.FieldTypeEditor,
+		Required: true,
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title":   "new",
+		"content": `<p onclick="alert(1)">Hello <b>world</b><script>alert(2)</script> and the rest</p>`,
+		"excerpt": "custom",
+		"raw":     `<p onclick="alert(1)">raw</p>`,
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectations := map[string]string{
+		"content": "<p>Hello <b>world</b> and the rest</p>",
+		"excerpt": "Hello worl",
+		"raw":     `<p onclick="alert(1)">raw</p>`, // no sanitization
+	}
+	for field, expected := range expectations {
+		if v := stored.GetString(field); v != expected {
+			t.Errorf("Expected %s %q, got %q", field, expected, v)
+		}
+	}
+
+	// the sanitized required value is checked after the sanitization
+	collection.Schema.GetFieldByName("raw").Options = &schema.EditorOptions{Sanitize: schema.EditorSanitizeBasic}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	stored, err = app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form = forms.NewRecordUpsert(app, stored)
+	form.LoadData(map[string]any{
+		"raw": `<script>alert(1)</script>`,
+	})
+	err = form.Submit()
+	errs, _ := err.(validation.Errors)
+	if _, ok := errs["raw"]; !ok {
+		t.Fatalf("Expected raw required error, got %v", err)
+	}
+}
+
+func TestRecordUpsertValidationRules(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	authRecord, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{Name: "start", Type: schema.FieldTypeNumber})
+	collection.Schema.AddField(&schema.SchemaField{Name: "end", Type: schema.FieldTypeNumber})
+	collection.Schema.AddField(&schema.SchemaField{Name: "email", Type: schema.FieldTypeEmail})
+	collection.Schema.AddField(&schema.SchemaField{Name: "phone", Type: schema.FieldTypeText})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "number",
+		Type:    schema.FieldTypeSequence,
+		Options: &schema.SequenceOptions{Pattern: "N-{seq:03}"},
+	})
+	collection.Options["validationRules"] = []models.CollectionValidationRule{
+		{Expression: "end > start", Field: "end", Message: "The end must be after the start."},
+		{Expression: "email != '' || phone != ''", Field: "email", Message: "Either email or phone is required."},
+		{Expression: "phone = '' || phone ~ '+%'", Field: "phone", Message: "Invalid phone."},
+		{Expression: "active = false || @request.auth.id != ''", Field: "active", Message: "Only auth records can activate."},
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	checkErrors := func(err error, expected map[string]string) {
+		t.Helper()
+
+		errs, ok := err.(validation.Errors)
+		if !ok || len(errs) != len(expected) {
+			t.Fatalf("Expected errors %v, got %v", expected, err)
+		}
+
+		for field, message := range expected {
+			fieldErr, _ := errs[field].(validation.Error)
+			if fieldErr == nil || fieldErr.Code() != "validation_rule_failed" || fieldErr.Message() != message {
+				t.Fatalf("Expected %q rule error %q, got %v", field, message, errs[field])
+			}
+		}
+	}
+
+	// create with failed rules
+	record := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title": "new",
+		"start": 10,
+		"end":   5,
+	})
+	checkErrors(form.Submit(), map[string]string{
+		"end":   "The end must be after the start.",
+		"email": "Either email or phone is required.",
+	})
+	if _, err := app.Dao().FindRecordById(collection.Id, record.Id); err == nil {
+		t.Fatal("Expected the record to not be created")
+	}
+
+	// dry submit with failed rules
+	checkErrors(form.DrySubmit(nil), map[string]string{
+		"end":   "The end must be after the start.",
+		"email": "Either email or phone is required.",
+	})
+
+	// create with failed @request rule
+	record = models.NewRecord(collection)
+	form = forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title":  "new",
+		"start":  1,
+		"end":    5,
+		"phone":  "123",
+		"active": true,
+	})
+	checkErrors(form.Submit(), map[string]string{
+		"phone":  "Invalid phone.",
+		"active": "Only auth records can activate.",
+	})
+
+	// create with satisfied rules
+	form.LoadData(map[string]any{"phone": "+123"})
+	form.SetRequestInfo(&models.RequestInfo{AuthRecord: authRecord})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("number"); v != "N-001" {
+		t.Fatalf("Expected number %q, got %q", "N-001", v)
+	}
+
+	// the dry saved sequence values shouldn't be reused
+	record2 := models.NewRecord(collection)
+	form = forms.NewRecordUpsert(app, record2)
+	form.LoadData(map[string]any{
+		"title": "new2",
+		"start": 1,
+		"end":   5,
+		"email": "test@example.com",
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if v := record2.GetString("number"); v != "N-002" {
+		t.Fatalf("Expected number %q, got %q", "N-002", v)
+	}
+
+	// update with failed rule
+	form = forms.NewRecordUpsert(app, record)
+	form.SetRequestInfo(&models.RequestInfo{AuthRecord: authRecord})
+	form.LoadData(map[string]any{
+		"start": 20,
+	})
+	checkErrors(form.Submit(), map[string]string{
+		"end": "The end must be after the start.",
+	})
+
+	stored, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := stored.GetInt("start"); v != 1 {
+		t.Fatalf("Expected the stored start to be %d, got %d", 1, v)
+	}
+}
+
+func TestRecordUpsertTrashedUniqueValues(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Options["softDelete"] = true
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	trashed, err := app.Dao().FindAuthRecordByEmail(collection.Id, "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DeleteRecord(trashed); err != nil {
+		t.Fatal(err)
+	}
+
+	record := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(app, record)
+	form.Email = "test@example.com"
+	form.Username = trashed.Username()
+	form.Password = "1234567890"
+	form.PasswordConfirm = "1234567890"
+
+	err = form.Submit()
+
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+	for _, key := range []string{"email", "username"} {
+		if _, ok := errs[key]; !ok {
+			t.Fatalf("Expected %q validation error, got %v", key, errs)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+)
+
+// MaxRecordsBatchRequests specifies the max allowed number of
+// operations that could be submitted with a single [RecordsBatch] form.
+const MaxRecordsBatchRequests int = 100
+
+// available batch request actions
+const (
+	RecordsBatchActionCreate string = "create"
+	RecordsBatchActionUpdate string = "update"
+	RecordsBatchActionUpsert string = "upsert"
+	RecordsBatchActionDelete string = "delete"
+)
+
+// RecordsBatchRequest defines a single [RecordsBatch] operation.
+type RecordsBatchRequest struct {
+	Action     string         `form:"action" json:"action"`
+	Collection string         `form:"collection" json:"collection"`
+	Id         string         `form:"id" json:"id"`
+	Data       map[string]any `form:"data" json:"data"`
+}
+
+// Validate makes RecordsBatchRequest validatable by implementing [validation.Validatable] interface.
+func (r RecordsBatchRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(
+			&r.Action,
+			validation.Required,
+			validation.In(
+				RecordsBatchActionCreate,
+				RecordsBatchActionUpdate,
+				RecordsBatchActionUpsert,
+				RecordsBatchActionDelete,
+			),
+		),
+		validation.Field(&r.Collection, validation.Required),
+		validation.Field(
+			&r.Id,
+			validation.When(
+				r.Action == RecordsBatchActionUpdate || r.Action == RecordsBatchActionDelete,
+				validation.Required,
+			),
+		),
+	)
+}
+
+// RecordsBatch is a form model for executing multiple
+// record create/update/upsert/delete operations in a single transaction.
+type RecordsBatch struct {
+	app core.App
+	dao *daos.Dao
+
+	Requests []*RecordsBatchRequest `form:"requests" json:"requests"`
+}
+
+// NewRecordsBatch creates a new [RecordsBatch] form
+// initialized with the provided [core.App] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordsBatch(app core.App) *RecordsBatch {
+	return &RecordsBatch{
+		app: app,
+		dao: app.Dao(),
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordsBatch) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordsBatch) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Requests,
+			validation.Required,
+			validation.Length(1, MaxRecordsBatchRequests),
+		),
+	)
+}
+
+// Submit validates the form and executes the provided handler for
+// each of the form requests within a single transaction.
+//
+// The transaction is rollbacked on the first handler error.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before executing the requests.
+func (form *RecordsBatch) Submit(
+	handler func(txDao *daos.Dao, index int, request *RecordsBatchRequest) error,
+	interceptors ...InterceptorFunc[[]*RecordsBatchRequest],
+) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	return runInterceptors(form.Requests, func(requests []*RecordsBatchRequest) error {
+		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			for i, r := range requests {
+				if err := handler(txDao, i, r); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}, interceptors...)
+}
@@ -0,0 +1,154 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsBatchValidate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tooManyRequests := make([]map[string]any, forms.MaxRecordsBatchRequests+1)
+	for i := range tooManyRequests {
+		tooManyRequests[i] = map[string]any{"action": "create", "collection": "demo2"}
+	}
+	tooManyRequestsRaw, _ := json.Marshal(map[string]any{"requests": tooManyRequests})
+
+	scenarios := []struct {
+		name           string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"empty data",
+			`{}`,
+			[]string{"requests"},
+		},
+		{
+			"empty requests",
+			`{"requests":[]}`,
+			[]string{"requests"},
+		},
+		{
+			"too many requests",
+			string(tooManyRequestsRaw),
+			[]string{"requests"},
+		},
+		{
+			"invalid request items",
+			`{"requests":[
+				{"action":"create","collection":"demo2"},
+				{"action":"invalid","collection":"demo2"},
+				{"action":"update","collection":"demo2"},
+				{"action":"delete","collection":""}
+			]}`,
+			[]string{"requests"},
+		},
+		{
+			"valid request items",
+			`{"requests":[
+				{"action":"create","collection":"demo2"},
+				{"action":"update","collection":"demo2","id":"test"},
+				{"action":"upsert","collection":"demo2"},
+				{"action":"delete","collection":"demo2","id":"test"}
+			]}`,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewRecordsBatch(app)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			result := form.Validate()
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Fatalf("Failed to parse errors %v", result)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordsBatchSubmit(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	form := forms.NewRecordsBatch(app)
+	form.Requests = []*forms.RecordsBatchRequest{
+		{Action: forms.RecordsBatchActionDelete, Collection: "demo2", Id: "llvuca81nly1qls"},
+		{Action: forms.RecordsBatchActionDelete, Collection: "demo2", Id: "achvryl401bhse3"},
+	}
+
+	calls := ""
+	interceptorCalls := 0
+
+	err := form.Submit(func(txDao *daos.Dao, i int, r *forms.RecordsBatchRequest) error {
+		calls += r.Id
+
+		record, err := txDao.FindRecordById(r.Collection, r.Id)
+		if err != nil {
+			return err
+		}
+
+		if err := txDao.DeleteRecord(record); err != nil {
+			return err
+		}
+
+		if i == 1 {
+			return errors.New("test_error")
+		}
+
+		return nil
+	}, func(next forms.InterceptorNextFunc[[]*forms.RecordsBatchRequest]) forms.InterceptorNextFunc[[]*forms.RecordsBatchRequest] {
+		return func(requests []*forms.RecordsBatchRequest) error {
+			interceptorCalls++
+			return next(requests)
+		}
+	})
+
+	if err == nil || err.Error() != "test_error" {
+		t.Fatalf("Expected test_error, got %v", err)
+	}
+
+	if interceptorCalls != 1 {
+		t.Fatalf("Expected the interceptor to be called once, got %d", interceptorCalls)
+	}
+
+	if calls != "llvuca81nly1qlsachvryl401bhse3" {
+		t.Fatalf("Expected both handlers to be called, got %q", calls)
+	}
+
+	// the transaction should have been rollbacked
+	for _, r := range form.Requests {
+		record, _ := app.Dao().FindRecordById(r.Collection, r.Id)
+		if record == nil {
+			t.Fatalf("Expected record %q to not be deleted", r.Id)
+		}
+	}
+}
@@ -0,0 +1,555 @@
+package forms
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// available records export formats
+const (
+	RecordsExportFormatJSON   string = "json"
+	RecordsExportFormatNDJSON string = "ndjson"
+	RecordsExportFormatCSV    string = "csv"
+)
+
+// available records export relation field render modes
+const (
+	RecordsExportRelationsId     string = "id"
+	RecordsExportRelationsExpand string = "expand"
+)
+
+// available records export file field render modes
+const (
+	RecordsExportFilesName string = "name"
+	RecordsExportFilesUrl  string = "url"
+)
+
+// recordsExportChunkSize specifies the number of records
+// that are loaded and written together.
+const recordsExportChunkSize = 200
+
+// RecordsExport is a form model for streaming the records
+// of a single collection in JSON, NDJSON or CSV format.
+type RecordsExport struct {
+	app         core.App
+	dao         *daos.Dao
+	collection  *models.Collection
+	requestInfo *models.RequestInfo
+	fetchFunc   daos.ExpandFetchFunc
+
+	Format    string `form:"format" json:"format"`
+	Filter    string `form:"filter" json:"filter"`
+	Sort      string `form:"sort" json:"sort"`
+	Fields    string `form:"fields" json:"fields"`
+	Relations string `form:"relations" json:"relations"`
+	Files     string `form:"files" json:"files"`
+}
+
+// NewRecordsExport creates a new [RecordsExport] form
+// initialized with the provided [core.App] and [models.Collection] instances.
+//
+// By default the form has full access to the collection records.
+// Use [SetRequestInfo()] to apply the collection API rules of a specific request.
+//
+// NB! The records are streamed with an open db cursor and therefore
+// the form shouldn't be submitted with a transaction Dao.
+func NewRecordsExport(app core.App, collection *models.Collection) *RecordsExport {
+	return &RecordsExport{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+		Format:     RecordsExportFormatJSON,
+		Relations:  RecordsExportRelationsId,
+		Files:      RecordsExportFilesName,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordsExport) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// SetRequestInfo sets the request info used to resolve the filter and
+// sort `@request.*` fields and to apply the collection list API rule
+// (if the request is not from an admin).
+func (form *RecordsExport) SetRequestInfo(requestInfo *models.RequestInfo) {
+	form.requestInfo = requestInfo
+}
+
+// SetExpandFetchFunc sets the function used to fetch the related
+// records when Relations is set to [RecordsExportRelationsExpand].
+//
+// If not set, the relations are expanded only when the form has full access.
+func (form *RecordsExport) SetExpandFetchFunc(fetchFunc daos.ExpandFetchFunc) {
+	form.fetchFunc = fetchFunc
+}
+
+// ContentType returns the HTTP content type of the form export format.
+func (form *RecordsExport) ContentType() string {
+	switch form.Format {
+	case RecordsExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case RecordsExportFormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/json"
+	}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordsExport) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Format,
+			validation.Required,
+			validation.In(RecordsExportFormatJSON, RecordsExportFormatNDJSON, RecordsExportFormatCSV),
+		),
+		validation.Field(
+			&form.Relations,
+			validation.In(RecordsExportRelationsId, RecordsExportRelationsExpand),
+		),
+		validation.Field(
+			&form.Files,
+			validation.In(RecordsExportFilesName, RecordsExportFilesUrl),
+		),
+		validation.Field(&form.Fields, validation.By(form.checkFields)),
+	)
+}
+
+func (form *RecordsExport) checkFields(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil
+	}
+
+	available := form.availableFields()
+
+	for _, name := range strings.Split(v, ",") {
+		name = strings.TrimSpace(name)
+		if !list.ExistInSlice(name, available) {
+			return validation.NewError(
+				"validation_unknown_export_field",
+				fmt.Sprintf("Unknown export field %q.", name),
+			)
+		}
+	}
+
+	return nil
+}
+
+// availableFields returns the names of all exportable collection fields.
+func (form *RecordsExport) availableFields() []string {
+	result := []string{schema.FieldNameId}
+
+	if !form.collection.IsView() {
+		result = append(result, schema.FieldNameCreated, schema.FieldNameUpdated)
+	}
+
+	if form.collection.IsAuth() {
+		result = append(
+			result,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+		)
+	}
+
+	for _, field := range form.collection.Schema.Fields() {
+		result = append(result, field.Name)
+	}
+
+	return result
+}
+
+// exportFields returns the names of the fields to export.
+func (form *RecordsExport) exportFields() []string {
+	if form.Fields == "" {
+		return form.availableFields()
+	}
+
+	result := []string{}
+	for _, name := range strings.Split(form.Fields, ",") {
+		result = append(result, strings.TrimSpace(name))
+	}
+
+	return list.ToUniqueStringSlice(result)
+}
+
+// hasFullAccess checks whether the form should ignore the collection API rules.
+func (form *RecordsExport) hasFullAccess() bool {
+	return form.requestInfo == nil || form.requestInfo.Admin != nil
+}
+
+// Submit validates the form and streams the matching collection
+// records into the provided writer.
+//
+// If the writer implements [http.Flusher], it is flushed after each written chunk.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before writing the records.
+func (form *RecordsExport) Submit(w io.Writer, interceptors ...InterceptorFunc[io.Writer]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	if !form.hasFullAccess() && form.collection.ListRule == nil {
+		return errors.New("only admins can export the collection records")
+	}
+
+	query, err := form.buildQuery()
+	if err != nil {
+		return err
+	}
+
+	return runInterceptors(w, func(w io.Writer) error {
+		rows, err := query.Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		writer := newRecordsExportWriter(w, form.Format, form.exportFields())
+
+		if err := writer.start(); err != nil {
+			return err
+		}
+
+		chunk := make([]*models.Record, 0, recordsExportChunkSize)
+
+		for rows.Next() {
+			row := dbx.NullStringMap{}
+			if err := rows.ScanMap(row); err != nil {
+				return err
+			}
+
+			chunk = append(chunk, models.NewRecordFromNullStringMap(form.collection, row))
+
+			if len(chunk) >= recordsExportChunkSize {
+				if err := form.writeChunk(writer, chunk); err != nil {
+					return err
+				}
+				chunk = chunk[:0]
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if err := form.writeChunk(writer, chunk); err != nil {
+			return err
+		}
+
+		return writer.end()
+	}, interceptors...)
+}
+
+// buildQuery builds the records select query applying
+// the form filter, sort and the collection list API rule.
+func (form *RecordsExport) buildQuery() (*dbx.SelectQuery, error) {
+	requestInfo := form.requestInfo
+	if requestInfo == nil {
+		requestInfo = &models.RequestInfo{}
+	}
+
+	resolver := resolvers.NewRecordFieldResolver(
+		form.dao,
+		form.collection,
+		requestInfo,
+		// hidden fields are searchable only with full access
+		form.hasFullAccess(),
+	)
+
+	query := form.dao.RecordQuery(form.collection)
+
+	filters := []search.FilterData{}
+	if !form.hasFullAccess() && form.collection.ListRule != nil && *form.collection.ListRule != "" {
+		filters = append(filters, search.FilterData(*form.collection.ListRule))
+	}
+	if form.Filter != "" {
+		filters = append(filters, search.FilterData(form.Filter))
+	}
+
+	for _, f := range filters {
+		expr, err := f.BuildExpr(resolver)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			query.AndWhere(expr)
+		}
+	}
+
+	if form.Sort != "" {
+		for _, sortField := range search.ParseSortFromString(form.Sort) {
+			expr, err := sortField.BuildExpr(resolver)
+			if err != nil {
+				return nil, err
+			}
+			query.AndOrderBy(expr)
+		}
+	}
+
+	if err := resolver.UpdateQuery(query); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+// writeChunk renders and writes the provided records chunk.
+func (form *RecordsExport) writeChunk(writer *recordsExportWriter, records []*models.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	fields := form.exportFields()
+
+	// the chunk records are loaded from raw db rows
+	// and therefore their encrypted fields must be decrypted manually
+	if err := form.dao.DecryptRecords(records...); err != nil {
+		return err
+	}
+
+	// the restricted fields are exported as empty
+	if !form.hasFullAccess() {
+		if err := form.dao.HideRestrictedRecordsFields(records, form.requestInfo); err != nil {
+			form.app.Logger().Debug("Failed to resolve the export fields view rules", slog.String("error", err.Error()))
+		}
+	}
+
+	if form.Relations == RecordsExportRelationsExpand {
+		expands := []string{}
+		for _, name := range fields {
+			if field := form.collection.Schema.GetFieldByName(name); field != nil && field.Type == schema.FieldTypeRelation {
+				expands = append(expands, name)
+			}
+		}
+
+		// the related records are not expanded without an explicit
+		// fetch func to prevent bypassing their collections view API rule
+		if len(expands) > 0 && (form.fetchFunc != nil || form.hasFullAccess()) {
+			// the failed expands are ignored and rendered as empty
+			form.dao.ExpandRecords(records, expands, form.fetchFunc)
+		}
+	}
+
+	for _, record := range records {
+		if form.hasFullAccess() {
+			record.IgnoreEmailVisibility(true)
+		}
+
+		if err := writer.write(form.renderRecord(record, fields)); err != nil {
+			return err
+		}
+	}
+
+	return writer.flush()
+}
+
+// renderRecord returns the export values of the specified record fields.
+func (form *RecordsExport) renderRecord(record *models.Record, fields []string) []any {
+	exported := record.PublicExport()
+
+	result := make([]any, len(fields))
+
+	for i, name := range fields {
+		value := exported[name]
+
+		field := form.collection.Schema.GetFieldByName(name)
+		if field != nil {
+			switch field.Type {
+			case schema.FieldTypeRelation:
+				if form.Relations == RecordsExportRelationsExpand {
+					value = renderExpandedRelation(record.Expand()[name], field)
+				}
+			case schema.FieldTypeFile:
+				if form.Files == RecordsExportFilesUrl {
+					value = form.renderFileUrls(record, value)
+				}
+			}
+		}
+
+		result[i] = value
+	}
+
+	return result
+}
+
+// renderFileUrls replaces the provided file name(s) with their absolute urls.
+func (form *RecordsExport) renderFileUrls(record *models.Record, value any) any {
+	baseUrl := strings.TrimRight(form.app.Settings().Meta.AppUrl, "/") +
+		"/api/files/" + form.collection.Id + "/" + record.Id + "/"
+
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return v
+		}
+		return baseUrl + v
+	case []string:
+		urls := make([]string, len(v))
+		for i, name := range v {
+			urls[i] = baseUrl + name
+		}
+		return urls
+	}
+
+	return value
+}
+
+// renderExpandedRelation returns the public export of the expanded relation records.
+func renderExpandedRelation(expanded any, field *schema.SchemaField) any {
+	options, _ := field.Options.(*schema.RelationOptions)
+	isMultiple := options == nil || options.IsMultiple()
+
+	records := []map[string]any{}
+	switch v := expanded.(type) {
+	case *models.Record:
+		records = append(records, v.PublicExport())
+	case []*models.Record:
+		for _, r := range v {
+			records = append(records, r.PublicExport())
+		}
+	}
+
+	if isMultiple {
+		return records
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	return records[0]
+}
+
+// -------------------------------------------------------------------
+
+// recordsExportWriter writes the rendered records in the specified format.
+type recordsExportWriter struct {
+	w      io.Writer
+	csv    *csv.Writer
+	format string
+	fields []string
+	count  int
+}
+
+func newRecordsExportWriter(w io.Writer, format string, fields []string) *recordsExportWriter {
+	writer := &recordsExportWriter{w: w, format: format, fields: fields}
+
+	if format == RecordsExportFormatCSV {
+		writer.csv = csv.NewWriter(w)
+	}
+
+	return writer
+}
+
+func (ew *recordsExportWriter) start() error {
+	switch ew.format {
+	case RecordsExportFormatCSV:
+		return ew.csv.Write(ew.fields)
+	case RecordsExportFormatJSON:
+		_, err := io.WriteString(ew.w, "[")
+		return err
+	}
+
+	return nil
+}
+
+func (ew *recordsExportWriter) write(values []any) error {
+	defer func() { ew.count++ }()
+
+	if ew.format == RecordsExportFormatCSV {
+		line := make([]string, len(values))
+		for i, v := range values {
+			line[i] = csvValue(v)
+		}
+		return ew.csv.Write(line)
+	}
+
+	item := make(map[string]any, len(values))
+	for i, v := range values {
+		item[ew.fields[i]] = v
+	}
+
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	if ew.format == RecordsExportFormatNDJSON {
+		raw = append(raw, '\n')
+	} else if ew.count > 0 {
+		raw = append([]byte{','}, raw...)
+	}
+
+	_, err = ew.w.Write(raw)
+
+	return err
+}
+
+func (ew *recordsExportWriter) flush() error {
+	if ew.csv != nil {
+		ew.csv.Flush()
+		if err := ew.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	if f, ok := ew.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+func (ew *recordsExportWriter) end() error {
+	if ew.format == RecordsExportFormatJSON {
+		if _, err := io.WriteString(ew.w, "]"); err != nil {
+			return err
+		}
+	}
+
+	return ew.flush()
+}
+
+// csvValue converts the provided export value into a single CSV cell.
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case types.DateTime:
+		return v.String()
+	case bool, int, int64, float64:
+		return cast.ToString(v)
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return cast.ToString(value)
+	}
+
+	return string(raw)
+}
@@ -0,0 +1,277 @@
+package forms_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsExportValidate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		init           func(form *forms.RecordsExport)
+		expectedErrors []string
+	}{
+		{
+			"defaults",
+			func(form *forms.RecordsExport) {},
+			[]string{},
+		},
+		{
+			"invalid options",
+			func(form *forms.RecordsExport) {
+				form.Format = "xml"
+				form.Relations = "invalid"
+				form.Files = "invalid"
+				form.Fields = "id,missing"
+			},
+			[]string{"format", "relations", "files", "fields"},
+		},
+		{
+			"valid options",
+			func(form *forms.RecordsExport) {
+				form.Format = forms.RecordsExportFormatCSV
+				form.Relations = forms.RecordsExportRelationsExpand
+				form.Files = forms.RecordsExportFilesUrl
+				form.Fields = "id, created,title"
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		form := forms.NewRecordsExport(app, collection)
+		s.init(form)
+
+		result := form.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		if len(errs) != len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRecordsExportSubmit(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name        string
+		collection  string
+		requestInfo *models.RequestInfo
+		init        func(form *forms.RecordsExport)
+		expectError bool
+		expected    []string
+		notExpected []string
+	}{
+		{
+			"csv",
+			"demo2",
+			nil,
+			func(form *forms.RecordsExport) {
+				form.Format = forms.RecordsExportFormatCSV
+				form.Fields = "id,title,active"
+				form.Sort = "-title"
+			},
+			false,
+			[]string{"id,title,active\n0yxhwia2amd8gec,test3,true\nachvryl401bhse3,test2,true\nllvuca81nly1qls,test1,false\n"},
+			nil,
+		},
+		{
+			"ndjson with filter",
+			"demo2",
+			nil,
+			func(form *forms.RecordsExport) {
+				form.Format = forms.RecordsExportFormatNDJSON
+				form.Fields = "id,title"
+				form.Filter = "active=true"
+				form.Sort = "title"
+			},
+			false,
+			[]string{`{"id":"achvryl401bhse3","title":"test2"}` + "\n" + `{"id":"0yxhwia2amd8gec","title":"test3"}` + "\n"},
+			nil,
+		},
+		{
+			"json with no matching records",
+			"demo2",
+			nil,
+			func(form *forms.RecordsExport) {
+				form.Filter = "title='missing'"
+			},
+			false,
+			[]string{"[]"},
+			nil,
+		},
+		{
+			"invalid filter",
+			"demo2",
+			nil,
+			func(form *forms.RecordsExport) {
+				form.Filter = "missing>1"
+			},
+			true,
+			nil,
+			nil,
+		},
+		{
+			"nil list rule with guest request info",
+			"demo1",
+			&models.RequestInfo{},
+			func(form *forms.RecordsExport) {},
+			true,
+			nil,
+			nil,
+		},
+		{
+			"json with expanded relations",
+			"demo4",
+			nil,
+			func(form *forms.RecordsExport) {
+				form.Fields = "id,rel_one_cascade,rel_many_cascade"
+				form.Filter = "id='qzaqccwrmva4o1n'"
+				form.Relations = forms.RecordsExportRelationsExpand
+			},
+			false,
+			[]string{
+				`[{"id":"qzaqccwrmva4o1n",`,
+				`"rel_one_cascade":{"collectionId":"wzlqyes4orhoygb","collectionName":"demo3"`,
+				`"rel_many_cascade":[{"collectionId":"wzlqyes4orhoygb","collectionName":"demo3"`,
+				`"id":"7nwo8tuiatetxdm"`,
+				`"id":"mk5fmymtx4wsprk"`,
+			},
+			nil,
+		},
+		{
+			"csv with file urls",
+			"demo1",
+			nil,
+			func(form *forms.RecordsExport) {
+				form.Format = forms.RecordsExportFormatCSV
+				form.Fields = "id,file_one,file_many"
+				form.Filter = "id='84nmscqy84lsi1t'"
+				form.Files = forms.RecordsExportFilesUrl
+			},
+			false,
+			[]string{
+				"84nmscqy84lsi1t,http://localhost:8090/api/files/wsmn24bux7wo113/84nmscqy84lsi1t/test_d61b33QdDU.txt,",
+				`""http://localhost:8090/api/files/wsmn24bux7wo113/84nmscqy84lsi1t/300_WlbFWSGmW9.png""`,
+			},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordsExport(app, collection)
+			form.SetRequestInfo(s.requestInfo)
+			s.init(form)
+
+			var buf bytes.Buffer
+
+			err = form.Submit(&buf)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			output := buf.String()
+
+			for _, str := range s.expected {
+				if !strings.Contains(output, str) {
+					t.Errorf("Cannot find %q in\n%s", str, output)
+				}
+			}
+
+			for _, str := range s.notExpected {
+				if strings.Contains(output, str) {
+					t.Errorf("Didn't expect %q in\n%s", str, output)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordsExportSubmitEncryptedFields(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Dao().SetEncryptionKey("abcdabcdabcdabcdabcdabcdabcdabcd")
+
+	collection := &models.Collection{
+		Name: "encrypted_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "title",
+				Type: schema.FieldTypeText,
+			},
+			&schema.SchemaField{
+				Name: "secret",
+				Type: schema.FieldTypeEncrypted,
+			},
+		),
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record := models.NewRecord(collection)
+	record.Set("title", "test")
+	record.Set("secret", "plain_secret")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordsExport(app, collection)
+	form.Format = forms.RecordsExportFormatCSV
+	form.Fields = "title,secret"
+
+	var buf bytes.Buffer
+
+	if err := form.Submit(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "title,secret\ntest,plain_secret\n"
+	if output := buf.String(); output != expected {
+		t.Fatalf("Expected %q, got %q", expected, output)
+	}
+}
@@ -0,0 +1,451 @@
+package forms
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/spf13/cast"
+)
+
+// available records import formats
+const (
+	RecordsImportFormatCSV    string = "csv"
+	RecordsImportFormatNDJSON string = "ndjson"
+)
+
+// available records import modes
+const (
+	RecordsImportModeInsert string = "insert"
+	RecordsImportModeUpsert string = "upsert"
+)
+
+// DefaultRecordsImportBatchSize specifies the default number
+// of records that are committed in a single transaction.
+const DefaultRecordsImportBatchSize int = 100
+
+// MaxRecordsImportBatchSize specifies the max allowed number
+// of records that could be committed in a single transaction.
+const MaxRecordsImportBatchSize int = 1000
+
+// MaxRecordsImportNDJSONLineSize specifies the max allowed size in bytes of a single NDJSON line.
+const MaxRecordsImportNDJSONLineSize int = 10 << 20
+
+var errRecordsImportDryRun = errors.New("records import dry run rollback")
+
+var errRecordsImportInvalidJSON = errors.New("records import invalid json line")
+
+// RecordsImportRowError defines a single failed import row.
+type RecordsImportRowError struct {
+	// Row is the 1-based index of the failed data row
+	// (the CSV header line is not counted).
+	Row     int    `json:"row"`
+	Id      string `json:"id"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+// RecordsImportResult defines the report of a submitted [RecordsImport] form.
+type RecordsImportResult struct {
+	DryRun  bool                     `json:"dryRun"`
+	Total   int                      `json:"total"`
+	Created int                      `json:"created"`
+	Updated int                      `json:"updated"`
+	Failed  int                      `json:"failed"`
+	Errors  []*RecordsImportRowError `json:"errors"`
+}
+
+// RecordsImport is a form model for bulk importing
+// collection records from CSV or NDJSON data.
+type RecordsImport struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	Format    string `form:"format" json:"format"`
+	Mode      string `form:"mode" json:"mode"`
+	DryRun    bool   `form:"dryRun" json:"dryRun"`
+	BatchSize int    `form:"batchSize" json:"batchSize"`
+
+	// Mapping is an optional map with the source column names
+	// as keys and the target collection field names as values.
+	//
+	// The source columns without mapping are loaded into the field with the same name.
+	Mapping map[string]string `form:"mapping" json:"mapping"`
+}
+
+// NewRecordsImport creates a new [RecordsImport] form
+// initialized with the provided [core.App] and [models.Collection] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordsImport(app core.App, collection *models.Collection) *RecordsImport {
+	return &RecordsImport{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+		Format:     RecordsImportFormatCSV,
+		Mode:       RecordsImportModeInsert,
+		BatchSize:  DefaultRecordsImportBatchSize,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordsImport) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordsImport) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Format,
+			validation.Required,
+			validation.In(RecordsImportFormatCSV, RecordsImportFormatNDJSON),
+		),
+		validation.Field(
+			&form.Mode,
+			validation.Required,
+			validation.In(RecordsImportModeInsert, RecordsImportModeUpsert),
+		),
+		validation.Field(
+			&form.BatchSize,
+			validation.Required,
+			validation.Min(1),
+			validation.Max(MaxRecordsImportBatchSize),
+		),
+		validation.Field(&form.Mapping, validation.By(form.checkMapping)),
+	)
+}
+
+func (form *RecordsImport) checkMapping(value any) error {
+	v, _ := value.(map[string]string)
+
+	for column, fieldName := range v {
+		if fieldName == "" {
+			continue // ignored column
+		}
+
+		if fieldName == schema.FieldNameId ||
+			(form.collection.IsAuth() && isRecordsImportAuthField(fieldName)) ||
+			form.collection.Schema.GetFieldByName(fieldName) != nil {
+			continue
+		}
+
+		return validation.NewError(
+			"validation_unknown_import_field",
+			fmt.Sprintf("Column %q is mapped to unknown field %q.", column, fieldName),
+		)
+	}
+
+	return nil
+}
+
+func isRecordsImportAuthField(name string) bool {
+	switch name {
+	case schema.FieldNameUsername,
+		schema.FieldNameEmail,
+		schema.FieldNameEmailVisibility,
+		schema.FieldNameVerified,
+		"password",
+		"passwordConfirm":
+		return true
+	}
+
+	return false
+}
+
+// Submit validates the form and imports the records from the provided reader.
+//
+// The rows are validated and saved with the same rules as the regular
+// record create/update requests and the valid rows are committed
+// in transactions of BatchSize rows. The failed rows are skipped
+// and reported in the returned result.
+//
+// In DryRun mode all rows are processed in a single transaction
+// which is rollbacked at the end.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before reading the records.
+func (form *RecordsImport) Submit(r io.Reader, interceptors ...InterceptorFunc[io.Reader]) (*RecordsImportResult, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if form.collection.IsView() {
+		return nil, errors.New("view collection records cannot be imported")
+	}
+
+	result := &RecordsImportResult{
+		DryRun: form.DryRun,
+		Errors: []*RecordsImportRowError{},
+	}
+
+	err := runInterceptors(r, func(r io.Reader) error {
+		if !form.DryRun {
+			return form.process(form.dao, r, result)
+		}
+
+		txErr := form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			if err := form.process(txDao, r, result); err != nil {
+				return err
+			}
+
+			return errRecordsImportDryRun
+		})
+		if txErr != nil && !errors.Is(txErr, errRecordsImportDryRun) {
+			return txErr
+		}
+
+		return nil
+	}, interceptors...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// recordsImportRow defines a single loaded import row.
+type recordsImportRow struct {
+	index int
+	data  map[string]any
+	err   error // row read error
+}
+
+// process reads the rows from r and imports them in batches.
+func (form *RecordsImport) process(dao *daos.Dao, r io.Reader, result *RecordsImportResult) error {
+	next, err := form.rowsReader(r)
+	if err != nil {
+		return err
+	}
+
+	batch := make([]*recordsImportRow, 0, form.BatchSize)
+
+	for i := 1; ; i++ {
+		data, err := next()
+		if err == io.EOF {
+			break
+		}
+
+		row := &recordsImportRow{index: i}
+
+		switch {
+		case errors.Is(err, csv.ErrFieldCount):
+			// malformed single CSV line -> report and continue with the next one
+			row.err = errors.New("Invalid number of CSV columns.")
+		case errors.Is(err, errRecordsImportInvalidJSON):
+			// malformed single NDJSON line -> report and continue with the next one
+			row.err = errors.New("Invalid JSON object.")
+		case err != nil:
+			return fmt.Errorf("failed to read row %d: %w", i, err)
+		default:
+			row.data = form.normalizeRow(data)
+		}
+
+		batch = append(batch, row)
+
+		if len(batch) >= form.BatchSize {
+			if err := form.processBatch(dao, batch, result); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	return form.processBatch(dao, batch, result)
+}
+
+// processBatch imports the provided rows within a single transaction.
+func (form *RecordsImport) processBatch(dao *daos.Dao, batch []*recordsImportRow, result *RecordsImportResult) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var created, updated int
+	var rowErrors []*RecordsImportRowError
+
+	txErr := dao.RunInTransaction(func(txDao *daos.Dao) error {
+		for _, row := range batch {
+			if row.err != nil {
+				rowErrors = append(rowErrors, newRecordsImportRowError(row, row.err))
+				continue
+			}
+
+			isNew, err := form.importRow(txDao, row.data)
+			if errors.Is(err, daos.ErrSavepointFailed) {
+				return err // abort the entire batch
+			}
+			if err != nil {
+				rowErrors = append(rowErrors, newRecordsImportRowError(row, err))
+				continue
+			}
+
+			if isNew {
+				created++
+			} else {
+				updated++
+			}
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return txErr
+	}
+
+	result.Total += len(batch)
+	result.Created += created
+	result.Updated += updated
+	result.Failed += len(rowErrors)
+	result.Errors = append(result.Errors, rowErrors...)
+
+	return nil
+}
+
+// importRow creates or updates a single record from the provided row data
+// within a savepoint so that a failed row doesn't affect the other rows.
+func (form *RecordsImport) importRow(txDao *daos.Dao, data map[string]any) (bool, error) {
+	var record *models.Record
+
+	if id := cast.ToString(data[schema.FieldNameId]); id != "" && form.Mode == RecordsImportModeUpsert {
+		record, _ = txDao.FindRecordById(form.collection.Id, id)
+	}
+
+	isNew := record == nil
+	if isNew {
+		record = models.NewRecord(form.collection)
+	}
+
+	err := txDao.RunInSavepoint(func(spDao *daos.Dao) error {
+		upsert := NewRecordUpsert(form.app, record)
+		upsert.SetDao(spDao)
+		upsert.SetFullManageAccess(true)
+
+		if err := upsert.LoadData(data); err != nil {
+			return err
+		}
+
+		return upsert.Submit()
+	})
+
+	return isNew, err
+}
+
+// normalizeRow maps the row columns to the collection fields and
+// excludes the file fields since they cannot be imported.
+func (form *RecordsImport) normalizeRow(data map[string]any) map[string]any {
+	result := make(map[string]any, len(data))
+
+	for column, value := range data {
+		name := column
+		if mapped, ok := form.Mapping[column]; ok {
+			if mapped == "" {
+				continue // ignored column
+			}
+			name = mapped
+		}
+
+		field := form.collection.Schema.GetFieldByName(name)
+		if field != nil && field.Type == schema.FieldTypeFile {
+			continue
+		}
+
+		// CSV cells are always strings so try to decode the json field values
+		if str, ok := value.(string); ok && field != nil && field.Type == schema.FieldTypeJson && form.Format == RecordsImportFormatCSV {
+			if str == "" {
+				value = nil
+			} else if json.Valid([]byte(str)) {
+				value = json.RawMessage(str)
+			}
+		}
+
+		result[name] = value
+	}
+
+	return result
+}
+
+// rowsReader returns a function that reads the next data row from r
+// (io.EOF is returned when there are no more rows).
+func (form *RecordsImport) rowsReader(r io.Reader) (func() (map[string]any, error), error) {
+	if form.Format == RecordsImportFormatNDJSON {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, MaxRecordsImportNDJSONLineSize)
+
+		return func() (map[string]any, error) {
+			for scanner.Scan() {
+				line := bytes.TrimSpace(scanner.Bytes())
+				if len(line) == 0 {
+					continue // skip empty lines
+				}
+
+				data := map[string]any{}
+				if err := json.Unmarshal(line, &data); err != nil {
+					return nil, errRecordsImportInvalidJSON
+				}
+
+				return data, nil
+			}
+
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+
+			return nil, io.EOF
+		}, nil
+	}
+
+	csvReader := csv.NewReader(r)
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return func() (map[string]any, error) { return nil, io.EOF }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV header: %w", err)
+	}
+
+	return func() (map[string]any, error) {
+		line, err := csvReader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		data := make(map[string]any, len(header))
+		for i, column := range header {
+			data[column] = line[i]
+		}
+
+		return data, nil
+	}, nil
+}
+
+// newRecordsImportRowError creates a new row error from the provided import error.
+func newRecordsImportRowError(row *recordsImportRow, err error) *RecordsImportRowError {
+	rowErr := &RecordsImportRowError{
+		Row:     row.index,
+		Id:      cast.ToString(row.data[schema.FieldNameId]),
+		Message: "Failed to import record.",
+		Data:    map[string]any{},
+	}
+
+	var validationErrs validation.Errors
+	if errors.As(err, &validationErrs) {
+		rowErr.Data = validationErrs
+	} else {
+		rowErr.Message = err.Error()
+	}
+
+	return rowErr
+}
@@ -0,0 +1,250 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsImportValidate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		collection     string
+		init           func(form *forms.RecordsImport)
+		expectedErrors []string
+	}{
+		{
+			"defaults",
+			"demo2",
+			func(form *forms.RecordsImport) {},
+			[]string{},
+		},
+		{
+			"invalid options",
+			"demo2",
+			func(form *forms.RecordsImport) {
+				form.Format = "xml"
+				form.Mode = "replace"
+				form.BatchSize = forms.MaxRecordsImportBatchSize + 1
+				form.Mapping = map[string]string{"a": "title", "b": "missing"}
+			},
+			[]string{"format", "mode", "batchSize", "mapping"},
+		},
+		{
+			"auth fields mapping in non-auth collection",
+			"demo2",
+			func(form *forms.RecordsImport) {
+				form.Mapping = map[string]string{"a": "email"}
+			},
+			[]string{"mapping"},
+		},
+		{
+			"valid options",
+			"users",
+			func(form *forms.RecordsImport) {
+				form.Format = forms.RecordsImportFormatNDJSON
+				form.Mode = forms.RecordsImportModeUpsert
+				form.BatchSize = 1
+				form.Mapping = map[string]string{"a": "id", "b": "email", "c": "name", "d": ""}
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form := forms.NewRecordsImport(app, collection)
+		s.init(form)
+
+		result := form.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		if len(errs) != len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRecordsImportSubmit(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		collection     string
+		data           string
+		init           func(form *forms.RecordsImport)
+		expectError    bool
+		expectedResult string
+		expectedTitles map[string]bool // title => exists
+	}{
+		{
+			"view collection",
+			"view1",
+			"id\n",
+			func(form *forms.RecordsImport) {},
+			true,
+			"",
+			nil,
+		},
+		{
+			"invalid form",
+			"demo2",
+			"title\nnew1\n",
+			func(form *forms.RecordsImport) {
+				form.Mode = "invalid"
+			},
+			true,
+			"",
+			map[string]bool{"new1": false},
+		},
+		{
+			"malformed ndjson lines",
+			"demo2",
+			`{"title":"new1"}` + "\n" + `{"title":` + "\n" + `["new2"]` + "\n" + `{"title":"new3"}` + "\n" + `{"title":`,
+			func(form *forms.RecordsImport) {
+				form.Format = forms.RecordsImportFormatNDJSON
+				form.BatchSize = 2
+			},
+			false,
+			`{"dryRun":false,"total":5,"created":2,"updated":0,"failed":3,"errors":[` +
+				`{"row":2,"id":"","message":"Invalid JSON object.","data":{}},` +
+				`{"row":3,"id":"","message":"Invalid JSON object.","data":{}},` +
+				`{"row":5,"id":"","message":"Invalid JSON object.","data":{}}` +
+				`]}`,
+			map[string]bool{"new1": true, "new3": true},
+		},
+		{
+			"empty csv",
+			"demo2",
+			"",
+			func(form *forms.RecordsImport) {},
+			false,
+			`{"dryRun":false,"total":0,"created":0,"updated":0,"failed":0,"errors":[]}`,
+			nil,
+		},
+		{
+			"csv insert with failed rows",
+			"demo2",
+			"id,title,active\n" +
+				",new1,true\n" +
+				",test1,false\n" +
+				",new2\n" +
+				"llvuca81nly1qls,new3,false\n" +
+				",new4,false\n",
+			func(form *forms.RecordsImport) {},
+			false,
+			`{"dryRun":false,"total":5,"created":2,"updated":0,"failed":3,"errors":[` +
+				`{"row":2,"id":"","message":"Failed to import record.","data":{"title":"Value must be unique"}},` +
+				`{"row":3,"id":"","message":"Invalid number of CSV columns.","data":{}},` +
+				`{"row":4,"id":"llvuca81nly1qls","message":"Failed to import record.","data":{"id":"The model id is invalid or already exists."}}` +
+				`]}`,
+			map[string]bool{"new1": true, "new2": false, "new3": false, "new4": true, "test1": true},
+		},
+		{
+			"csv upsert with mapping",
+			"demo2",
+			"key,Name,ignored\n" +
+				"llvuca81nly1qls,updated1,abc\n" +
+				",new1,abc\n",
+			func(form *forms.RecordsImport) {
+				form.Mode = forms.RecordsImportModeUpsert
+				form.Mapping = map[string]string{"key": "id", "Name": "title", "ignored": ""}
+			},
+			false,
+			`{"dryRun":false,"total":2,"created":1,"updated":1,"failed":0,"errors":[]}`,
+			map[string]bool{"test1": false, "updated1": true, "new1": true},
+		},
+		{
+			"ndjson with batches",
+			"demo2",
+			`{"title":"new1"}` + "\n" + `{"title":"x"}` + "\n\n" + `{"title":"new2","active":true}` + "\n",
+			func(form *forms.RecordsImport) {
+				form.Format = forms.RecordsImportFormatNDJSON
+				form.BatchSize = 1
+			},
+			false,
+			`{"dryRun":false,"total":3,"created":2,"updated":0,"failed":1,"errors":[` +
+				`{"row":2,"id":"","message":"Failed to import record.","data":{"title":"Must be at least 2 character(s)"}}` +
+				`]}`,
+			map[string]bool{"new1": true, "x": false, "new2": true},
+		},
+		{
+			"dry run",
+			"demo2",
+			"id,title\n" +
+				",new1\n" +
+				"llvuca81nly1qls,updated1\n" +
+				",x\n",
+			func(form *forms.RecordsImport) {
+				form.Mode = forms.RecordsImportModeUpsert
+				form.DryRun = true
+			},
+			false,
+			`{"dryRun":true,"total":3,"created":1,"updated":1,"failed":1,"errors":[` +
+				`{"row":3,"id":"","message":"Failed to import record.","data":{"title":"Must be at least 2 character(s)"}}` +
+				`]}`,
+			map[string]bool{"new1": false, "updated1": false, "test1": true},
+		},
+	}
+
+	for _, s := range scenarios {
+		func() {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordsImport(app, collection)
+			s.init(form)
+
+			result, err := form.Submit(strings.NewReader(s.data))
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+				return
+			}
+
+			if !hasErr {
+				encoded, _ := json.Marshal(result)
+				if string(encoded) != s.expectedResult {
+					t.Errorf("[%s] Expected result \n%s, \ngot \n%s", s.name, s.expectedResult, encoded)
+				}
+			}
+
+			for title, exists := range s.expectedTitles {
+				_, err := app.Dao().FindFirstRecordByData(collection.Id, "title", title)
+				if exists != (err == nil) {
+					t.Errorf("[%s] Expected record %q to exist %v, got %v (%v)", s.name, title, exists, err == nil, err)
+				}
+			}
+		}()
+	}
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
 )
 
 var requiredErr = validation.NewError("validation_required", "Missing required value")
@@ -76,7 +78,7 @@ func (validator *RecordDataValidator) Validate(data map[string]any) error {
 		value := field.PrepareValue(data[key])
 
 		// check required constraint
-		if field.Required && validation.Required.Validate(value) != nil {
+		if field.Required && validation.Required.Validate(value) != nil && !canDeriveValue(field, data) {
 			errs[key] = requiredErr
 			continue
 		}
@@ -119,6 +121,12 @@ func (validator *RecordDataValidator) checkFieldValue(field *schema.SchemaField,
 		return validator.checkFileValue(field, value)
 	case schema.FieldTypeRelation:
 		return validator.checkRelationValue(field, value)
+	case schema.FieldTypeGeoPoint:
+		return validator.checkGeoPointValue(field, value)
+	case schema.FieldTypeEncrypted:
+		return validator.checkEncryptedValue(field, value)
+	case schema.FieldTypeDecimal:
+		return validator.checkDecimalValue(field, value)
 	}
 
 	return nil
@@ -153,6 +161,44 @@ func (validator *RecordDataValidator) checkTextValue(field *schema.SchemaField,
 	return nil
 }
 
+func (validator *RecordDataValidator) checkEncryptedValue(field *schema.SchemaField, value any) error {
+	options, _ := field.Options.(*schema.EncryptedOptions)
+
+	// validate the plain value as regular text
+	textField := *field
+	textField.Options = &options.TextOptions
+
+	return validator.checkTextValue(&textField, value)
+}
+
+func (validator *RecordDataValidator) checkDecimalValue(field *schema.SchemaField, value any) error {
+	val, _ := value.(types.Decimal)
+	if val.IsZero() {
+		if field.Required {
+			return requiredErr
+		}
+		return nil // nothing to check (skip zero-defaults)
+	}
+
+	options, _ := field.Options.(*schema.DecimalOptions)
+
+	if val.Round(int32(options.Scale)).Cmp(val) != 0 {
+		return validation.NewError(
+			"validation_decimal_scale_constraint",
+			fmt.Sprintf("Must have no more than %d decimal place(s)", options.Scale),
+		)
+	}
+
+	if intDigits := val.Round(int32(options.Scale)).Precision() - options.Scale; intDigits > options.Precision-options.Scale {
+		return validation.NewError(
+			"validation_decimal_precision_constraint",
+			fmt.Sprintf("Must have no more than %d digit(s) before the decimal point", options.Precision-options.Scale),
+		)
+	}
+
+	return nil
+}
+
 func (validator *RecordDataValidator) checkNumberValue(field *schema.SchemaField, value any) error {
 	val, _ := value.(float64)
 	if val == 0 {
@@ -265,6 +311,26 @@ func (validator *RecordDataValidator) checkDateValue(field *schema.SchemaField,
 	return nil
 }
 
+func (validator *RecordDataValidator) checkGeoPointValue(field *schema.SchemaField, value any) error {
+	val, _ := value.(types.GeoPoint)
+	if val.IsZero() {
+		if field.Required {
+			return requiredErr
+		}
+		return nil // nothing to check
+	}
+
+	if val.Lon < -180 || val.Lon > 180 {
+		return validation.NewError("validation_invalid_geo_point_lon", "The longitude must be between -180 and 180 degrees")
+	}
+
+	if val.Lat < -90 || val.Lat > 90 {
+		return validation.NewError("validation_invalid_geo_point_lat", "The latitude must be between -90 and 90 degrees")
+	}
+
+	return nil
+}
+
 func (validator *RecordDataValidator) checkSelectValue(field *schema.SchemaField, value any) error {
 	normalizedVal := list.ToUniqueStringSlice(value)
 	if len(normalizedVal) == 0 {
@@ -313,7 +379,46 @@ func (validator *RecordDataValidator) checkJsonValue(field *schema.SchemaField,
 		return requiredErr
 	}
 
-	return nil
+	if rawStr == "" || rawStr == "null" {
+		return nil // nothing to check against the schema
+	}
+
+	return validator.checkJsonSchemaValue(options, raw)
+}
+
+// checkJsonSchemaValue validates the raw json value against the field JSON Schema (if any).
+//
+// The schema errors are returned as nested validation errors keyed by the
+// invalid value path (eg. `{"$.items[0].name": {"code": "validation_json_schema_type", ...}}`).
+func (validator *RecordDataValidator) checkJsonSchemaValue(options *schema.JsonOptions, raw types.JsonRaw) error {
+	compiled, err := options.CompiledSchema()
+	if err != nil || compiled == nil {
+		return nil // the schema is validated on collection save
+	}
+
+	schemaErrs, err := compiled.ValidateJSON(raw)
+	if err != nil {
+		return validation.NewError("validation_invalid_json", "Must be a valid json value")
+	}
+
+	if len(schemaErrs) == 0 {
+		return nil
+	}
+
+	result := validation.Errors{}
+
+	for _, schemaErr := range schemaErrs {
+		if _, ok := result[schemaErr.Path]; ok {
+			continue // keep only the first error per path
+		}
+
+		result[schemaErr.Path] = validation.NewError(
+			"validation_json_schema_"+inflector.Snakecase(schemaErr.Keyword),
+			schemaErr.Message,
+		)
+	}
+
+	return result
 }
 
 func (validator *RecordDataValidator) checkFileValue(field *schema.SchemaField, value any) error {
@@ -391,3 +496,18 @@ func (validator *RecordDataValidator) checkRelationValue(field *schema.SchemaFie
 
 	return nil
 }
+
+// canDeriveValue checks whether the empty value of a derived text field
+// could be generated on save from its DeriveFrom field value.
+func canDeriveValue(field *schema.SchemaField, data map[string]any) bool {
+	if field.Type != schema.FieldTypeText {
+		return false
+	}
+
+	options, _ := field.Options.(*schema.TextOptions)
+	if options == nil || options.DeriveFrom == "" {
+		return false
+	}
+
+	return inflector.Slugify(cast.ToString(data[options.DeriveFrom])) != ""
+}
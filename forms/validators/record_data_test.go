@@ -731,6 +731,315 @@ func TestRecordDataValidatorValidateDate(t *testing.T) {
 	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
 }
 
+func TestRecordDataValidatorValidateGeoPoint(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "field1",
+			Type: schema.FieldTypeGeoPoint,
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeGeoPoint,
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(geoPoint) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": nil,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(geoPoint) check required constraint + zero point",
+			map[string]any{
+				"field1": `{"lon":0,"lat":0}`,
+				"field2": map[string]any{"lon": 0, "lat": 0},
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(geoPoint) check invalid longitude",
+			map[string]any{
+				"field1": map[string]any{"lon": -180.1, "lat": 0},
+				"field2": map[string]any{"lon": 180.1, "lat": 0},
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(geoPoint) check invalid latitude",
+			map[string]any{
+				"field1": map[string]any{"lon": 0, "lat": -90.1},
+				"field2": types.GeoPoint{Lon: 0, Lat: 90.1},
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(geoPoint) valid data (only required)",
+			map[string]any{
+				"field2": `{"lon":23.32,"lat":42.69}`,
+			},
+			nil,
+			[]string{},
+		},
+		{
+			"(geoPoint) valid data (all)",
+			map[string]any{
+				"field1": types.GeoPoint{Lon: -180, Lat: 90},
+				"field2": map[string]any{"lon": 180, "lat": -90},
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidateDerivedText(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "title",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name:     "slug",
+			Required: true,
+			Type:     schema.FieldTypeText,
+			Options:  &schema.TextOptions{DeriveFrom: "title"},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(derived text) empty value and empty source",
+			map[string]any{
+				"title": "",
+				"slug":  "",
+			},
+			nil,
+			[]string{"slug"},
+		},
+		{
+			"(derived text) empty value and non-sluggable source",
+			map[string]any{
+				"title": "!!!",
+				"slug":  "",
+			},
+			nil,
+			[]string{"slug"},
+		},
+		{
+			"(derived text) empty value and derivable source",
+			map[string]any{
+				"title": "test",
+				"slug":  "",
+			},
+			nil,
+			[]string{},
+		},
+		{
+			"(derived text) explicit value",
+			map[string]any{
+				"slug": "test",
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidateEncrypted(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	min := 3
+	max := 5
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "field1",
+			Type: schema.FieldTypeEncrypted,
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeEncrypted,
+			Options: &schema.EncryptedOptions{
+				TextOptions: schema.TextOptions{Pattern: `^\w+$`},
+				BlindIndex:  true,
+			},
+		},
+		&schema.SchemaField{
+			Name: "field3",
+			Type: schema.FieldTypeEncrypted,
+			Options: &schema.EncryptedOptions{
+				TextOptions: schema.TextOptions{Min: &min, Max: &max},
+			},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(encrypted) check required constraint",
+			map[string]any{
+				"field1": "",
+				"field2": "",
+				"field3": "",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(encrypted) check pattern constraint",
+			map[string]any{
+				"field1": "test test",
+				"field2": "test test",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(encrypted) check min constraint",
+			map[string]any{
+				"field2": "test",
+				"field3": "ab",
+			},
+			nil,
+			[]string{"field3"},
+		},
+		{
+			"(encrypted) check max constraint",
+			map[string]any{
+				"field2": "test",
+				"field3": "abcdef",
+			},
+			nil,
+			[]string{"field3"},
+		},
+		{
+			"(encrypted) valid data",
+			map[string]any{
+				"field1": "test test",
+				"field2": "test",
+				"field3": "abcd",
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidateDecimal(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypeDecimal,
+			Options: &schema.DecimalOptions{Precision: 5, Scale: 2},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeDecimal,
+			Options:  &schema.DecimalOptions{Precision: 3, Scale: 0},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(decimal) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": "0.00",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(decimal) check scale constraint",
+			map[string]any{
+				"field1": "1.005",
+				"field2": "1.5",
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(decimal) check precision constraint",
+			map[string]any{
+				"field1": "1000",
+				"field2": "-1000",
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(decimal) trailing zeros are allowed",
+			map[string]any{
+				"field1": "1.2500",
+				"field2": "10.000",
+			},
+			nil,
+			[]string{},
+		},
+		{
+			"(decimal) valid data",
+			map[string]any{
+				"field1": "-999.99",
+				"field2": 999,
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
 func TestRecordDataValidatorValidateSelect(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -1001,6 +1310,97 @@ func TestRecordDataValidatorValidateJson(t *testing.T) {
 	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
 }
 
+func TestRecordDataValidatorValidateJsonSchema(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "settings",
+			Type: schema.FieldTypeJson,
+			Options: &schema.JsonOptions{
+				MaxSize: 9999,
+				Schema: types.JsonRaw(`{
+					"type": "object",
+					"required": ["theme"],
+					"properties": {
+						"theme": {"enum": ["light", "dark"]},
+						"items": {"type": "array", "items": {"type": "integer"}}
+					}
+				}`),
+			},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		value          any
+		expectedErrors map[string]string // path -> code
+	}{
+		{
+			"empty value",
+			nil,
+			nil,
+		},
+		{
+			"invalid root type",
+			`"test"`,
+			map[string]string{"$": "validation_json_schema_type"},
+		},
+		{
+			"invalid nested values",
+			`{"items":[1, "2", 3.5]}`,
+			map[string]string{
+				"$.theme":    "validation_json_schema_required",
+				"$.items[1]": "validation_json_schema_type",
+				"$.items[2]": "validation_json_schema_type",
+			},
+		},
+		{
+			"valid value",
+			map[string]any{"theme": "dark", "items": []int{1, 2}},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			validator := validators.NewRecordDataValidator(app.Dao(), models.NewRecord(collection), nil)
+
+			result := validator.Validate(map[string]any{"settings": s.value})
+			if len(s.expectedErrors) == 0 {
+				if result != nil {
+					t.Fatalf("Expected no errors, got %v", result)
+				}
+				return
+			}
+
+			errs, _ := result.(validation.Errors)
+			settingsErrs, ok := errs["settings"].(validation.Errors)
+			if !ok {
+				t.Fatalf("Expected nested settings errors, got %v", result)
+			}
+
+			if len(settingsErrs) != len(s.expectedErrors) {
+				t.Fatalf("Expected errors %v, got %v", s.expectedErrors, settingsErrs)
+			}
+
+			for path, code := range s.expectedErrors {
+				err, _ := settingsErrs[path].(validation.Error)
+				if err == nil || err.Code() != code {
+					t.Fatalf("Expected %q error with code %q, got %v", path, code, settingsErrs[path])
+				}
+			}
+		})
+	}
+}
+
 func TestRecordDataValidatorValidateFile(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
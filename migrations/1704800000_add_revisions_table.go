@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the system table for storing the record revisions
+// of the collections with enabled "trackHistory" option.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_revisions}} (
+				[[id]]                TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]      TEXT NOT NULL,
+				[[recordId]]          TEXT NOT NULL,
+				[[action]]            TEXT DEFAULT "" NOT NULL,
+				[[data]]              JSON DEFAULT "{}" NOT NULL,
+				[[changes]]           JSON DEFAULT "[]" NOT NULL,
+				[[actorType]]         TEXT DEFAULT "" NOT NULL,
+				[[actorCollectionId]] TEXT DEFAULT "" NOT NULL,
+				[[actorId]]           TEXT DEFAULT "" NOT NULL,
+				[[created]]           TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]           TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE INDEX _revisions_record_idx on {{_revisions}} ([[collectionId]], [[recordId]], [[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_revisions").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the system table for storing the last assigned
+// value of the collections sequence fields.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_sequences}} (
+				[[collectionId]] TEXT NOT NULL,
+				[[fieldId]]      TEXT NOT NULL,
+				[[scope]]        TEXT DEFAULT "" NOT NULL,
+				[[value]]        INTEGER DEFAULT 0 NOT NULL,
+				---
+				PRIMARY KEY ([[collectionId]], [[fieldId]], [[scope]]),
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_sequences").Execute()
+
+		return err
+	})
+}
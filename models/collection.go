@@ -7,6 +7,7 @@ import (
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
 )
 
 var (
@@ -14,6 +15,14 @@ var (
 	_ FilesManager = (*Collection)(nil)
 )
 
+const (
+	collectionOptionSoftDelete   = "softDelete"
+	collectionOptionTrackHistory = "trackHistory"
+	collectionOptionSearchFields = "searchFields"
+
+	collectionOptionValidationRules = "validationRules"
+)
+
 const (
 	CollectionTypeBase = "base"
 	CollectionTypeAuth = "auth"
@@ -64,6 +73,72 @@ func (m *Collection) IsView() bool {
 	return m.Type == CollectionTypeView
 }
 
+// HasSoftDelete checks if the current collection has enabled the
+// "softDelete" option (aka. deleted records are moved to the trash).
+//
+// View collections always return false.
+func (m *Collection) HasSoftDelete() bool {
+	if m.IsView() {
+		return false
+	}
+
+	// direct options access to avoid decoding the
+	// options every time a collection record is queried
+	return cast.ToBool(m.Options[collectionOptionSoftDelete])
+}
+
+// HasTrackHistory checks if the current collection has enabled the
+// "trackHistory" option (aka. each record save creates a revision).
+//
+// View collections always return false.
+func (m *Collection) HasTrackHistory() bool {
+	if m.IsView() {
+		return false
+	}
+
+	return cast.ToBool(m.Options[collectionOptionTrackHistory])
+}
+
+// SearchFields returns the names of the collection fields that are
+// full-text indexed (aka. the "searchFields" option).
+//
+// View collections always return nil.
+func (m *Collection) SearchFields() []string {
+	if m.IsView() {
+		return nil
+	}
+
+	return cast.ToStringSlice(m.Options[collectionOptionSearchFields])
+}
+
+// ValidationRules returns the cross-field record validation
+// rules of the collection (aka. the "validationRules" option).
+//
+// View collections always return nil.
+func (m *Collection) ValidationRules() []CollectionValidationRule {
+	if _, ok := m.Options[collectionOptionValidationRules]; !ok {
+		return nil
+	}
+
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().ValidationRules
+	case CollectionTypeAuth:
+		return m.AuthOptions().ValidationRules
+	}
+
+	return nil
+}
+
+// SearchTableName returns the name of the collection FTS5 virtual
+// table that stores the full-text indexed field values.
+//
+// The table name is based on the collection id so that
+// it doesn't need to be renamed with the collection.
+func (m *Collection) SearchTableName() string {
+	return "_" + m.Id + "_fts"
+}
+
 // MarshalJSON implements the [json.Marshaler] interface.
 func (m Collection) MarshalJSON() ([]byte, error) {
 	type alias Collection // prevent recursion
@@ -162,11 +237,18 @@ func (m *Collection) SetOptions(typedOptions any) error {
 
 // CollectionBaseOptions defines the "base" Collection.Options fields.
 type CollectionBaseOptions struct {
+	SoftDelete   bool     `form:"softDelete" json:"softDelete,omitempty"`
+	TrackHistory bool     `form:"trackHistory" json:"trackHistory,omitempty"`
+	SearchFields []string `form:"searchFields" json:"searchFields,omitempty"`
+
+	ValidationRules []CollectionValidationRule `form:"validationRules" json:"validationRules,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
 func (o CollectionBaseOptions) Validate() error {
-	return nil
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.ValidationRules),
+	)
 }
 
 // -------------------------------------------------------------------
@@ -182,11 +264,17 @@ type CollectionAuthOptions struct {
 	OnlyVerified       bool     `form:"onlyVerified" json:"onlyVerified"`
 	OnlyEmailDomains   []string `form:"onlyEmailDomains" json:"onlyEmailDomains"`
 	MinPasswordLength  int      `form:"minPasswordLength" json:"minPasswordLength"`
+	SoftDelete         bool     `form:"softDelete" json:"softDelete,omitempty"`
+	TrackHistory       bool     `form:"trackHistory" json:"trackHistory,omitempty"`
+	SearchFields       []string `form:"searchFields" json:"searchFields,omitempty"`
+
+	ValidationRules []CollectionValidationRule `form:"validationRules" json:"validationRules,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
 func (o CollectionAuthOptions) Validate() error {
 	return validation.ValidateStruct(&o,
+		validation.Field(&o.ValidationRules),
 		validation.Field(&o.ManageRule, validation.NilOrNotEmpty),
 		validation.Field(
 			&o.ExceptEmailDomains,
@@ -207,6 +295,34 @@ func (o CollectionAuthOptions) Validate() error {
 
 // -------------------------------------------------------------------
 
+// CollectionValidationRule defines a single cross-field record
+// constraint of a "base" or "auth" collection (eg. "endDate > startDate").
+//
+// The rule is checked on each record create/update and the record
+// is considered valid only if it satisfies the rule filter expression.
+type CollectionValidationRule struct {
+	// Expression is the filter expression that the record must satisfy.
+	// It has access to the same identifiers as the collection API rules.
+	Expression string `form:"expression" json:"expression"`
+
+	// Field is the name of the record field the error is reported for.
+	Field string `form:"field" json:"field"`
+
+	// Message is the error message returned when the rule is not satisfied.
+	Message string `form:"message" json:"message"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (r CollectionValidationRule) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Expression, validation.Required),
+		validation.Field(&r.Field, validation.Required),
+		validation.Field(&r.Message, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// -------------------------------------------------------------------
+
 // CollectionViewOptions defines the "view" Collection.Options fields.
 type CollectionViewOptions struct {
 	Query string `form:"query" json:"query"`
@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -74,6 +75,114 @@ func TestCollectionIsAuth(t *testing.T) {
 	}
 }
 
+func TestCollectionHasSoftDelete(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		collection models.Collection
+		expected   bool
+	}{
+		{models.Collection{}, false},
+		{models.Collection{Type: models.CollectionTypeBase}, false},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"softDelete": false}}, false},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"softDelete": true}}, true},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"softDelete": true}}, true},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"softDelete": true}}, false},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.HasSoftDelete()
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestCollectionHasTrackHistory(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		collection models.Collection
+		expected   bool
+	}{
+		{models.Collection{}, false},
+		{models.Collection{Type: models.CollectionTypeBase}, false},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"trackHistory": false}}, false},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"trackHistory": true}}, true},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"trackHistory": true}}, true},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"trackHistory": true}}, false},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.HasTrackHistory()
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestCollectionSearchFields(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		collection models.Collection
+		expected   string
+	}{
+		{models.Collection{}, ""},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"searchFields": nil}}, ""},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"searchFields": []string{"a", "b"}}}, "a,b"},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"searchFields": []any{"a"}}}, "a"},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"searchFields": []string{"a"}}}, ""},
+	}
+
+	for i, s := range scenarios {
+		result := strings.Join(s.collection.SearchFields(), ",")
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestCollectionValidationRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []any{map[string]any{"expression": "a > b", "field": "a", "message": "test"}}
+
+	scenarios := []struct {
+		collection models.Collection
+		expected   int
+	}{
+		{models.Collection{}, 0},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"validationRules": nil}}, 0},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"validationRules": rules}}, 1},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"validationRules": rules}}, 1},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"validationRules": rules}}, 0},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.ValidationRules()
+		if len(result) != s.expected {
+			t.Errorf("(%d) Expected %d rules, got %v", i, s.expected, result)
+			continue
+		}
+
+		if s.expected > 0 && (result[0].Expression != "a > b" || result[0].Field != "a" || result[0].Message != "test") {
+			t.Errorf("(%d) Unexpected rule %v", i, result[0])
+		}
+	}
+}
+
+func TestCollectionSearchTableName(t *testing.T) {
+	t.Parallel()
+
+	c := models.Collection{}
+	c.Id = "test"
+
+	if v := c.SearchTableName(); v != "_test_fts" {
+		t.Fatalf("Expected %q, got %q", "_test_fts", v)
+	}
+}
+
 func TestCollectionMarshalJSON(t *testing.T) {
 	t.Parallel()
 
@@ -385,6 +494,59 @@ func TestCollectionBaseOptionsValidate(t *testing.T) {
 	if err := opt.Validate(); err != nil {
 		t.Fatal(err)
 	}
+
+	opt.ValidationRules = []models.CollectionValidationRule{{Expression: "a > b", Field: "a"}}
+	if err := opt.Validate(); err == nil {
+		t.Fatal("Expected validation rules error, got nil")
+	}
+}
+
+func TestCollectionValidationRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		rule           models.CollectionValidationRule
+		expectedErrors []string
+	}{
+		{
+			"empty",
+			models.CollectionValidationRule{},
+			[]string{"expression", "field", "message"},
+		},
+		{
+			"too long message",
+			models.CollectionValidationRule{Expression: "a > b", Field: "a", Message: strings.Repeat("a", 256)},
+			[]string{"message"},
+		},
+		{
+			"valid",
+			models.CollectionValidationRule{Expression: "a > b", Field: "a", Message: "test"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.rule.Validate()
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Fatalf("Failed to parse errors %v", result)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got errors \n%v", s.expectedErrors, result)
+			}
+
+			for key := range errs {
+				if !list.ExistInSlice(key, s.expectedErrors) {
+					t.Fatalf("Unexpected error key %q in \n%v", key, errs)
+				}
+			}
+		})
+	}
 }
 
 func TestCollectionAuthOptionsValidate(t *testing.T) {
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/types"
@@ -29,22 +31,79 @@ type Record struct {
 
 	collection *Collection
 
-	exportUnknown         bool // whether to export unknown fields
-	ignoreEmailVisibility bool // whether to ignore the emailVisibility flag for auth collections
+	exportUnknown         bool                // whether to export unknown fields
+	ignoreEmailVisibility bool                // whether to ignore the emailVisibility flag for auth collections
+	hiddenFields          map[string]struct{} // schema fields excluded from the public export
 	loaded                bool
-	originalData          map[string]any    // the original (aka. first loaded) model data
-	expand                *store.Store[any] // expanded relations
-	data                  *store.Store[any] // any custom data in addition to the base model fields
+	originalData          map[string]any           // the original (aka. first loaded) model data
+	revisionActor         Model                    // the admin or auth record that made the last changes
+	encryptionKey         string                   // the key used to encrypt the encrypted fields on save
+	encryptedStored       map[string]string        // the not yet decrypted (and unchanged) stored encrypted fields values
+	originalEncrypted     map[string]string        // the not yet decrypted stored encrypted fields values at load time
+	decimalIncrements     map[string]types.Decimal // the decimal fields increments applied by the db on update
+	expand                *store.Store[any]        // expanded relations
+	data                  *store.Store[any]        // any custom data in addition to the base model fields
 }
 
-// NewRecord initializes a new empty Record model.
+// NewRecord initializes a new Record model with the
+// collection schema fields default values (if any).
+//
+// Note that the request related default macros (eg. "@request.auth.id")
+// are not resolved (see [forms.RecordUpsert.SetRequestInfo]).
 func NewRecord(collection *Collection) *Record {
+	record := newEmptyRecord(collection)
+
+	if collection != nil {
+		for _, field := range collection.Schema.Fields() {
+			if v, ok := FieldDefaultValue(field, nil); ok {
+				record.Set(field.Name, v)
+			}
+		}
+	}
+
+	return record
+}
+
+// newEmptyRecord initializes a new empty Record model.
+func newEmptyRecord(collection *Collection) *Record {
 	return &Record{
 		collection: collection,
 		data:       store.New[any](nil),
 	}
 }
 
+// FieldDefaultValue returns the default value of the provided
+// schema field with resolved macro (if any).
+//
+// The request related macros (eg. "@request.auth.id") are resolved from
+// requestInfo and are skipped if there is no request info or auth record.
+//
+// Returns false if the field doesn't have a default value to apply.
+func FieldDefaultValue(field *schema.SchemaField, requestInfo *RequestInfo) (any, bool) {
+	value, ok := field.DefaultValue()
+	if !ok {
+		return nil, false
+	}
+
+	switch macro := field.DefaultMacro(); macro {
+	case "":
+		return value, true
+	case schema.DefaultMacroRequestAuthId:
+		if requestInfo == nil || requestInfo.AuthRecord == nil {
+			return nil, false
+		}
+
+		return requestInfo.AuthRecord.Id, true
+	default:
+		v, err := search.IdentifierMacroValue(macro)
+		if err != nil {
+			return nil, false
+		}
+
+		return v, true
+	}
+}
+
 // nullStringMapValue returns the raw string value if it exist and
 // its not NULL, otherwise - nil.
 func nullStringMapValue(data dbx.NullStringMap, key string) any {
@@ -65,9 +124,30 @@ func nullStringMapValue(data dbx.NullStringMap, key string) any {
 func NewRecordFromNullStringMap(collection *Collection, data dbx.NullStringMap) *Record {
 	resultMap := make(map[string]any, len(data))
 
+	var encryptedStored map[string]string
+
 	// load schema fields
 	for _, field := range collection.Schema.Fields() {
 		resultMap[field.Name] = nullStringMapValue(data, field.Name)
+
+		// keep the encrypted fields stored values aside until decrypted
+		// with the Dao key (see Record.DecryptFields)
+		if field.Type == schema.FieldTypeEncrypted {
+			if stored, _ := resultMap[field.Name].(string); stored != "" {
+				if encryptedStored == nil {
+					encryptedStored = map[string]string{}
+				}
+				encryptedStored[field.Name] = stored
+			}
+			resultMap[field.Name] = nil
+		}
+
+		// unscale the decimal fields stored integer
+		if field.Type == schema.FieldTypeDecimal {
+			stored := types.Decimal{}
+			_ = stored.Scan(resultMap[field.Name])
+			resultMap[field.Name] = stored.Mul(types.NewDecimal(1, decimalFieldScale(field)))
+		}
 	}
 
 	// load base model fields
@@ -82,9 +162,15 @@ func NewRecordFromNullStringMap(collection *Collection, data dbx.NullStringMap)
 		}
 	}
 
-	record := NewRecord(collection)
+	// load the soft delete state
+	if collection.HasSoftDelete() {
+		resultMap[schema.FieldNameDeleted] = nullStringMapValue(data, schema.FieldNameDeleted)
+	}
+
+	record := newEmptyRecord(collection)
 
 	record.Load(resultMap)
+	record.setEncryptedStored(encryptedStored)
 	record.PostScan()
 
 	return record
@@ -119,8 +205,10 @@ func (m *Record) Collection() *Collection {
 // with its ORIGINAL data state (aka. the initially loaded) and
 // everything else reset to the defaults.
 func (m *Record) OriginalCopy() *Record {
-	newRecord := NewRecord(m.collection)
+	newRecord := newEmptyRecord(m.collection)
 	newRecord.Load(m.originalData)
+	newRecord.setEncryptedStored(m.originalEncrypted)
+	newRecord.encryptionKey = m.encryptionKey
 
 	if m.IsNew() {
 		newRecord.MarkAsNew()
@@ -134,8 +222,10 @@ func (m *Record) OriginalCopy() *Record {
 // CleanCopy returns a copy of the current record model populated only
 // with its LATEST data state and everything else reset to the defaults.
 func (m *Record) CleanCopy() *Record {
-	newRecord := NewRecord(m.collection)
+	newRecord := newEmptyRecord(m.collection)
 	newRecord.Load(m.data.GetAll())
+	newRecord.setEncryptedStored(m.encryptedStored)
+	newRecord.encryptionKey = m.encryptionKey
 	newRecord.Id = m.Id
 	newRecord.Created = m.Created
 	newRecord.Updated = m.Updated
@@ -149,6 +239,59 @@ func (m *Record) CleanCopy() *Record {
 	return newRecord
 }
 
+// ETag returns the current record version as a quoted HTTP entity tag
+// derived from the record id and its "updated" timestamp.
+//
+// Returns an empty string if the record doesn't have an "updated"
+// value (eg. in case of a view collection record).
+func (m *Record) ETag() string {
+	if m.Updated.IsZero() {
+		return ""
+	}
+
+	return `"` + security.MD5(m.Id+m.Updated.String()) + `"`
+}
+
+// MatchETag checks whether the current record ETag matches the
+// provided "If-Match" header value (aka. "*" or a comma separated
+// list of quoted entity tags).
+//
+// Weak entity tags (aka. W/"...") never match.
+func (m *Record) MatchETag(ifMatch string) bool {
+	etag := m.ETag()
+
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag == "*" || (etag != "" && tag == etag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsTrashed checks whether the record is marked as soft deleted
+// (aka. it has a nonempty "deleted" timestamp).
+func (m *Record) IsTrashed() bool {
+	return m.GetString(schema.FieldNameDeleted) != ""
+}
+
+// SetRevisionActor sets the admin or auth record model that made
+// the current record changes.
+//
+// The actor is stored with the record revision if the record
+// collection has enabled the "trackHistory" option.
+func (m *Record) SetRevisionActor(actor Model) {
+	m.revisionActor = actor
+}
+
+// RevisionActor returns the admin or auth record model that made
+// the current record changes (if set).
+func (m *Record) RevisionActor() Model {
+	return m.revisionActor
+}
+
 // Expand returns a shallow copy of the current Record model expand data.
 func (m *Record) Expand() map[string]any {
 	if m.expand == nil {
@@ -285,6 +428,26 @@ func (m *Record) WithUnknownData(state bool) {
 	m.exportUnknown = state
 }
 
+// Hide excludes the specified schema fields (and their expanded relations)
+// from the public safe serialization of the record (see [Record.PublicExport]).
+//
+// The field values are still accessible with [Record.Get].
+func (m *Record) Hide(fieldNames ...string) {
+	if m.hiddenFields == nil {
+		m.hiddenFields = make(map[string]struct{}, len(fieldNames))
+	}
+
+	for _, name := range fieldNames {
+		m.hiddenFields[name] = struct{}{}
+	}
+}
+
+// IsHidden checks whether the specified field is excluded from the record public export.
+func (m *Record) IsHidden(fieldName string) bool {
+	_, ok := m.hiddenFields[fieldName]
+	return ok
+}
+
 // Set sets the provided key-value data pair for the current Record model.
 //
 // If the record collection has field with name matching the provided "key",
@@ -321,9 +484,48 @@ func (m *Record) Set(key string, value any) {
 		}
 
 		m.data.Set(key, v)
+
+		// the stored encrypted value is replaced with a new one
+		delete(m.encryptedStored, key)
+
+		// the explicitly assigned value replaces the pending increment
+		delete(m.decimalIncrements, key)
 	}
 }
 
+// SetDecimalIncrement marks the current decimal field "key" value as
+// the result of incrementing the stored db value with delta.
+//
+// On the next record update the field column is set by the db to
+// `column + delta` instead of the current value so that the concurrent
+// changes of the same field are not overwritten (see [daos.Dao.SaveRecord]).
+//
+// The mark is discarded on the next [Record.Set] of the same field.
+func (m *Record) SetDecimalIncrement(key string, delta types.Decimal) {
+	field := m.Collection().Schema.GetFieldByName(key)
+	if field == nil || field.Type != schema.FieldTypeDecimal {
+		return // not a decimal field
+	}
+
+	if m.decimalIncrements == nil {
+		m.decimalIncrements = map[string]types.Decimal{}
+	}
+
+	m.decimalIncrements[key] = delta
+}
+
+// DecimalIncrements returns a copy of the pending decimal fields
+// increments (see [Record.SetDecimalIncrement]).
+func (m *Record) DecimalIncrements() map[string]types.Decimal {
+	result := make(map[string]types.Decimal, len(m.decimalIncrements))
+
+	for k, v := range m.decimalIncrements {
+		result[k] = v
+	}
+
+	return result
+}
+
 // Get returns a normalized single record model data value for "key".
 func (m *Record) Get(key string) any {
 	switch key {
@@ -389,6 +591,20 @@ func (m *Record) GetDateTime(key string) types.DateTime {
 	return d
 }
 
+// GetGeoPoint returns the data value for "key" as GeoPoint instance.
+func (m *Record) GetGeoPoint(key string) types.GeoPoint {
+	point := types.GeoPoint{}
+	_ = point.Scan(m.Get(key))
+	return point
+}
+
+// GetDecimal returns the data value for "key" as Decimal instance.
+func (m *Record) GetDecimal(key string) types.Decimal {
+	d := types.Decimal{}
+	_ = d.Scan(m.Get(key))
+	return d
+}
+
 // GetStringSlice returns the data value for "key" as a slice of unique strings.
 func (m *Record) GetStringSlice(key string) []string {
 	return list.ToUniqueStringSlice(m.Get(key))
@@ -493,7 +709,26 @@ func (m *Record) ColumnValueMap() map[string]any {
 
 	// export schema field values
 	for _, field := range m.collection.Schema.Fields() {
+		if field.Type == schema.FieldTypeComputed {
+			continue // generated by the db
+		}
+
+		if field.Type == schema.FieldTypeEncrypted {
+			result[field.Name] = m.encryptedColumnValue(field)
+			continue
+		}
+
+		if field.Type == schema.FieldTypeDecimal {
+			result[field.Name] = m.decimalColumnValue(field)
+			continue
+		}
+
 		result[field.Name] = m.getNormalizeDataValueForDB(field.Name)
+
+		// sync the raw number of the formatted sequence value
+		if col := schema.SequenceNumberColumn(field); col != "" {
+			result[col] = m.sequenceNumberColumnValue(field)
+		}
 	}
 
 	// export auth collection fields
@@ -508,13 +743,38 @@ func (m *Record) ColumnValueMap() map[string]any {
 	result[schema.FieldNameCreated] = m.getNormalizeDataValueForDB(schema.FieldNameCreated)
 	result[schema.FieldNameUpdated] = m.getNormalizeDataValueForDB(schema.FieldNameUpdated)
 
+	// export the soft delete state
+	if m.collection.HasSoftDelete() {
+		result[schema.FieldNameDeleted] = m.GetString(schema.FieldNameDeleted)
+	}
+
 	return result
 }
 
+// sequenceNumberColumnValue returns the raw number of the
+// specified formatted sequence field value (or 0 if unknown).
+func (m *Record) sequenceNumberColumnValue(field *schema.SchemaField) int {
+	value := m.GetString(field.Name)
+
+	options, _ := field.Options.(*schema.SequenceOptions)
+	if options != nil {
+		if seq, ok := schema.ParseSequence(options.Pattern, value); ok {
+			return seq
+		}
+	}
+
+	// plain number assigned before the pattern change
+	seq, _ := strconv.Atoi(value)
+
+	return seq
+}
+
 // PublicExport exports only the record fields that are safe to be public.
 //
 // For auth records, to force the export of the email field you need to set
 // `m.IgnoreEmailVisibility(true)`.
+//
+// The fields marked with [Record.Hide] are excluded from the export.
 func (m *Record) PublicExport() map[string]any {
 	result := make(map[string]any, len(m.collection.Schema.Fields())+5)
 
@@ -527,6 +787,9 @@ func (m *Record) PublicExport() map[string]any {
 
 	// export schema field values
 	for _, field := range m.collection.Schema.Fields() {
+		if m.IsHidden(field.Name) {
+			continue
+		}
 		result[field.Name] = m.Get(field.Name)
 	}
 
@@ -549,13 +812,27 @@ func (m *Record) PublicExport() map[string]any {
 		result[schema.FieldNameUpdated] = updated
 	}
 
+	// export the soft delete timestamp only for the trashed records
+	if m.IsTrashed() {
+		result[schema.FieldNameDeleted] = m.GetString(schema.FieldNameDeleted)
+	}
+
 	// add helper collection reference fields
 	result[schema.FieldNameCollectionId] = m.collection.Id
 	result[schema.FieldNameCollectionName] = m.collection.Name
 
 	// add expand (if set)
 	if m.expand != nil && m.expand.Length() > 0 {
-		result[schema.FieldNameExpand] = m.expand.GetAll()
+		expand := m.expand.GetAll()
+
+		// exclude the expanded relations of the hidden fields
+		for name := range m.hiddenFields {
+			delete(expand, name)
+		}
+
+		if len(expand) > 0 {
+			result[schema.FieldNameExpand] = expand
+		}
 	}
 
 	return result
@@ -676,6 +953,47 @@ func (m *Record) ReplaceModifers(data map[string]any) map[string]any {
 	return clone
 }
 
+// decimalColumnValue returns the decimal field value as scaled integer for db storage.
+//
+// For existing records with pending increment it returns
+// `column + delta` expression instead (see [Record.SetDecimalIncrement]).
+func (m *Record) decimalColumnValue(field *schema.SchemaField) any {
+	scale := decimalFieldScale(field)
+
+	if delta, ok := m.decimalIncrements[field.Name]; ok && !m.IsNew() {
+		if scaledDelta, err := delta.ScaledInt64(scale); err == nil {
+			param := "decimalIncrement_" + field.Name
+
+			return dbx.NewExp(
+				fmt.Sprintf("[[%s]] + {:%s}", field.Name, param),
+				dbx.Params{param: scaledDelta},
+			)
+		}
+	}
+
+	d := m.GetDecimal(field.Name)
+
+	scaled, err := d.ScaledInt64(scale)
+	if err != nil {
+		// the value precision is normally validated before save so this
+		// shouldn't happen but fallback to the exact scaled value string
+		return d.Mul(types.NewDecimal(1, -scale)).Round(0).String()
+	}
+
+	return scaled
+}
+
+// decimalFieldScale returns the scale option of the provided decimal field.
+func decimalFieldScale(field *schema.SchemaField) int32 {
+	field.InitOptions()
+
+	if options, _ := field.Options.(*schema.DecimalOptions); options != nil {
+		return int32(options.Scale)
+	}
+
+	return 0
+}
+
 // getNormalizeDataValueForDB returns the "key" data value formatted for db storage.
 func (m *Record) getNormalizeDataValueForDB(key string) any {
 	var val any
@@ -744,6 +1062,10 @@ func (m *Record) extractUnknownData(data map[string]any) map[string]any {
 		}
 	}
 
+	if m.collection.HasSoftDelete() {
+		knownFields[schema.FieldNameDeleted] = struct{}{}
+	}
+
 	result := map[string]any{}
 
 	for k, v := range data {
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// blindIndexSeparator separates the blind index hash
+// from the encrypted value in the stored field value.
+const blindIndexSeparator = "$"
+
+// CheckRecordEncryptionKey checks whether the provided
+// key is a valid record encryption key (32 chars aes key).
+func CheckRecordEncryptionKey(key string) error {
+	if key == "" {
+		return errors.New("missing encryption key")
+	}
+
+	if len(key) != 32 {
+		return errors.New("the encryption key must be exactly 32 characters")
+	}
+
+	return nil
+}
+
+// EncryptFieldValue encrypts the provided plain encrypted field value with key.
+//
+// If the field has enabled blind index, the keyed hash of the
+// plain value is prepended to the encrypted one.
+//
+// Empty values are not encrypted.
+func EncryptFieldValue(field *schema.SchemaField, plain string, key string) (string, error) {
+	if plain == "" {
+		return "", nil
+	}
+
+	encrypted, err := security.Encrypt([]byte(plain), key)
+	if err != nil {
+		return "", err
+	}
+
+	field.InitOptions()
+	if options, _ := field.Options.(*schema.EncryptedOptions); options != nil && options.BlindIndex {
+		return FieldBlindIndex(plain, key) + blindIndexSeparator + encrypted, nil
+	}
+
+	return encrypted, nil
+}
+
+// DecryptFieldValue decrypts the provided stored encrypted field value with key.
+func DecryptFieldValue(stored string, key string) (string, error) {
+	if stored == "" {
+		return "", nil
+	}
+
+	// strip the blind index hash (if any)
+	if _, encrypted, ok := strings.Cut(stored, blindIndexSeparator); ok {
+		stored = encrypted
+	}
+
+	plain, err := security.Decrypt(stored, key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// FieldBlindIndex returns the blind index hash of the provided plain
+// encrypted field value (the hash key is derived from the encryption key).
+func FieldBlindIndex(plain string, key string) string {
+	if plain == "" {
+		return ""
+	}
+
+	return security.HS256(plain, security.SHA256("blindIndex:"+key))
+}
+
+// SetEncryptionKey sets the key used to encrypt the record
+// encrypted fields values on save (see [Record.ColumnValueMap]).
+//
+// The key is usually assigned by the Dao from its [daos.Dao.EncryptionKey].
+func (m *Record) SetEncryptionKey(key string) {
+	m.encryptionKey = key
+}
+
+// DecryptFields decrypts the stored encrypted fields values of a
+// db loaded record (see [NewRecordFromNullStringMap]) with the provided
+// key and remembers the key for the subsequent record save.
+//
+// On failure the record is left unchanged and an error is returned,
+// aka. the stored encrypted values are never exposed or re-encrypted.
+func (m *Record) DecryptFields(key string) error {
+	m.encryptionKey = key
+
+	if len(m.originalEncrypted) == 0 {
+		return nil // nothing to decrypt
+	}
+
+	decrypted := make(map[string]string, len(m.originalEncrypted))
+
+	for name, stored := range m.originalEncrypted {
+		plain, err := DecryptFieldValue(stored, key)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt the %q field value: %w", name, err)
+		}
+
+		decrypted[name] = plain
+	}
+
+	for name, plain := range decrypted {
+		// update the current value only if it wasn't changed after the load
+		if _, ok := m.encryptedStored[name]; ok {
+			m.Set(name, plain)
+		}
+
+		if m.originalData != nil {
+			m.originalData[name] = plain
+		}
+
+		delete(m.originalEncrypted, name)
+	}
+
+	return nil
+}
+
+// setEncryptedStored replaces the not yet decrypted stored encrypted
+// fields values of the record with a copy of the provided ones.
+func (m *Record) setEncryptedStored(stored map[string]string) {
+	m.encryptedStored = nil
+	m.originalEncrypted = nil
+
+	if len(stored) == 0 {
+		return
+	}
+
+	m.encryptedStored = make(map[string]string, len(stored))
+	m.originalEncrypted = make(map[string]string, len(stored))
+	for k, v := range stored {
+		m.encryptedStored[k] = v
+		m.originalEncrypted[k] = v
+	}
+}
+
+// encryptedColumnValue returns the encrypted db value of the provided encrypted field.
+func (m *Record) encryptedColumnValue(field *schema.SchemaField) string {
+	// not decrypted and unchanged since load
+	if stored, ok := m.encryptedStored[field.Name]; ok {
+		return stored
+	}
+
+	encrypted, err := EncryptFieldValue(field, m.GetString(field.Name), m.encryptionKey)
+	if err != nil {
+		// never persist the plain value
+		// (the dao checks the key before save so this normally shouldn't happen)
+		return ""
+	}
+
+	return encrypted
+}
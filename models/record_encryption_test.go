@@ -0,0 +1,231 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/spf13/cast"
+)
+
+const testEncryptionKey = "abcdabcdabcdabcdabcdabcdabcdabcd"
+
+func TestCheckRecordEncryptionKey(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		key         string
+		expectError bool
+	}{
+		{"", true},
+		{"abc", true},
+		{testEncryptionKey + "a", true},
+		{testEncryptionKey, false},
+	}
+
+	for i, s := range scenarios {
+		err := models.CheckRecordEncryptionKey(s.key)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+	}
+}
+
+func TestEncryptAndDecryptFieldValue(t *testing.T) {
+	t.Parallel()
+
+	plainField := &schema.SchemaField{Name: "test", Type: schema.FieldTypeEncrypted}
+	blindField := &schema.SchemaField{Name: "test", Type: schema.FieldTypeEncrypted, Options: &schema.EncryptedOptions{BlindIndex: true}}
+
+	scenarios := []struct {
+		name         string
+		field        *schema.SchemaField
+		value        string
+		key          string
+		expectError  bool
+		expectPrefix string
+	}{
+		{"empty value", plainField, "", testEncryptionKey, false, ""},
+		{"invalid key", plainField, "test", "invalid", true, ""},
+		{"without blind index", plainField, "test", testEncryptionKey, false, ""},
+		{"with blind index", blindField, "test", testEncryptionKey, false, models.FieldBlindIndex("test", testEncryptionKey) + "$"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			encrypted, err := models.EncryptFieldValue(s.field, s.value, s.key)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if s.value != "" && encrypted == s.value {
+				t.Fatalf("Expected the value to be encrypted, got %q", encrypted)
+			}
+
+			if !strings.HasPrefix(encrypted, s.expectPrefix) {
+				t.Fatalf("Expected %q prefix, got %q", s.expectPrefix, encrypted)
+			}
+
+			decrypted, err := models.DecryptFieldValue(encrypted, s.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if decrypted != s.value {
+				t.Fatalf("Expected decrypted value %q, got %q", s.value, decrypted)
+			}
+
+			// decrypt with another key
+			if s.value != "" {
+				if _, err := models.DecryptFieldValue(encrypted, strings.Repeat("x", 32)); err == nil {
+					t.Fatal("Expected decrypt error with different key")
+				}
+			}
+		})
+	}
+}
+
+func TestFieldBlindIndex(t *testing.T) {
+	t.Parallel()
+
+	if v := models.FieldBlindIndex("", testEncryptionKey); v != "" {
+		t.Fatalf("Expected empty blind index for empty value, got %q", v)
+	}
+
+	hash1 := models.FieldBlindIndex("test", testEncryptionKey)
+	hash2 := models.FieldBlindIndex("test", testEncryptionKey)
+	hash3 := models.FieldBlindIndex("test", strings.Repeat("x", 32))
+	hash4 := models.FieldBlindIndex("test2", testEncryptionKey)
+
+	if len(hash1) != 64 {
+		t.Fatalf("Expected 64 chars hash, got %q", hash1)
+	}
+
+	if hash1 != hash2 {
+		t.Fatalf("Expected the same value hashes to match, got %q and %q", hash1, hash2)
+	}
+
+	if hash1 == hash3 || hash1 == hash4 {
+		t.Fatalf("Expected different keys or values hashes to not match, got %q, %q and %q", hash1, hash3, hash4)
+	}
+}
+
+func TestRecordEncryptedFields(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Name: "test",
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "field1",
+				Type: schema.FieldTypeEncrypted,
+			},
+			&schema.SchemaField{
+				Name:    "field2",
+				Type:    schema.FieldTypeEncrypted,
+				Options: &schema.EncryptedOptions{BlindIndex: true},
+			},
+			&schema.SchemaField{
+				Name: "field3",
+				Type: schema.FieldTypeEncrypted,
+			},
+		),
+	}
+
+	record := models.NewRecord(collection)
+	record.SetEncryptionKey(testEncryptionKey)
+	record.Set("field1", "secret1")
+	record.Set("field2", "secret2")
+
+	columns := record.ColumnValueMap()
+
+	encrypted1, _ := columns["field1"].(string)
+	encrypted2, _ := columns["field2"].(string)
+
+	if encrypted1 == "" || strings.Contains(encrypted1, "secret1") {
+		t.Fatalf("Expected field1 to be encrypted, got %q", encrypted1)
+	}
+
+	if !strings.HasPrefix(encrypted2, models.FieldBlindIndex("secret2", testEncryptionKey)+"$") {
+		t.Fatalf("Expected field2 to be encrypted with blind index, got %q", encrypted2)
+	}
+
+	if v := columns["field3"]; v != "" {
+		t.Fatalf("Expected empty field3 value, got %v", v)
+	}
+
+	row := dbx.NullStringMap{
+		"field1": {String: encrypted1, Valid: true},
+		"field2": {String: encrypted2, Valid: true},
+	}
+
+	// not decrypted yet
+	loaded := models.NewRecordFromNullStringMap(collection, row)
+	for _, field := range []string{"field1", "field2"} {
+		if v := loaded.GetString(field); v != "" {
+			t.Errorf("Expected empty %s value before decryption, got %q", field, v)
+		}
+	}
+
+	// invalid key
+	if err := loaded.DecryptFields(strings.Repeat("x", 32)); err == nil {
+		t.Fatal("Expected decrypt error with invalid key")
+	}
+	if v := loaded.GetString("field1"); v != "" {
+		t.Fatalf("Expected field1 to remain empty after failed decryption, got %q", v)
+	}
+
+	// the not decrypted stored values must be persisted as they are
+	loadedColumns := loaded.ColumnValueMap()
+	if v := loadedColumns["field1"]; v != encrypted1 {
+		t.Fatalf("Expected field1 stored value %q, got %v", encrypted1, v)
+	}
+	if v := loadedColumns["field2"]; v != encrypted2 {
+		t.Fatalf("Expected field2 stored value %q, got %v", encrypted2, v)
+	}
+
+	// valid key
+	if err := loaded.DecryptFields(testEncryptionKey); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"field1": "secret1",
+		"field2": "secret2",
+		"field3": "",
+	}
+	for field, value := range expected {
+		if v := loaded.GetString(field); v != value {
+			t.Errorf("Expected %s value %q, got %q", field, value, v)
+		}
+		if v := loaded.OriginalCopy().GetString(field); v != value {
+			t.Errorf("Expected original %s value %q, got %q", field, value, v)
+		}
+	}
+
+	// undecryptable stored value
+	invalid := models.NewRecordFromNullStringMap(collection, dbx.NullStringMap{
+		"field1": {String: encrypted1, Valid: true},
+		"field3": {String: "invalid", Valid: true},
+	})
+	if err := invalid.DecryptFields(testEncryptionKey); err == nil {
+		t.Fatal("Expected decrypt error for the invalid field3 stored value")
+	}
+
+	// explicitly changed values replace the stored ones
+	invalid.SetEncryptionKey(testEncryptionKey)
+	invalid.Set("field3", "new")
+	decrypted, err := models.DecryptFieldValue(cast.ToString(invalid.ColumnValueMap()["field3"]), testEncryptionKey)
+	if err != nil || decrypted != "new" {
+		t.Fatalf("Expected the new field3 value to be encrypted, got %q (%v)", decrypted, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,6 +39,150 @@ func TestNewRecord(t *testing.T) {
 	}
 }
 
+func TestNewRecordWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Name: "test_collection",
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "status",
+				Type:    schema.FieldTypeSelect,
+				Options: &schema.SelectOptions{MaxSelect: 1, Values: []string{"draft", "published"}, Default: []string{"draft"}},
+			},
+			&schema.SchemaField{
+				Name:    "views",
+				Type:    schema.FieldTypeNumber,
+				Options: &schema.NumberOptions{Default: types.Pointer(10.0)},
+			},
+			&schema.SchemaField{
+				Name:    "published",
+				Type:    schema.FieldTypeDate,
+				Options: &schema.DateOptions{Default: "@now"},
+			},
+			&schema.SchemaField{
+				Name:    "author",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{Default: schema.DefaultMacroRequestAuthId},
+			},
+			&schema.SchemaField{
+				Name: "title",
+				Type: schema.FieldTypeText,
+			},
+		),
+	}
+
+	before := types.NowDateTime().Time().Add(-time.Second)
+
+	m := models.NewRecord(collection)
+
+	if v := m.GetString("status"); v != "draft" {
+		t.Fatalf("Expected status %q, got %q", "draft", v)
+	}
+
+	if v := m.Get("views"); v != 10.0 {
+		t.Fatalf("Expected views %v, got %v", 10, v)
+	}
+
+	if v := m.GetDateTime("published"); v.Time().Before(before) {
+		t.Fatalf("Expected published to be set to the current date, got %v", v)
+	}
+
+	// the request macros are not resolved
+	if _, ok := m.SchemaData()["author"]; ok {
+		t.Fatal("Expected the author field to not be set")
+	}
+
+	if _, ok := m.SchemaData()["title"]; ok {
+		t.Fatal("Expected the title field to not be set")
+	}
+
+	// the records loaded from the db shouldn't have defaults
+	fromDb := models.NewRecordFromNullStringMap(collection, dbx.NullStringMap{})
+	if v := fromDb.GetString("status"); v != "" {
+		t.Fatalf("Expected empty db record status, got %q", v)
+	}
+}
+
+func TestFieldDefaultValue(t *testing.T) {
+	t.Parallel()
+
+	authRecord := models.NewRecord(&models.Collection{})
+	authRecord.Id = "auth_id"
+
+	scenarios := []struct {
+		name          string
+		field         *schema.SchemaField
+		requestInfo   *models.RequestInfo
+		expectedValue any
+		expectedOk    bool
+	}{
+		{
+			"no default",
+			&schema.SchemaField{Type: schema.FieldTypeText},
+			nil,
+			nil,
+			false,
+		},
+		{
+			"static default",
+			&schema.SchemaField{Type: schema.FieldTypeBool, Options: &schema.BoolOptions{Default: types.Pointer(false)}},
+			nil,
+			false,
+			true,
+		},
+		{
+			"unknown macro",
+			&schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Default: "@missing"}},
+			nil,
+			nil,
+			false,
+		},
+		{
+			"request macro without request info",
+			&schema.SchemaField{Type: schema.FieldTypeRelation, Options: &schema.RelationOptions{Default: schema.DefaultMacroRequestAuthId}},
+			nil,
+			nil,
+			false,
+		},
+		{
+			"request macro without auth record",
+			&schema.SchemaField{Type: schema.FieldTypeRelation, Options: &schema.RelationOptions{Default: schema.DefaultMacroRequestAuthId}},
+			&models.RequestInfo{},
+			nil,
+			false,
+		},
+		{
+			"request macro with auth record",
+			&schema.SchemaField{Type: schema.FieldTypeRelation, Options: &schema.RelationOptions{Default: schema.DefaultMacroRequestAuthId}},
+			&models.RequestInfo{AuthRecord: authRecord},
+			"auth_id",
+			true,
+		},
+		{
+			"identifier macro",
+			&schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Default: "@year"}},
+			nil,
+			time.Now().UTC().Year(),
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			value, ok := models.FieldDefaultValue(s.field, s.requestInfo)
+
+			if ok != s.expectedOk {
+				t.Fatalf("Expected ok %v, got %v", s.expectedOk, ok)
+			}
+
+			if value != s.expectedValue {
+				t.Fatalf("Expected value %v, got %v", s.expectedValue, value)
+			}
+		})
+	}
+}
+
 func TestNewRecordFromNullStringMap(t *testing.T) {
 	t.Parallel()
 
@@ -405,6 +550,168 @@ func TestRecordCleanCopy(t *testing.T) {
 	}
 }
 
+func TestRecordETag(t *testing.T) {
+	t.Parallel()
+
+	m := models.NewRecord(&models.Collection{})
+
+	if v := m.ETag(); v != "" {
+		t.Fatalf("Expected empty ETag for record without updated value, got %q", v)
+	}
+
+	m.Load(map[string]any{
+		"id":      "llvuca81nly1qls",
+		"updated": "2022-10-12 11:42:51.509Z",
+	})
+
+	expected := `"d6b0a6a1f31c4daba6c0a6d398ed3897"`
+	if v := m.ETag(); v != expected {
+		t.Fatalf("Expected ETag %q, got %q", expected, v)
+	}
+
+	m.Set("updated", "2022-10-12 11:42:51.510Z")
+	if v := m.ETag(); v == expected {
+		t.Fatalf("Expected the ETag to change after updated change, got %q", v)
+	}
+}
+
+func TestRecordMatchETag(t *testing.T) {
+	t.Parallel()
+
+	m := models.NewRecord(&models.Collection{})
+	m.Load(map[string]any{
+		"id":      "llvuca81nly1qls",
+		"updated": "2022-10-12 11:42:51.509Z",
+	})
+
+	noETag := models.NewRecord(&models.Collection{})
+
+	scenarios := []struct {
+		record   *models.Record
+		ifMatch  string
+		expected bool
+	}{
+		{m, "", false},
+		{m, `"invalid"`, false},
+		{m, `d6b0a6a1f31c4daba6c0a6d398ed3897`, false},
+		{m, `W/"d6b0a6a1f31c4daba6c0a6d398ed3897"`, false},
+		{m, `"d6b0a6a1f31c4daba6c0a6d398ed3897"`, true},
+		{m, `"invalid", "d6b0a6a1f31c4daba6c0a6d398ed3897"`, true},
+		{m, "*", true},
+		{noETag, `""`, false},
+		{noETag, "*", true},
+	}
+
+	for i, s := range scenarios {
+		result := s.record.MatchETag(s.ifMatch)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestRecordIsTrashed(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Type:    models.CollectionTypeBase,
+		Options: types.JsonMap{"softDelete": true},
+	}
+
+	m := models.NewRecord(collection)
+	if m.IsTrashed() {
+		t.Fatal("Expected the new record to not be trashed")
+	}
+
+	m.Set("deleted", "2023-01-01 00:00:00.000Z")
+	if !m.IsTrashed() {
+		t.Fatal("Expected the record to be trashed")
+	}
+
+	// the deleted timestamp should be exported only for the trashed records
+	if v, ok := m.PublicExport()["deleted"]; !ok || v != "2023-01-01 00:00:00.000Z" {
+		t.Fatalf("Expected the deleted timestamp to be exported, got %v", v)
+	}
+
+	m.Set("deleted", "")
+	if _, ok := m.PublicExport()["deleted"]; ok {
+		t.Fatal("Expected the deleted timestamp to not be exported")
+	}
+
+	// the soft delete state should be persisted
+	if v, ok := m.ColumnValueMap()["deleted"]; !ok || v != "" {
+		t.Fatalf("Expected the deleted column value, got %v", v)
+	}
+}
+
+func TestRecordSetAndGetRevisionActor(t *testing.T) {
+	t.Parallel()
+
+	m := models.NewRecord(&models.Collection{})
+	if m.RevisionActor() != nil {
+		t.Fatalf("Expected nil revision actor, got %v", m.RevisionActor())
+	}
+
+	admin := &models.Admin{}
+	m.SetRevisionActor(admin)
+	if m.RevisionActor() != admin {
+		t.Fatalf("Expected the admin revision actor, got %v", m.RevisionActor())
+	}
+}
+
+func TestRecordHide(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "field1", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "field2", Type: schema.FieldTypeText},
+		),
+	}
+
+	m := models.NewRecord(collection)
+	m.Set("field1", "a")
+	m.Set("field2", "b")
+	m.SetExpand(map[string]any{"field2": "c"})
+
+	if m.IsHidden("field1") || m.IsHidden("field2") {
+		t.Fatal("Expected the fields to not be hidden")
+	}
+
+	m.Hide("field2", "missing")
+
+	if m.IsHidden("field1") {
+		t.Fatal("Expected field1 to not be hidden")
+	}
+
+	if !m.IsHidden("field2") || !m.IsHidden("missing") {
+		t.Fatal("Expected field2 and missing to be hidden")
+	}
+
+	exported := m.PublicExport()
+
+	if v, ok := exported["field1"]; !ok || v != "a" {
+		t.Fatalf("Expected field1 to be exported, got %v", v)
+	}
+
+	if _, ok := exported["field2"]; ok {
+		t.Fatal("Expected field2 to not be exported")
+	}
+
+	if _, ok := exported[schema.FieldNameExpand]; ok {
+		t.Fatal("Expected the hidden expand to not be exported")
+	}
+
+	// the hidden field should remain accessible
+	if v := m.GetString("field2"); v != "b" {
+		t.Fatalf("Expected field2 value %q, got %q", "b", v)
+	}
+
+	if v := m.Expand()["field2"]; v != "c" {
+		t.Fatalf("Expected the expand field2 value to be kept, got %v", v)
+	}
+}
+
 func TestRecordSetAndGetExpand(t *testing.T) {
 	t.Parallel()
 
@@ -1031,6 +1338,173 @@ func TestRecordGetDateTime(t *testing.T) {
 	}
 }
 
+func TestRecordGetGeoPoint(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		value    any
+		expected types.GeoPoint
+	}{
+		{nil, types.GeoPoint{}},
+		{"", types.GeoPoint{}},
+		{"test", types.GeoPoint{}},
+		{123, types.GeoPoint{}},
+		{`{"lon":1.5,"lat":-2}`, types.GeoPoint{Lon: 1.5, Lat: -2}},
+		{map[string]any{"lon": 3, "lat": 4}, types.GeoPoint{Lon: 3, Lat: 4}},
+		{types.GeoPoint{Lon: 5, Lat: 6}, types.GeoPoint{Lon: 5, Lat: 6}},
+	}
+
+	collection := &models.Collection{}
+
+	for i, s := range scenarios {
+		m := models.NewRecord(collection)
+		m.Set("test", s.value)
+
+		result := m.GetGeoPoint("test")
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestRecordGetDecimal(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		value    any
+		expected string
+	}{
+		{nil, "0"},
+		{"", "0"},
+		{"test", "0"},
+		{123, "123"},
+		{0.1, "0.1"},
+		{"-12.50", "-12.50"},
+		{types.NewDecimal(1005, 3), "1.005"},
+	}
+
+	collection := &models.Collection{}
+
+	for i, s := range scenarios {
+		m := models.NewRecord(collection)
+		m.Set("test", s.value)
+
+		result := m.GetDecimal("test").String()
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestRecordDecimalColumnValue(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "price",
+				Type:    schema.FieldTypeDecimal,
+				Options: &schema.DecimalOptions{Precision: 10, Scale: 2},
+			},
+			&schema.SchemaField{
+				Name:    "qty",
+				Type:    schema.FieldTypeDecimal,
+				Options: &schema.DecimalOptions{Precision: 5, Scale: 0},
+			},
+		),
+	}
+
+	m := models.NewRecord(collection)
+	m.Set("price", "12.5")
+	m.Set("qty", 3)
+
+	columns := m.ColumnValueMap()
+	if v := columns["price"]; v != int64(1250) {
+		t.Fatalf("Expected price column value %v, got %v (%T)", 1250, v, v)
+	}
+	if v := columns["qty"]; v != int64(3) {
+		t.Fatalf("Expected qty column value %v, got %v (%T)", 3, v, v)
+	}
+
+	// load back the stored scaled integers
+	loaded := models.NewRecordFromNullStringMap(collection, dbx.NullStringMap{
+		"price": sql.NullString{String: "1250", Valid: true},
+		"qty":   sql.NullString{String: "3", Valid: true},
+	})
+	if v := loaded.GetDecimal("price").String(); v != "12.50" {
+		t.Fatalf("Expected loaded price %q, got %q", "12.50", v)
+	}
+	if v := loaded.GetDecimal("qty").String(); v != "3" {
+		t.Fatalf("Expected loaded qty %q, got %q", "3", v)
+	}
+
+	raw, err := json.Marshal(loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"price":"12.50"`) {
+		t.Fatalf("Expected the price to be serialized as json string, got %s", raw)
+	}
+}
+
+func TestRecordSetDecimalIncrement(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Name: "test",
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "price",
+				Type:    schema.FieldTypeDecimal,
+				Options: &schema.DecimalOptions{Precision: 10, Scale: 2},
+			},
+			&schema.SchemaField{
+				Name: "title",
+				Type: schema.FieldTypeText,
+			},
+		),
+	}
+
+	m := models.NewRecord(collection)
+	m.Set("price", "1.5")
+	m.SetDecimalIncrement("price", types.NewDecimal(5, 1))
+	m.SetDecimalIncrement("title", types.NewDecimal(5, 1)) // not a decimal field
+
+	if total := len(m.DecimalIncrements()); total != 1 {
+		t.Fatalf("Expected 1 pending increment, got %d", total)
+	}
+
+	// new records are inserted with the current value
+	if v := m.ColumnValueMap()["price"]; v != int64(150) {
+		t.Fatalf("Expected new record price column value %v, got %v (%T)", 150, v, v)
+	}
+
+	m.MarkAsNotNew()
+
+	expr, ok := m.ColumnValueMap()["price"].(dbx.Expression)
+	if !ok {
+		t.Fatalf("Expected price column value to be db expression, got %T", m.ColumnValueMap()["price"])
+	}
+
+	params := dbx.Params{}
+	rawExpr := expr.Build(nil, params)
+	if rawExpr != "[[price]] + {:decimalIncrement_price}" {
+		t.Fatalf("Unexpected increment expression %q", rawExpr)
+	}
+	if v := params["decimalIncrement_price"]; v != int64(50) {
+		t.Fatalf("Expected scaled delta %v, got %v (%T)", 50, v, v)
+	}
+
+	// explicitly assigned value discards the increment
+	m.Set("price", "3")
+	if total := len(m.DecimalIncrements()); total != 0 {
+		t.Fatalf("Expected the pending increment to be discarded, got %d", total)
+	}
+	if v := m.ColumnValueMap()["price"]; v != int64(300) {
+		t.Fatalf("Expected price column value %v, got %v (%T)", 300, v, v)
+	}
+}
+
 func TestRecordGetStringSlice(t *testing.T) {
 	t.Parallel()
 
@@ -1315,6 +1789,14 @@ func TestRecordColumnValueMap(t *testing.T) {
 					MaxSelect: types.Pointer(2),
 				},
 			},
+			&schema.SchemaField{
+				Name: "field5",
+				Type: schema.FieldTypeComputed,
+				Options: &schema.ComputedOptions{
+					Expression: "field1 || 'abc'",
+					ValueType:  schema.FieldTypeText,
+				},
+			},
 		),
 	}
 
@@ -1341,6 +1823,7 @@ func TestRecordColumnValueMap(t *testing.T) {
 		"field2":          "test.png",
 		"field3":          []string{"test1", "test2"},
 		"field4":          []string{"test11", "test12", "test11"}, // strip duplicate,
+		"field5":          "test_computed",                        // generated by the db
 		"unknown":         "test_unknown",
 		"passwordHash":    "test_passwordHash",
 		"username":        "test_username",
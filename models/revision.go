@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*Revision)(nil)
+
+const (
+	RevisionActionCreate = "create"
+	RevisionActionUpdate = "update"
+)
+
+const (
+	RevisionActorAdmin      = "admin"
+	RevisionActorAuthRecord = "authRecord"
+)
+
+// Revision defines a single saved state (aka. snapshot) of a record
+// from a collection with enabled "trackHistory" option.
+type Revision struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+	Action       string `db:"action" json:"action"`
+
+	// Data is the snapshot of the record schema fields.
+	Data types.JsonMap `db:"data" json:"data"`
+
+	// Changes lists the names of the record fields that were
+	// changed compared to the previous record state.
+	Changes types.JsonArray[string] `db:"changes" json:"changes"`
+
+	// ActorType is the type of the actor that made the change
+	// ("admin", "authRecord" or empty string if unknown).
+	ActorType         string `db:"actorType" json:"actorType"`
+	ActorCollectionId string `db:"actorCollectionId" json:"actorCollectionId"`
+	ActorId           string `db:"actorId" json:"actorId"`
+}
+
+func (m *Revision) TableName() string {
+	return "_revisions"
+}
+
+// SetActor loads the actor fields from the provided admin or auth record model.
+//
+// The actor fields are reset if the model is nil or of unsupported type.
+func (m *Revision) SetActor(actor Model) {
+	m.ActorType = ""
+	m.ActorCollectionId = ""
+	m.ActorId = ""
+
+	switch v := actor.(type) {
+	case *Admin:
+		if v != nil {
+			m.ActorType = RevisionActorAdmin
+			m.ActorId = v.Id
+		}
+	case *Record:
+		if v != nil && v.Collection() != nil {
+			m.ActorType = RevisionActorAuthRecord
+			m.ActorCollectionId = v.Collection().Id
+			m.ActorId = v.Id
+		}
+	}
+}
+
+// RevisionChange defines a single field change between two revisions.
+type RevisionChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// DiffRevisionData compares the provided revision data maps and
+// returns the changed fields (missing keys are considered nil).
+//
+// The values are compared by their JSON representation to allow
+// comparing deserialized revision data with live record values.
+func DiffRevisionData(oldData, newData map[string]any) map[string]*RevisionChange {
+	result := map[string]*RevisionChange{}
+
+	check := func(key string) {
+		if _, ok := result[key]; ok {
+			return // already checked
+		}
+
+		oldValue := oldData[key]
+		newValue := newData[key]
+
+		if !isSameJsonValue(oldValue, newValue) {
+			result[key] = &RevisionChange{Old: oldValue, New: newValue}
+		}
+	}
+
+	for k := range oldData {
+		check(k)
+	}
+
+	for k := range newData {
+		check(k)
+	}
+
+	return result
+}
+
+func isSameJsonValue(a, b any) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	// normalize the values (eg. to ignore the map keys order)
+	var normA, normB any
+	if json.Unmarshal(rawA, &normA) != nil || json.Unmarshal(rawB, &normB) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(normA, normB)
+}
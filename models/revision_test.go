@@ -0,0 +1,101 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRevisionTableName(t *testing.T) {
+	t.Parallel()
+
+	m := models.Revision{}
+	if m.TableName() != "_revisions" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestRevisionSetActor(t *testing.T) {
+	t.Parallel()
+
+	admin := &models.Admin{}
+	admin.Id = "test_admin"
+
+	authRecord := models.NewRecord(&models.Collection{BaseModel: models.BaseModel{Id: "test_collection"}})
+	authRecord.Id = "test_record"
+
+	scenarios := []struct {
+		actor                     models.Model
+		expectedType              string
+		expectedActorCollectionId string
+		expectedActorId           string
+	}{
+		{nil, "", "", ""},
+		{(*models.Admin)(nil), "", "", ""},
+		{&models.Param{}, "", "", ""},
+		{admin, models.RevisionActorAdmin, "", "test_admin"},
+		{authRecord, models.RevisionActorAuthRecord, "test_collection", "test_record"},
+	}
+
+	for i, s := range scenarios {
+		m := models.Revision{ActorType: "old", ActorCollectionId: "old", ActorId: "old"}
+		m.SetActor(s.actor)
+
+		if m.ActorType != s.expectedType {
+			t.Errorf("(%d) Expected ActorType %q, got %q", i, s.expectedType, m.ActorType)
+		}
+		if m.ActorCollectionId != s.expectedActorCollectionId {
+			t.Errorf("(%d) Expected ActorCollectionId %q, got %q", i, s.expectedActorCollectionId, m.ActorCollectionId)
+		}
+		if m.ActorId != s.expectedActorId {
+			t.Errorf("(%d) Expected ActorId %q, got %q", i, s.expectedActorId, m.ActorId)
+		}
+	}
+}
+
+func TestDiffRevisionData(t *testing.T) {
+	t.Parallel()
+
+	dt, _ := types.ParseDateTime("2023-01-01 00:00:00.000Z")
+
+	oldData := map[string]any{
+		"same_str":   "a",
+		"same_slice": []any{"a", "b"},
+		"same_json":  map[string]any{"a": 1, "b": 2},
+		"same_date":  "2023-01-01 00:00:00.000Z",
+		"changed":    "a",
+		"removed":    "a",
+	}
+
+	newData := map[string]any{
+		"same_str":   "a",
+		"same_slice": []string{"a", "b"},
+		"same_json":  types.JsonRaw(`{"b":2, "a":1}`),
+		"same_date":  dt,
+		"changed":    "b",
+		"added":      1,
+	}
+
+	diff := models.DiffRevisionData(oldData, newData)
+
+	expected := map[string][2]any{
+		"changed": {"a", "b"},
+		"removed": {"a", nil},
+		"added":   {nil, 1},
+	}
+
+	if len(diff) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(expected), len(diff), diff)
+	}
+
+	for k, v := range expected {
+		change, ok := diff[k]
+		if !ok {
+			t.Fatalf("Missing expected change %q in %v", k, diff)
+		}
+		if change.Old != v[0] || change.New != v[1] {
+			t.Fatalf("Expected %q change %v, got %v -> %v", k, v, change.Old, change.New)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// computedExpressionFunctions lists the deterministic SQLite functions
+// that are allowed to be used in a computed field expression.
+var computedExpressionFunctions = map[string]struct{}{
+	"abs":               {},
+	"round":             {},
+	"min":               {},
+	"max":               {},
+	"coalesce":          {},
+	"ifnull":            {},
+	"nullif":            {},
+	"iif":               {},
+	"lower":             {},
+	"upper":             {},
+	"trim":              {},
+	"ltrim":             {},
+	"rtrim":             {},
+	"length":            {},
+	"substr":            {},
+	"replace":           {},
+	"instr":             {},
+	"printf":            {},
+	"date":              {},
+	"time":              {},
+	"datetime":          {},
+	"julianday":         {},
+	"strftime":          {},
+	"json_extract":      {},
+	"json_array_length": {},
+}
+
+// computedExpressionDateFunctions lists the allowed date and time functions.
+//
+// They are deterministic only if their arguments don't contain any of
+// the computedExpressionDateNonDeterministic values.
+var computedExpressionDateFunctions = map[string]struct{}{
+	"date":      {},
+	"time":      {},
+	"datetime":  {},
+	"julianday": {},
+	"strftime":  {},
+}
+
+// computedExpressionDateNonDeterministic lists the date and time
+// functions arguments that make their result non-deterministic.
+var computedExpressionDateNonDeterministic = []string{"now", "localtime", "utc"}
+
+// computedExpressionDateForbidden lists the operators and functions that are
+// not allowed in the date and time functions arguments because they could
+// be used to build one of the computedExpressionDateNonDeterministic values.
+var computedExpressionDateForbidden = map[string]struct{}{
+	"||":      {},
+	"replace": {},
+	"printf":  {},
+}
+
+// computedExpressionKeywords lists the SQL keywords that are allowed
+// to be used in a computed field expression.
+var computedExpressionKeywords = map[string]struct{}{
+	"and":     {},
+	"or":      {},
+	"not":     {},
+	"is":      {},
+	"null":    {},
+	"true":    {},
+	"false":   {},
+	"case":    {},
+	"when":    {},
+	"then":    {},
+	"else":    {},
+	"end":     {},
+	"like":    {},
+	"between": {},
+	"in":      {},
+	"cast":    {},
+	"as":      {},
+}
+
+// computedExpressionCastTypes lists the allowed CAST(... AS type) types.
+var computedExpressionCastTypes = map[string]struct{}{
+	"integer": {},
+	"real":    {},
+	"text":    {},
+	"numeric": {},
+}
+
+// computedExpressionOperators lists the allowed expression operators
+// (longer operators first to ensure greedy matching).
+var computedExpressionOperators = []string{
+	"||", "<=", ">=", "!=", "<>", "==",
+	"+", "-", "*", "/", "%", "=", "<", ">", "(", ")", ",",
+}
+
+// ComputedExpression is a parsed computed field SQL expression.
+type ComputedExpression struct {
+	sql         string
+	identifiers []string
+}
+
+// SQL returns the normalized SQL expression with quoted identifiers.
+func (e *ComputedExpression) SQL() string {
+	return e.sql
+}
+
+// Identifiers returns the unique names of the fields referenced in the expression.
+func (e *ComputedExpression) Identifiers() []string {
+	return e.identifiers
+}
+
+// ParseComputedExpression parses and validates a raw computed field expression.
+//
+// The expression could contain only field identifiers, numbers, single
+// quoted strings, arithmetic, comparison and concatenation operators,
+// parenthesis and a limited set of deterministic functions and keywords
+// (eg. `price * qty` or `lower(title) || '-' || id`).
+//
+// The date and time functions can't be used with the non-deterministic
+// 'now', 'localtime' and 'utc' arguments (including values that could be
+// concatenated or replaced into one of them).
+func ParseComputedExpression(raw string) (*ComputedExpression, error) {
+	var sql strings.Builder
+	var identifiers []string
+	var lastWord string
+	var pendingFunc string
+	var calls []string // the function name (or empty string) of each open parenthesis
+
+	existing := map[string]struct{}{}
+
+	for i := 0; i < len(raw); {
+		c := raw[i]
+		word := ""
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '\'':
+			end := i + 1
+			for ; end < len(raw); end++ {
+				if raw[end] == '\'' {
+					if end+1 < len(raw) && raw[end+1] == '\'' {
+						end++ // escaped quote
+						continue
+					}
+					break
+				}
+			}
+			if end >= len(raw) {
+				return nil, errors.New("unterminated string literal")
+			}
+
+			literal := raw[i : end+1]
+
+			// prevent dbx placeholders and non-deterministic date values
+			if strings.Contains(literal, "{{") || strings.Contains(literal, "[[") || strings.Contains(literal, "{:") {
+				return nil, fmt.Errorf("string literal %s contains reserved characters", literal)
+			}
+			if strings.EqualFold(strings.TrimSpace(literal[1:len(literal)-1]), "now") {
+				return nil, errors.New("the non-deterministic 'now' value is not allowed")
+			}
+			if isInDateFunction(calls) {
+				lower := strings.ToLower(literal)
+				for _, v := range computedExpressionDateNonDeterministic {
+					if strings.Contains(lower, v) {
+						return nil, fmt.Errorf("the non-deterministic %q date and time function argument is not allowed", v)
+					}
+				}
+			}
+
+			sql.WriteString(literal)
+			i = end + 1
+		case c >= '0' && c <= '9' || (c == '.' && i+1 < len(raw) && raw[i+1] >= '0' && raw[i+1] <= '9'):
+			end := i
+			for end < len(raw) && (raw[end] >= '0' && raw[end] <= '9' || raw[end] == '.') {
+				end++
+			}
+			sql.WriteString(raw[i:end])
+			i = end
+		case isIdentifierChar(c) && (c < '0' || c > '9'):
+			end := i
+			for end < len(raw) && isIdentifierChar(raw[end]) {
+				end++
+			}
+			word = raw[i:end]
+			lower := strings.ToLower(word)
+			i = end
+
+			if _, ok := computedExpressionKeywords[lower]; ok {
+				sql.WriteString(strings.ToUpper(word))
+				break
+			}
+
+			if _, ok := computedExpressionCastTypes[lower]; ok && lastWord == "as" {
+				sql.WriteString(strings.ToUpper(word))
+				break
+			}
+
+			// check whether it is a function call
+			next := i
+			for next < len(raw) && (raw[next] == ' ' || raw[next] == '\t') {
+				next++
+			}
+			if next < len(raw) && raw[next] == '(' {
+				if _, ok := computedExpressionFunctions[lower]; !ok {
+					return nil, fmt.Errorf("function %s is not allowed", word)
+				}
+				if _, ok := computedExpressionDateForbidden[lower]; ok && isInDateFunction(calls) {
+					return nil, fmt.Errorf("function %s is not allowed in the date and time functions arguments", word)
+				}
+				pendingFunc = lower
+				sql.WriteString(lower)
+				break
+			}
+
+			if _, ok := existing[word]; !ok {
+				existing[word] = struct{}{}
+				identifiers = append(identifiers, word)
+			}
+			sql.WriteString("[[" + word + "]]")
+		default:
+			var op string
+			for _, candidate := range computedExpressionOperators {
+				if strings.HasPrefix(raw[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+
+			// disallow comments
+			if op == "-" && strings.HasPrefix(raw[i:], "--") || op == "/" && strings.HasPrefix(raw[i:], "/*") {
+				return nil, errors.New("comments are not allowed")
+			}
+
+			if _, ok := computedExpressionDateForbidden[op]; ok && isInDateFunction(calls) {
+				return nil, fmt.Errorf("operator %s is not allowed in the date and time functions arguments", op)
+			}
+
+			switch op {
+			case "(":
+				calls = append(calls, pendingFunc)
+				pendingFunc = ""
+			case ")":
+				if len(calls) == 0 {
+					return nil, errors.New("unbalanced parenthesis")
+				}
+				calls = calls[:len(calls)-1]
+			}
+
+			sql.WriteString(op)
+			i += len(op)
+		}
+
+		lastWord = strings.ToLower(word)
+
+		sql.WriteByte(' ')
+	}
+
+	if len(calls) != 0 {
+		return nil, errors.New("unbalanced parenthesis")
+	}
+
+	normalized := strings.TrimSpace(sql.String())
+	if normalized == "" {
+		return nil, errors.New("empty expression")
+	}
+
+	return &ComputedExpression{sql: normalized, identifiers: identifiers}, nil
+}
+
+// isInDateFunction checks whether any of the provided
+// open parenthesis calls is a date and time function.
+func isInDateFunction(calls []string) bool {
+	for _, name := range calls {
+		if _, ok := computedExpressionDateFunctions[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,111 @@
+package schema_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+func TestParseComputedExpression(t *testing.T) {
+	scenarios := []struct {
+		raw                 string
+		expectError         bool
+		expectSQL           string
+		expectedIdentifiers []string
+	}{
+		{"", true, "", nil},
+		{"   ", true, "", nil},
+		{"a +", false, "[[a]] +", []string{"a"}},
+		{"(a + b", true, "", nil},
+		{"a + b)", true, "", nil},
+		{"a; DROP TABLE b", true, "", nil},
+		{"a -- comment", true, "", nil},
+		{"a /* comment */", true, "", nil},
+		{"random()", true, "", nil},
+		{"load_extension('x')", true, "", nil},
+		{"(SELECT 1)", false, "( [[SELECT]] 1 )", []string{"SELECT"}},
+		{"date('now')", true, "", nil},
+		{"date(created, 'localtime')", true, "", nil},
+		{"strftime('%Y', created, 'UTC')", true, "", nil},
+		{"datetime(created, ' +1 day', 'Now')", true, "", nil},
+		{"date('no' || 'w')", true, "", nil},
+		{"date(replace('nxw', 'x', 'o'))", true, "", nil},
+		{"julianday(printf('%s', 'no'))", true, "", nil},
+		{"date(json_extract('{\"a\":\"now\"}', '$.a'))", true, "", nil},
+		{"date(round(1) || 'x')", true, "", nil},
+		{
+			// non-date usage of the date function arguments
+			"title || 'utc' || 'localtime'",
+			false,
+			"[[title]] || 'utc' || 'localtime'",
+			[]string{"title"},
+		},
+		{
+			"strftime('%Y-%m', created, '+1 month') || ' ' || replace(title, 'a', 'b')",
+			false,
+			"strftime ( '%Y-%m' , [[created]] , '+1 month' ) || ' ' || replace ( [[title]] , 'a' , 'b' )",
+			[]string{"created", "title"},
+		},
+		{"'unterminated", true, "", nil},
+		{"'{{table}}'", true, "", nil},
+		{"'[[col]]'", true, "", nil},
+		{"'{:param}'", true, "", nil},
+		{"a.b", true, "", nil},
+		{"\"a\"", true, "", nil},
+		{
+			"price*qty",
+			false,
+			"[[price]] * [[qty]]",
+			[]string{"price", "qty"},
+		},
+		{
+			"lower(title) || '-' || id || ' it''s'",
+			false,
+			"lower ( [[title]] ) || '-' || [[id]] || ' it''s'",
+			[]string{"title", "id"},
+		},
+		{
+			"ROUND(a / 2.5, 2) + .5 >= a",
+			false,
+			"round ( [[a]] / 2.5 , 2 ) + .5 >= [[a]]",
+			[]string{"a"},
+		},
+		{
+			"case when a is not null then cast(a as integer) else 0 end",
+			false,
+			"CASE WHEN [[a]] IS NOT NULL THEN CAST ( [[a]] AS INTEGER ) ELSE 0 END",
+			[]string{"a"},
+		},
+		{
+			// cast type names are fields when not used in a cast
+			"text || integer",
+			false,
+			"[[text]] || [[integer]]",
+			[]string{"text", "integer"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.raw, func(t *testing.T) {
+			result, err := schema.ParseComputedExpression(s.raw)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if result.SQL() != s.expectSQL {
+				t.Fatalf("Expected SQL\n%s\ngot\n%s", s.expectSQL, result.SQL())
+			}
+
+			if strings.Join(result.Identifiers(), ",") != strings.Join(s.expectedIdentifiers, ",") {
+				t.Fatalf("Expected identifiers %v, got %v", s.expectedIdentifiers, result.Identifiers())
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/tools/sanitizer"
+)
+
+// Editor field sanitization presets.
+const (
+	// EditorSanitizePlain strips all html tags and keeps only the text content.
+	EditorSanitizePlain string = "plain"
+
+	// EditorSanitizeBasic allows only the basic text formatting, lists and links.
+	EditorSanitizeBasic string = "basic"
+
+	// EditorSanitizeRich extends the basic preset with images, tables and layout elements.
+	EditorSanitizeRich string = "rich"
+)
+
+var (
+	sanitizeTagRegex       = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	sanitizeAttributeRegex = regexp.MustCompile(`^[a-z][a-z0-9\-_]*$`)
+	sanitizeSchemeRegex    = regexp.MustCompile(`^[a-z][a-z0-9+.\-]*$`)
+)
+
+var editorSanitizeBasicTags = []string{
+	"p", "br", "hr", "span", "strong", "b", "em", "i", "u", "s", "strike",
+	"sub", "sup", "small", "code", "pre", "blockquote",
+	"h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "li", "a",
+}
+
+var editorSanitizeBasicAttributes = []string{"href", "title", "target", "rel"}
+
+var editorSanitizeSchemes = []string{"http", "https", "mailto", "tel"}
+
+var editorSanitizePresets = map[string]sanitizer.HTMLPolicy{
+	EditorSanitizePlain: {},
+	EditorSanitizeBasic: {
+		AllowedTags:       editorSanitizeBasicTags,
+		AllowedAttributes: editorSanitizeBasicAttributes,
+		AllowedSchemes:    editorSanitizeSchemes,
+	},
+	EditorSanitizeRich: {
+		AllowedTags: append([]string{
+			"div", "figure", "figcaption", "img", "picture", "source",
+			"table", "caption", "colgroup", "col", "thead", "tbody", "tfoot", "tr", "th", "td",
+		}, editorSanitizeBasicTags...),
+		AllowedAttributes: append([]string{
+			"src", "srcset", "sizes", "alt", "width", "height", "class",
+			"colspan", "rowspan", "align",
+		}, editorSanitizeBasicAttributes...),
+		AllowedSchemes: editorSanitizeSchemes,
+	},
+}
+
+// EditorSanitizePresets returns the names of the available editor field sanitization presets.
+func EditorSanitizePresets() []string {
+	return []string{EditorSanitizePlain, EditorSanitizeBasic, EditorSanitizeRich}
+}
+
+func checkSanitizeAttribute(value any) error {
+	v, _ := value.(string)
+
+	if strings.HasPrefix(v, "on") {
+		return validation.NewError("validation_event_handler_attribute", "Event handler attributes are not allowed.")
+	}
+
+	return nil
+}
@@ -3,19 +3,29 @@ package schema
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/jsonschema"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/sanitizer"
+	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
 var schemaFieldNameRegex = regexp.MustCompile(`^\w+$`)
 
+// DefaultMacroRequestAuthId is the field default value macro
+// that resolves to the id of the current request auth record.
+const DefaultMacroRequestAuthId string = "@request.auth.id"
+
 // field value modifiers
 const (
 	FieldValueModifierAdd      string = "+"
@@ -46,6 +56,7 @@ const (
 	FieldNamePasswordHash           string = "passwordHash"
 	FieldNameLastResetSentAt        string = "lastResetSentAt"
 	FieldNameLastVerificationSentAt string = "lastVerificationSentAt"
+	FieldNameDeleted                string = "deleted"
 )
 
 // BaseModelFieldNames returns the field names that all models have (id, created, updated).
@@ -82,17 +93,23 @@ func AuthFieldNames() []string {
 
 // All valid field types
 const (
-	FieldTypeText     string = "text"
-	FieldTypeNumber   string = "number"
-	FieldTypeBool     string = "bool"
-	FieldTypeEmail    string = "email"
-	FieldTypeUrl      string = "url"
-	FieldTypeEditor   string = "editor"
-	FieldTypeDate     string = "date"
-	FieldTypeSelect   string = "select"
-	FieldTypeJson     string = "json"
-	FieldTypeFile     string = "file"
-	FieldTypeRelation string = "relation"
+	FieldTypeText      string = "text"
+	FieldTypeNumber    string = "number"
+	FieldTypeBool      string = "bool"
+	FieldTypeEmail     string = "email"
+	FieldTypeUrl       string = "url"
+	FieldTypeEditor    string = "editor"
+	FieldTypeDate      string = "date"
+	FieldTypeSelect    string = "select"
+	FieldTypeJson      string = "json"
+	FieldTypeFile      string = "file"
+	FieldTypeRelation  string = "relation"
+	FieldTypeComputed  string = "computed"
+	FieldTypeGeoPoint  string = "geoPoint"
+	FieldTypeEncrypted string = "encrypted"
+	FieldTypeSequence  string = "sequence"
+	FieldTypeAutodate  string = "autodate"
+	FieldTypeDecimal   string = "decimal"
 
 	// Deprecated: Will be removed in v0.9+
 	FieldTypeUser string = "user"
@@ -112,6 +129,12 @@ func FieldTypes() []string {
 		FieldTypeJson,
 		FieldTypeFile,
 		FieldTypeRelation,
+		FieldTypeComputed,
+		FieldTypeGeoPoint,
+		FieldTypeEncrypted,
+		FieldTypeSequence,
+		FieldTypeAutodate,
+		FieldTypeDecimal,
 	}
 }
 
@@ -140,6 +163,20 @@ type SchemaField struct {
 	// Please use the collection.Indexes field to define a unique constraint.
 	Unique bool `form:"unique" json:"unique"`
 
+	// ViewRule is an optional filter expression that restricts which
+	// clients could see the field value (empty means no restriction).
+	//
+	// The field is stripped from the API responses of the records
+	// that doesn't satisfy the rule. Admins can always see the field.
+	ViewRule string `form:"viewRule" json:"viewRule,omitempty"`
+
+	// WriteRule is an optional filter expression that restricts which
+	// clients could change the field value (empty means no restriction).
+	//
+	// The rule is checked against the existing record on update and against
+	// the new record on create. Admins can always change the field.
+	WriteRule string `form:"writeRule" json:"writeRule,omitempty"`
+
 	Options any `form:"options" json:"options"`
 }
 
@@ -148,10 +185,29 @@ func (f *SchemaField) ColDefinition() string {
 	switch f.Type {
 	case FieldTypeNumber:
 		return "NUMERIC DEFAULT 0 NOT NULL"
+	case FieldTypeDecimal:
+		// stored as scaled integer (see [DecimalOptions])
+		return "INTEGER DEFAULT 0 NOT NULL"
 	case FieldTypeBool:
 		return "BOOLEAN DEFAULT FALSE NOT NULL"
 	case FieldTypeJson:
 		return "JSON DEFAULT NULL"
+	case FieldTypeGeoPoint:
+		return `JSON DEFAULT '{"lon":0,"lat":0}' NOT NULL`
+	case FieldTypeSequence:
+		// the formatted sequence values are compared by their
+		// separate raw number column (see [SequenceNumberColumn])
+		if SequenceNumberColumn(f) != "" {
+			return "TEXT DEFAULT '' NOT NULL"
+		}
+		return SequenceNumberColDefinition
+	case FieldTypeComputed:
+		f.InitOptions()
+		options, _ := f.Options.(*ComputedOptions)
+		if options == nil {
+			options = &ComputedOptions{}
+		}
+		return options.ColDefinition()
 	default:
 		if opt, ok := f.Options.(MultiValuer); ok && opt.IsMultiple() {
 			return "JSON DEFAULT '[]' NOT NULL"
@@ -216,9 +272,46 @@ func (f SchemaField) Validate() error {
 		// currently file fields cannot be unique because a proper
 		// hash/content check could cause performance issues
 		validation.Field(&f.Unique, validation.When(f.Type == FieldTypeFile, validation.Empty)),
+		// the computed, sequence and autodate fields value is always managed by the db
+		validation.Field(&f.Required, validation.When(f.IsAutoManaged(), validation.Empty)),
 	)
 }
 
+// IsAutoManaged checks whether the field value is always managed
+// by the db (aka. computed, sequence and autodate fields are not writable).
+func (f *SchemaField) IsAutoManaged() bool {
+	return f.Type == FieldTypeComputed || f.Type == FieldTypeSequence || f.Type == FieldTypeAutodate
+}
+
+// DefaultValue returns the default value of the field options
+// that is assigned to the omitted field on record create.
+//
+// Returns false if the field options don't support or don't have a default value.
+func (f *SchemaField) DefaultValue() (any, bool) {
+	// init field options (if not already)
+	f.InitOptions()
+
+	opt, ok := f.Options.(DefaultValuer)
+	if !ok {
+		return nil, false
+	}
+
+	return opt.DefaultValue()
+}
+
+// DefaultMacro returns the field default value macro name
+// (eg. "@now") or empty string if the default is not a macro.
+func (f *SchemaField) DefaultMacro() string {
+	v, _ := f.DefaultValue()
+
+	str, ok := v.(string)
+	if !ok || !strings.HasPrefix(str, "@") {
+		return ""
+	}
+
+	return str
+}
+
 func (f *SchemaField) checkOptions(value any) error {
 	v, ok := value.(FieldOptions)
 	if !ok {
@@ -265,6 +358,18 @@ func (f *SchemaField) InitOptions() error {
 		options = &FileOptions{}
 	case FieldTypeRelation:
 		options = &RelationOptions{}
+	case FieldTypeComputed:
+		options = &ComputedOptions{}
+	case FieldTypeGeoPoint:
+		options = &GeoPointOptions{}
+	case FieldTypeEncrypted:
+		options = &EncryptedOptions{}
+	case FieldTypeSequence:
+		options = &SequenceOptions{}
+	case FieldTypeAutodate:
+		options = &AutodateOptions{}
+	case FieldTypeDecimal:
+		options = &DecimalOptions{}
 
 	// Deprecated: Will be removed in v0.9+
 	case FieldTypeUser:
@@ -289,8 +394,19 @@ func (f *SchemaField) PrepareValue(value any) any {
 	f.InitOptions()
 
 	switch f.Type {
-	case FieldTypeText, FieldTypeEmail, FieldTypeUrl, FieldTypeEditor:
+	case FieldTypeText, FieldTypeEmail, FieldTypeUrl, FieldTypeEditor, FieldTypeEncrypted:
 		return cast.ToString(value)
+	case FieldTypeSequence:
+		if SequenceNumberColumn(f) != "" {
+			return cast.ToString(value)
+		}
+
+		// plain sequence number (0 means not assigned)
+		if str, ok := value.(string); ok {
+			seq, _ := strconv.Atoi(strings.TrimSpace(str))
+			return seq
+		}
+		return cast.ToInt(value)
 	case FieldTypeJson:
 		val := value
 
@@ -327,8 +443,25 @@ func (f *SchemaField) PrepareValue(value any) any {
 		return cast.ToFloat64(value)
 	case FieldTypeBool:
 		return cast.ToBool(value)
-	case FieldTypeDate:
+	case FieldTypeDate, FieldTypeAutodate:
 		val, _ := types.ParseDateTime(value)
+		return val
+	case FieldTypeGeoPoint:
+		val := types.GeoPoint{}
+		_ = val.Scan(value)
+		return val
+	case FieldTypeDecimal:
+		val := types.Decimal{}
+		_ = val.Scan(value)
+
+		// pad with zeros to the field scale for consistent serialization
+		// (values with more fractional digits are left as they are
+		// so that they can be reported by the validator)
+		options, _ := f.Options.(*DecimalOptions)
+		if options != nil && val.Scale() < int32(options.Scale) {
+			val = val.Round(int32(options.Scale))
+		}
+
 		return val
 	case FieldTypeSelect:
 		val := list.ToUniqueStringSlice(value)
@@ -366,6 +499,20 @@ func (f *SchemaField) PrepareValue(value any) any {
 		}
 
 		return ids
+	case FieldTypeComputed:
+		options, _ := f.Options.(*ComputedOptions)
+		if options == nil {
+			return value
+		}
+
+		switch options.ValueType {
+		case FieldTypeNumber:
+			return cast.ToFloat64(value)
+		case FieldTypeBool:
+			return cast.ToBool(value)
+		default:
+			return cast.ToString(value)
+		}
 	default:
 		return value // unmodified
 	}
@@ -384,6 +531,16 @@ func (f *SchemaField) PrepareValueWithModifier(baseValue any, modifier string, m
 		case FieldValueModifierSubtract:
 			resolvedValue = cast.ToFloat64(baseValue) - cast.ToFloat64(modifierValue)
 		}
+	case FieldTypeDecimal:
+		base, _ := f.PrepareValue(baseValue).(types.Decimal)
+		mod, _ := f.PrepareValue(modifierValue).(types.Decimal)
+
+		switch modifier {
+		case FieldValueModifierAdd:
+			resolvedValue = base.Add(mod)
+		case FieldValueModifierSubtract:
+			resolvedValue = base.Sub(mod)
+		}
 	case FieldTypeSelect, FieldTypeRelation:
 		switch modifier {
 		case FieldValueModifierAdd:
@@ -417,15 +574,73 @@ type MultiValuer interface {
 	IsMultiple() bool
 }
 
+// DefaultValuer defines common interface methods that every field option
+// struct with default value support has.
+type DefaultValuer interface {
+	// DefaultValue returns the options default value
+	// and false if there is no default value set.
+	DefaultValue() (any, bool)
+}
+
 // FieldOptions defines common interface methods that every field option struct has.
 type FieldOptions interface {
 	Validate() error
 }
 
+// checkDefaultMacro returns a validation rule that checks whether the
+// default value macro (if any) is supported by the specified field type.
+func checkDefaultMacro(fieldType string) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+		if !strings.HasPrefix(v, "@") {
+			return nil // static value
+		}
+
+		var allowedTypes []string
+		switch {
+		case v == DefaultMacroRequestAuthId:
+			allowedTypes = []string{FieldTypeText, FieldTypeRelation}
+		case search.IsIdentifierMacro(v):
+			allowedTypes = []string{FieldTypeText, FieldTypeDate}
+		default:
+			return validation.NewError(
+				"validation_invalid_default_macro",
+				fmt.Sprintf("Unknown default value macro %q.", v),
+			)
+		}
+
+		if !list.ExistInSlice(fieldType, allowedTypes) {
+			return validation.NewError(
+				"validation_invalid_default_macro",
+				fmt.Sprintf("The %q macro is not supported by %q fields.", v, fieldType),
+			)
+		}
+
+		return nil
+	}
+}
+
 type TextOptions struct {
 	Min     *int   `form:"min" json:"min"`
 	Max     *int   `form:"max" json:"max"`
 	Pattern string `form:"pattern" json:"pattern"`
+
+	// DeriveFrom is the optional name of another collection field
+	// from which the empty text value is auto generated on record
+	// create as unique url slug (eg. "title" -> "hello-world-2").
+	//
+	// The generated slug is truncated to the Max constraint (if set).
+	DeriveFrom string `form:"deriveFrom" json:"deriveFrom,omitempty"`
+
+	// DeriveOnUpdate instructs to regenerate the derived value also
+	// on record update when the DeriveFrom field value changes
+	// (unless the text value itself was explicitly changed).
+	DeriveOnUpdate bool `form:"deriveOnUpdate" json:"deriveOnUpdate,omitempty"`
+
+	// Default is the optional value assigned to the omitted field on record create.
+	//
+	// It could be also a filter datetime macro (eg. "@now") or "@request.auth.id".
+	Default string `form:"default" json:"default,omitempty"`
 }
 
 func (o TextOptions) Validate() error {
@@ -438,9 +653,21 @@ func (o TextOptions) Validate() error {
 		validation.Field(&o.Min, validation.Min(0)),
 		validation.Field(&o.Max, validation.Min(minVal)),
 		validation.Field(&o.Pattern, validation.By(o.checkRegex)),
+		validation.Field(
+			&o.DeriveFrom,
+			validation.When(o.DeriveOnUpdate, validation.Required),
+			validation.Length(1, 255),
+			validation.Match(schemaFieldNameRegex),
+		),
+		validation.Field(&o.Default, validation.By(checkDefaultMacro(FieldTypeText))),
 	)
 }
 
+// DefaultValue implements the [DefaultValuer] interface.
+func (o TextOptions) DefaultValue() (any, bool) {
+	return o.Default, o.Default != ""
+}
+
 func (o *TextOptions) checkRegex(value any) error {
 	v, _ := value.(string)
 	if v == "" {
@@ -460,6 +687,9 @@ type NumberOptions struct {
 	Min       *float64 `form:"min" json:"min"`
 	Max       *float64 `form:"max" json:"max"`
 	NoDecimal bool     `form:"noDecimal" json:"noDecimal"`
+
+	// Default is the optional value assigned to the omitted field on record create.
+	Default *float64 `form:"default" json:"default,omitempty"`
 }
 
 func (o NumberOptions) Validate() error {
@@ -471,9 +701,19 @@ func (o NumberOptions) Validate() error {
 	return validation.ValidateStruct(&o,
 		validation.Field(&o.Min, validation.By(o.checkNoDecimal)),
 		validation.Field(&o.Max, maxRules...),
+		validation.Field(&o.Default, validation.By(o.checkNoDecimal)),
 	)
 }
 
+// DefaultValue implements the [DefaultValuer] interface.
+func (o NumberOptions) DefaultValue() (any, bool) {
+	if o.Default == nil {
+		return nil, false
+	}
+
+	return *o.Default, true
+}
+
 func (o *NumberOptions) checkNoDecimal(value any) error {
 	v, _ := value.(*float64)
 	if v == nil || !o.NoDecimal {
@@ -490,17 +730,67 @@ func (o *NumberOptions) checkNoDecimal(value any) error {
 // -------------------------------------------------------------------
 
 type BoolOptions struct {
+	// Default is the optional value assigned to the omitted field on record create.
+	Default *bool `form:"default" json:"default,omitempty"`
 }
 
 func (o BoolOptions) Validate() error {
 	return nil
 }
 
+// DefaultValue implements the [DefaultValuer] interface.
+func (o BoolOptions) DefaultValue() (any, bool) {
+	if o.Default == nil {
+		return nil, false
+	}
+
+	return *o.Default, true
+}
+
+// -------------------------------------------------------------------
+
+type GeoPointOptions struct {
+}
+
+func (o GeoPointOptions) Validate() error {
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// EncryptedOptions defines the options of the encrypted text field.
+//
+// The field values are encrypted at rest and, unless BlindIndex is enabled,
+// they can't be used in the filter and sort expressions.
+type EncryptedOptions struct {
+	TextOptions
+
+	// BlindIndex stores a keyed hash of the plain value together with
+	// the encrypted one to allow equality (=, !=) filter comparisons.
+	BlindIndex bool `form:"blindIndex" json:"blindIndex"`
+}
+
+func (o EncryptedOptions) Validate() error {
+	// the encrypted values cannot be checked for uniqueness
+	err := validation.ValidateStruct(&o,
+		validation.Field(&o.DeriveFrom, validation.Empty),
+		validation.Field(&o.DeriveOnUpdate, validation.Empty),
+	)
+	if err != nil {
+		return err
+	}
+
+	return o.TextOptions.Validate()
+}
+
 // -------------------------------------------------------------------
 
 type EmailOptions struct {
 	ExceptDomains []string `form:"exceptDomains" json:"exceptDomains"`
 	OnlyDomains   []string `form:"onlyDomains" json:"onlyDomains"`
+
+	// Default is the optional value assigned to the omitted field on record create.
+	Default string `form:"default" json:"default,omitempty"`
 }
 
 func (o EmailOptions) Validate() error {
@@ -513,14 +803,23 @@ func (o EmailOptions) Validate() error {
 			&o.OnlyDomains,
 			validation.When(len(o.ExceptDomains) > 0, validation.Empty).Else(validation.Each(is.Domain)),
 		),
+		validation.Field(&o.Default, validation.By(checkDefaultMacro(FieldTypeEmail))),
 	)
 }
 
+// DefaultValue implements the [DefaultValuer] interface.
+func (o EmailOptions) DefaultValue() (any, bool) {
+	return o.Default, o.Default != ""
+}
+
 // -------------------------------------------------------------------
 
 type UrlOptions struct {
 	ExceptDomains []string `form:"exceptDomains" json:"exceptDomains"`
 	OnlyDomains   []string `form:"onlyDomains" json:"onlyDomains"`
+
+	// Default is the optional value assigned to the omitted field on record create.
+	Default string `form:"default" json:"default,omitempty"`
 }
 
 func (o UrlOptions) Validate() error {
@@ -533,9 +832,15 @@ func (o UrlOptions) Validate() error {
 			&o.OnlyDomains,
 			validation.When(len(o.ExceptDomains) > 0, validation.Empty).Else(validation.Each(is.Domain)),
 		),
+		validation.Field(&o.Default, validation.By(checkDefaultMacro(FieldTypeUrl))),
 	)
 }
 
+// DefaultValue implements the [DefaultValuer] interface.
+func (o UrlOptions) DefaultValue() (any, bool) {
+	return o.Default, o.Default != ""
+}
+
 // -------------------------------------------------------------------
 
 type EditorOptions struct {
@@ -545,10 +850,90 @@ type EditorOptions struct {
 	//
 	// (see also https://www.tiny.cloud/docs/tinymce/6/url-handling/#convert_urls)
 	ConvertUrls bool `form:"convertUrls" json:"convertUrls"`
+
+	// Sanitize is the optional html sanitization preset applied
+	// to the submitted editor value before save.
+	//
+	// See [EditorSanitizePresets] for the available presets
+	// (empty string disables the sanitization).
+	Sanitize string `form:"sanitize" json:"sanitize"`
+
+	// AllowedTags, AllowedAttributes and AllowedSchemes optionally
+	// replace the corresponding lists of the Sanitize preset policy.
+	AllowedTags       []string `form:"allowedTags" json:"allowedTags"`
+	AllowedAttributes []string `form:"allowedAttributes" json:"allowedAttributes"`
+	AllowedSchemes    []string `form:"allowedSchemes" json:"allowedSchemes"`
+
+	// PlainTextField is the optional name of a text field of the same
+	// collection where to store the plain text version of the editor
+	// value (eg. for search and excerpts).
+	PlainTextField string `form:"plainTextField" json:"plainTextField"`
+
+	// Default is the optional value assigned to the omitted field on record create.
+	Default string `form:"default" json:"default,omitempty"`
 }
 
 func (o EditorOptions) Validate() error {
-	return nil
+	hasCustomLists := len(o.AllowedTags) > 0 || len(o.AllowedAttributes) > 0 || len(o.AllowedSchemes) > 0
+
+	return validation.ValidateStruct(&o,
+		validation.Field(
+			&o.Sanitize,
+			validation.When(hasCustomLists, validation.Required),
+			validation.In(list.ToInterfaceSlice(EditorSanitizePresets())...),
+		),
+		validation.Field(
+			&o.AllowedTags,
+			validation.Each(
+				validation.Match(sanitizeTagRegex),
+				validation.NotIn(list.ToInterfaceSlice(sanitizer.ForbiddenTags)...),
+			),
+		),
+		validation.Field(
+			&o.AllowedAttributes,
+			validation.Each(
+				validation.Match(sanitizeAttributeRegex),
+				validation.By(checkSanitizeAttribute),
+			),
+		),
+		validation.Field(
+			&o.AllowedSchemes,
+			validation.Each(
+				validation.Match(sanitizeSchemeRegex),
+				validation.NotIn("javascript", "vbscript"),
+			),
+		),
+		validation.Field(&o.PlainTextField, validation.Length(1, 255)),
+		validation.Field(&o.Default, validation.By(checkDefaultMacro(FieldTypeEditor))),
+	)
+}
+
+// DefaultValue implements the [DefaultValuer] interface.
+func (o EditorOptions) DefaultValue() (any, bool) {
+	return o.Default, o.Default != ""
+}
+
+// SanitizePolicy returns the html sanitization policy of the editor
+// field or nil if the sanitization is disabled.
+func (o EditorOptions) SanitizePolicy() *sanitizer.HTMLPolicy {
+	preset, ok := editorSanitizePresets[o.Sanitize]
+	if !ok {
+		return nil
+	}
+
+	policy := preset
+
+	if len(o.AllowedTags) > 0 {
+		policy.AllowedTags = o.AllowedTags
+	}
+	if len(o.AllowedAttributes) > 0 {
+		policy.AllowedAttributes = o.AllowedAttributes
+	}
+	if len(o.AllowedSchemes) > 0 {
+		policy.AllowedSchemes = o.AllowedSchemes
+	}
+
+	return &policy
 }
 
 // -------------------------------------------------------------------
@@ -556,14 +941,42 @@ func (o EditorOptions) Validate() error {
 type DateOptions struct {
 	Min types.DateTime `form:"min" json:"min"`
 	Max types.DateTime `form:"max" json:"max"`
+
+	// Default is the optional value assigned to the omitted field on record create.
+	//
+	// It could be a datetime string or a filter datetime macro (eg. "@now").
+	Default string `form:"default" json:"default,omitempty"`
 }
 
 func (o DateOptions) Validate() error {
 	return validation.ValidateStruct(&o,
 		validation.Field(&o.Max, validation.By(o.checkRange(o.Min, o.Max))),
+		validation.Field(
+			&o.Default,
+			validation.By(checkDefaultMacro(FieldTypeDate)),
+			validation.By(o.checkDefaultDate),
+		),
 	)
 }
 
+// DefaultValue implements the [DefaultValuer] interface.
+func (o DateOptions) DefaultValue() (any, bool) {
+	return o.Default, o.Default != ""
+}
+
+func (o *DateOptions) checkDefaultDate(value any) error {
+	v, _ := value.(string)
+	if v == "" || strings.HasPrefix(v, "@") {
+		return nil // nothing to check or macro
+	}
+
+	if dt, err := types.ParseDateTime(v); err != nil || dt.IsZero() {
+		return validation.NewError("validation_invalid_date", "Must be a valid datetime or datetime macro.")
+	}
+
+	return nil
+}
+
 func (o *DateOptions) checkRange(min types.DateTime, max types.DateTime) validation.RuleFunc {
 	return func(value any) error {
 		v, _ := value.(types.DateTime)
@@ -584,6 +997,9 @@ func (o *DateOptions) checkRange(min types.DateTime, max types.DateTime) validat
 type SelectOptions struct {
 	MaxSelect int      `form:"maxSelect" json:"maxSelect"`
 	Values    []string `form:"values" json:"values"`
+
+	// Default is the optional value(s) assigned to the omitted field on record create.
+	Default []string `form:"default" json:"default,omitempty"`
 }
 
 func (o SelectOptions) Validate() error {
@@ -600,9 +1016,19 @@ func (o SelectOptions) Validate() error {
 			validation.Min(1),
 			validation.Max(max),
 		),
+		validation.Field(
+			&o.Default,
+			validation.Length(0, o.MaxSelect),
+			validation.Each(validation.In(list.ToInterfaceSlice(o.Values)...)),
+		),
 	)
 }
 
+// DefaultValue implements the [DefaultValuer] interface.
+func (o SelectOptions) DefaultValue() (any, bool) {
+	return o.Default, len(o.Default) > 0
+}
+
 // IsMultiple implements MultiValuer interface and checks whether the
 // current field options support multiple values.
 func (o SelectOptions) IsMultiple() bool {
@@ -613,11 +1039,231 @@ func (o SelectOptions) IsMultiple() bool {
 
 type JsonOptions struct {
 	MaxSize int `form:"maxSize" json:"maxSize"`
+
+	// Schema is an optional JSON Schema document that the
+	// (non-empty) field value must conform to.
+	//
+	// See the [jsonschema] package for the supported keywords.
+	Schema types.JsonRaw `form:"schema" json:"schema"`
+
+	// Default is the optional value assigned to the omitted field on record create.
+	Default types.JsonRaw `form:"default" json:"default,omitempty"`
 }
 
 func (o JsonOptions) Validate() error {
 	return validation.ValidateStruct(&o,
 		validation.Field(&o.MaxSize, validation.Required, validation.Min(1)),
+		validation.Field(&o.Schema, validation.By(checkJsonSchema)),
+	)
+}
+
+// DefaultValue implements the [DefaultValuer] interface.
+func (o JsonOptions) DefaultValue() (any, bool) {
+	raw := strings.TrimSpace(o.Default.String())
+	if raw == "" || raw == "null" {
+		return nil, false
+	}
+
+	return o.Default, true
+}
+
+// CompiledSchema returns the compiled JSON Schema of the field
+// or nil if the field doesn't have a schema.
+func (o JsonOptions) CompiledSchema() (*jsonschema.Schema, error) {
+	raw := strings.TrimSpace(o.Schema.String())
+	if raw == "" || raw == "null" {
+		return nil, nil
+	}
+
+	return jsonschema.Compile([]byte(raw))
+}
+
+func checkJsonSchema(value any) error {
+	v, _ := value.(types.JsonRaw)
+
+	if _, err := (JsonOptions{Schema: v}).CompiledSchema(); err != nil {
+		return validation.NewError("validation_invalid_json_schema", "Invalid JSON Schema - "+err.Error()+".")
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+type ComputedOptions struct {
+	// Expression is the SQL expression used to compute the field value
+	// from the other fields of the same record (eg. `price * qty`).
+	//
+	// See [ParseComputedExpression] for the supported syntax.
+	Expression string `form:"expression" json:"expression"`
+
+	// ValueType is the type of the computed value ("text", "number" or "bool").
+	ValueType string `form:"valueType" json:"valueType"`
+
+	// Stored specifies whether the value is computed on write and
+	// stored in the db (STORED) or computed on read (VIRTUAL).
+	Stored bool `form:"stored" json:"stored"`
+}
+
+func (o ComputedOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(
+			&o.Expression,
+			validation.Required,
+			validation.Length(1, 1000),
+			validation.By(checkComputedExpression),
+		),
+		validation.Field(
+			&o.ValueType,
+			validation.Required,
+			validation.In(FieldTypeText, FieldTypeNumber, FieldTypeBool),
+		),
+	)
+}
+
+// ColDefinition returns the generated column definition of the computed field.
+func (o ComputedOptions) ColDefinition() string {
+	colType := "TEXT"
+	switch o.ValueType {
+	case FieldTypeNumber:
+		colType = "NUMERIC"
+	case FieldTypeBool:
+		colType = "BOOLEAN"
+	}
+
+	expr := "NULL"
+	if parsed, err := ParseComputedExpression(o.Expression); err == nil {
+		expr = parsed.SQL()
+	}
+
+	storage := "VIRTUAL"
+	if o.Stored {
+		storage = "STORED"
+	}
+
+	return fmt.Sprintf("%s GENERATED ALWAYS AS (%s) %s", colType, expr, storage)
+}
+
+func checkComputedExpression(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := ParseComputedExpression(v); err != nil {
+		return validation.NewError("validation_invalid_computed_expression", "Invalid expression - "+err.Error()+".")
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+type SequenceOptions struct {
+	// Scope is the optional name of another collection field whose
+	// value scopes the sequence (eg. a separate sequence per tenant).
+	Scope string `form:"scope" json:"scope"`
+
+	// Pattern is the optional format of the sequence value (eg. `INV-{year}-{seq:05}`).
+	//
+	// See [FormatSequence] for the supported placeholders.
+	Pattern string `form:"pattern" json:"pattern"`
+}
+
+// SequenceNumberColDefinition is the column definition
+// of the plain sequence number.
+const SequenceNumberColDefinition = "INTEGER DEFAULT 0 NOT NULL"
+
+// SequenceNumberColumn returns the name of the hidden record table
+// column that stores the raw number of a sequence field with pattern
+// (the formatted values are sorted and compared by it).
+//
+// Returns empty string if the field is not a sequence field with pattern.
+func SequenceNumberColumn(field *SchemaField) string {
+	if field.Type != FieldTypeSequence {
+		return ""
+	}
+
+	field.InitOptions()
+	options, _ := field.Options.(*SequenceOptions)
+	if options == nil || options.Pattern == "" {
+		return ""
+	}
+
+	return "_seq_" + field.Id
+}
+
+func (o SequenceOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Scope, validation.Length(1, 255)),
+		validation.Field(&o.Pattern, validation.Length(1, 255), validation.By(checkSequencePattern)),
+	)
+}
+
+func checkSequencePattern(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := FormatSequence(v, 1, time.Time{}); err != nil {
+		return validation.NewError("validation_invalid_sequence_pattern", "Invalid pattern - "+err.Error()+".")
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// MaxDecimalPrecision is the max allowed decimal field precision.
+//
+// It ensures that the stored scaled integer value could be
+// exactly compared with float numbers in the filter expressions.
+const MaxDecimalPrecision = 15
+
+type DecimalOptions struct {
+	// Precision is the max total number of digits (including the fractional ones).
+	Precision int `form:"precision" json:"precision"`
+
+	// Scale is the number of fractional digits.
+	//
+	// The field values are stored in the db as integers
+	// multiplied by 10^Scale (eg. 12.50 with scale 2 is stored as 1250).
+	Scale int `form:"scale" json:"scale"`
+
+	// Default is the optional value assigned to the omitted field on record create.
+	Default *types.Decimal `form:"default" json:"default,omitempty"`
+}
+
+func (o DecimalOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Precision, validation.Required, validation.Min(1), validation.Max(MaxDecimalPrecision)),
+		validation.Field(&o.Scale, validation.Min(0), validation.Max(o.Precision)),
+	)
+}
+
+// DefaultValue implements the [DefaultValuer] interface.
+func (o DecimalOptions) DefaultValue() (any, bool) {
+	if o.Default == nil {
+		return nil, false
+	}
+
+	return *o.Default, true
+}
+
+// -------------------------------------------------------------------
+
+type AutodateOptions struct {
+	// OnCreate sets the field value to the current datetime on record create.
+	OnCreate bool `form:"onCreate" json:"onCreate"`
+
+	// OnUpdate sets the field value to the current datetime on record update.
+	OnUpdate bool `form:"onUpdate" json:"onUpdate"`
+}
+
+func (o AutodateOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.OnCreate, validation.When(!o.OnUpdate, validation.Required.Error("At least one of onCreate or onUpdate must be enabled."))),
 	)
 }
 
@@ -677,6 +1323,12 @@ type RelationOptions struct {
 	// Deprecated: This field is no-op and will be removed in future versions.
 	// Instead use the individula SchemaField.Presentable option for each field in the relation collection.
 	DisplayFields []string `form:"displayFields" json:"displayFields"`
+
+	// Default is the optional related record id assigned
+	// to the omitted field on record create.
+	//
+	// It could be also the "@request.auth.id" macro.
+	Default string `form:"default" json:"default,omitempty"`
 }
 
 func (o RelationOptions) Validate() error {
@@ -689,9 +1341,15 @@ func (o RelationOptions) Validate() error {
 		validation.Field(&o.CollectionId, validation.Required),
 		validation.Field(&o.MinSelect, validation.Min(0)),
 		validation.Field(&o.MaxSelect, validation.NilOrNotEmpty, validation.Min(minVal)),
+		validation.Field(&o.Default, validation.By(checkDefaultMacro(FieldTypeRelation))),
 	)
 }
 
+// DefaultValue implements the [DefaultValuer] interface.
+func (o RelationOptions) DefaultValue() (any, bool) {
+	return o.Default, o.Default != ""
+}
+
 // IsMultiple implements MultiValuer interface and checks whether the
 // current field options support multiple values.
 func (o RelationOptions) IsMultiple() bool {
@@ -40,7 +40,7 @@ func TestAuthFieldNames(t *testing.T) {
 
 func TestFieldTypes(t *testing.T) {
 	result := schema.FieldTypes()
-	expected := 11
+	expected := 17
 
 	if len(result) != expected {
 		t.Fatalf("Expected %d types, got %d (%v)", expected, len(result), result)
@@ -117,6 +117,42 @@ func TestSchemaFieldColDefinition(t *testing.T) {
 			schema.SchemaField{Type: schema.FieldTypeRelation, Name: "test_multiple", Options: &schema.RelationOptions{MaxSelect: nil}},
 			"JSON DEFAULT '[]' NOT NULL",
 		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeComputed, Name: "test"},
+			"TEXT GENERATED ALWAYS AS (NULL) VIRTUAL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeComputed, Name: "test", Options: &schema.ComputedOptions{Expression: "a * b", ValueType: schema.FieldTypeNumber}},
+			"NUMERIC GENERATED ALWAYS AS ([[a]] * [[b]]) VIRTUAL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeComputed, Name: "test", Options: &schema.ComputedOptions{Expression: "a > 1", ValueType: schema.FieldTypeBool, Stored: true}},
+			"BOOLEAN GENERATED ALWAYS AS ([[a]] > 1) STORED",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeGeoPoint, Name: "test"},
+			`JSON DEFAULT '{"lon":0,"lat":0}' NOT NULL`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeEncrypted, Name: "test"},
+			"TEXT DEFAULT '' NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeSequence, Name: "test"},
+			"INTEGER DEFAULT 0 NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeSequence, Name: "test", Options: &schema.SequenceOptions{Pattern: "INV-{seq}"}},
+			"TEXT DEFAULT '' NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeAutodate, Name: "test"},
+			"TEXT DEFAULT '' NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeDecimal, Name: "test"},
+			"INTEGER DEFAULT 0 NOT NULL",
+		},
 	}
 
 	for i, s := range scenarios {
@@ -184,6 +220,25 @@ func TestSchemaFieldMarshalJSON(t *testing.T) {
 			},
 			`{"system":true,"id":"","name":"test","type":"text","required":true,"presentable":false,"unique":false,"options":{"min":null,"max":null,"pattern":"test"}}`,
 		},
+		// with view and write rules
+		{
+			schema.SchemaField{
+				Name:      "test",
+				Type:      schema.FieldTypeBool,
+				ViewRule:  "id = @request.auth.id",
+				WriteRule: "@request.auth.id != ''",
+			},
+			`{"system":false,"id":"","name":"test","type":"bool","required":false,"presentable":false,"unique":false,"viewRule":"id = @request.auth.id","writeRule":"@request.auth.id != ''","options":{}}`,
+		},
+		// with default value
+		{
+			schema.SchemaField{
+				Name:    "test",
+				Type:    schema.FieldTypeBool,
+				Options: &schema.BoolOptions{Default: types.Pointer(false)},
+			},
+			`{"system":false,"id":"","name":"test","type":"bool","required":false,"presentable":false,"unique":false,"options":{"default":false}}`,
+		},
 	}
 
 	for i, s := range scenarios {
@@ -234,6 +289,17 @@ func TestSchemaFieldUnmarshalJSON(t *testing.T) {
 			false,
 			`{"system":false,"id":"","name":"","type":"text","required":false,"presentable":false,"unique":false,"options":{"min":null,"max":null,"pattern":"test"}}`,
 		},
+		{
+			[]byte(`{"type":"number","options":{"default":5}}`),
+			false,
+			`{"system":false,"id":"","name":"","type":"number","required":false,"presentable":false,"unique":false,"options":{"min":null,"max":null,"noDecimal":false,"default":5}}`,
+		},
+		{
+			// wrong typed default value
+			[]byte(`{"type":"number","options":{"default":"abc"}}`),
+			true,
+			`{"system":false,"id":"","name":"","type":"number","required":false,"presentable":false,"unique":false,"options":{"default":"abc"}}`,
+		},
 	}
 
 	for i, s := range scenarios {
@@ -427,6 +493,68 @@ func TestSchemaFieldValidate(t *testing.T) {
 			},
 			[]string{"options"},
 		},
+		{
+			"unknown default macro",
+			schema.SchemaField{
+				Type:    schema.FieldTypeText,
+				Id:      "1234567890",
+				Name:    "test",
+				Options: &schema.TextOptions{Default: "@missing"},
+			},
+			[]string{"options"},
+		},
+		{
+			"default macro unsupported by the field type",
+			schema.SchemaField{
+				Type:    schema.FieldTypeDate,
+				Id:      "1234567890",
+				Name:    "test",
+				Options: &schema.DateOptions{Default: schema.DefaultMacroRequestAuthId},
+			},
+			[]string{"options"},
+		},
+		{
+			"valid default macro",
+			schema.SchemaField{
+				Type:    schema.FieldTypeDate,
+				Id:      "1234567890",
+				Name:    "test",
+				Options: &schema.DateOptions{Default: "@todayStart"},
+			},
+			[]string{},
+		},
+		{
+			"required computed field",
+			schema.SchemaField{
+				Type:     schema.FieldTypeComputed,
+				Id:       "1234567890",
+				Name:     "test",
+				Required: true,
+				Options:  &schema.ComputedOptions{Expression: "1", ValueType: schema.FieldTypeNumber},
+			},
+			[]string{"required"},
+		},
+		{
+			"required sequence field",
+			schema.SchemaField{
+				Type:     schema.FieldTypeSequence,
+				Id:       "1234567890",
+				Name:     "test",
+				Required: true,
+			},
+			[]string{"required"},
+		},
+		{
+			"required autodate field",
+			schema.SchemaField{
+				Type:     schema.FieldTypeAutodate,
+				Id:       "1234567890",
+				Name:     "test",
+				Required: true,
+				Options:  &schema.AutodateOptions{OnCreate: true},
+			},
+			[]string{"required"},
+		},
 		{
 			"trigger options validator (valid option field value)",
 			schema.SchemaField{
@@ -461,6 +589,117 @@ func TestSchemaFieldValidate(t *testing.T) {
 	}
 }
 
+func TestSchemaFieldDefaultValue(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		field         schema.SchemaField
+		expectedValue any
+		expectedOk    bool
+	}{
+		{
+			"options without default support",
+			schema.SchemaField{Type: schema.FieldTypeFile},
+			nil,
+			false,
+		},
+		{
+			"unset default",
+			schema.SchemaField{Type: schema.FieldTypeNumber},
+			nil,
+			false,
+		},
+		{
+			"zero number default",
+			schema.SchemaField{Type: schema.FieldTypeNumber, Options: &schema.NumberOptions{Default: types.Pointer(0.0)}},
+			0.0,
+			true,
+		},
+		{
+			"false bool default",
+			schema.SchemaField{Type: schema.FieldTypeBool, Options: &schema.BoolOptions{Default: types.Pointer(false)}},
+			false,
+			true,
+		},
+		{
+			"text default",
+			schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Default: "draft"}},
+			"draft",
+			true,
+		},
+		{
+			"encrypted default",
+			schema.SchemaField{Type: schema.FieldTypeEncrypted, Options: &schema.EncryptedOptions{TextOptions: schema.TextOptions{Default: "draft"}}},
+			"draft",
+			true,
+		},
+		{
+			"null json default",
+			schema.SchemaField{Type: schema.FieldTypeJson, Options: &schema.JsonOptions{Default: types.JsonRaw("null")}},
+			nil,
+			false,
+		},
+		{
+			"json default",
+			schema.SchemaField{Type: schema.FieldTypeJson, Options: &schema.JsonOptions{Default: types.JsonRaw(`{"a":1}`)}},
+			types.JsonRaw(`{"a":1}`),
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		value, ok := s.field.DefaultValue()
+
+		if ok != s.expectedOk {
+			t.Errorf("[%s] Expected ok %v, got %v", s.name, s.expectedOk, ok)
+		}
+
+		if fmt.Sprint(value) != fmt.Sprint(s.expectedValue) {
+			t.Errorf("[%s] Expected value %v, got %v", s.name, s.expectedValue, value)
+		}
+	}
+}
+
+func TestSchemaFieldDefaultMacro(t *testing.T) {
+	scenarios := []struct {
+		field    schema.SchemaField
+		expected string
+	}{
+		{schema.SchemaField{Type: schema.FieldTypeText}, ""},
+		{schema.SchemaField{Type: schema.FieldTypeNumber, Options: &schema.NumberOptions{Default: types.Pointer(123.0)}}, ""},
+		{schema.SchemaField{Type: schema.FieldTypeText, Options: &schema.TextOptions{Default: "test"}}, ""},
+		{schema.SchemaField{Type: schema.FieldTypeDate, Options: &schema.DateOptions{Default: "@now"}}, "@now"},
+		{schema.SchemaField{Type: schema.FieldTypeRelation, Options: &schema.RelationOptions{Default: "@request.auth.id"}}, "@request.auth.id"},
+	}
+
+	for i, s := range scenarios {
+		if result := s.field.DefaultMacro(); result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestSchemaFieldIsAutoManaged(t *testing.T) {
+	scenarios := []struct {
+		fieldType string
+		expected  bool
+	}{
+		{schema.FieldTypeText, false},
+		{schema.FieldTypeNumber, false},
+		{schema.FieldTypeDate, false},
+		{schema.FieldTypeComputed, true},
+		{schema.FieldTypeSequence, true},
+		{schema.FieldTypeAutodate, true},
+	}
+
+	for _, s := range scenarios {
+		field := schema.SchemaField{Type: s.fieldType}
+
+		if result := field.IsAutoManaged(); result != s.expected {
+			t.Errorf("(%s) Expected %v, got %v", s.fieldType, s.expected, result)
+		}
+	}
+}
+
 func TestSchemaFieldInitOptions(t *testing.T) {
 	scenarios := []struct {
 		field       schema.SchemaField
@@ -505,7 +744,7 @@ func TestSchemaFieldInitOptions(t *testing.T) {
 		{
 			schema.SchemaField{Type: schema.FieldTypeEditor},
 			false,
-			`{"system":false,"id":"","name":"","type":"editor","required":false,"presentable":false,"unique":false,"options":{"convertUrls":false}}`,
+			`{"system":false,"id":"","name":"","type":"editor","required":false,"presentable":false,"unique":false,"options":{"convertUrls":false,"sanitize":"","allowedTags":null,"allowedAttributes":null,"allowedSchemes":null,"plainTextField":""}}`,
 		},
 		{
 			schema.SchemaField{Type: schema.FieldTypeDate},
@@ -520,7 +759,7 @@ func TestSchemaFieldInitOptions(t *testing.T) {
 		{
 			schema.SchemaField{Type: schema.FieldTypeJson},
 			false,
-			`{"system":false,"id":"","name":"","type":"json","required":false,"presentable":false,"unique":false,"options":{"maxSize":0}}`,
+			`{"system":false,"id":"","name":"","type":"json","required":false,"presentable":false,"unique":false,"options":{"maxSize":0,"schema":null}}`,
 		},
 		{
 			schema.SchemaField{Type: schema.FieldTypeFile},
@@ -537,6 +776,36 @@ func TestSchemaFieldInitOptions(t *testing.T) {
 			false,
 			`{"system":false,"id":"","name":"","type":"user","required":false,"presentable":false,"unique":false,"options":{"maxSelect":0,"cascadeDelete":false}}`,
 		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeComputed},
+			false,
+			`{"system":false,"id":"","name":"","type":"computed","required":false,"presentable":false,"unique":false,"options":{"expression":"","valueType":"","stored":false}}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeGeoPoint},
+			false,
+			`{"system":false,"id":"","name":"","type":"geoPoint","required":false,"presentable":false,"unique":false,"options":{}}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeEncrypted},
+			false,
+			`{"system":false,"id":"","name":"","type":"encrypted","required":false,"presentable":false,"unique":false,"options":{"min":null,"max":null,"pattern":"","blindIndex":false}}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeSequence},
+			false,
+			`{"system":false,"id":"","name":"","type":"sequence","required":false,"presentable":false,"unique":false,"options":{"scope":"","pattern":""}}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeAutodate},
+			false,
+			`{"system":false,"id":"","name":"","type":"autodate","required":false,"presentable":false,"unique":false,"options":{"onCreate":false,"onUpdate":false}}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeDecimal},
+			false,
+			`{"system":false,"id":"","name":"","type":"decimal","required":false,"presentable":false,"unique":false,"options":{"precision":0,"scale":0}}`,
+		},
 		{
 			schema.SchemaField{
 				Type:    schema.FieldTypeText,
@@ -909,6 +1178,49 @@ func TestSchemaFieldPrepareValue(t *testing.T) {
 			[]string{"1ba88b4f-e9da-42f0-9764-9a55c953e724", "2ba88b4f-e9da-42f0-9764-9a55c953e724", "1ba88b4f-e9da-42f0-9764-9a55c953e724"},
 			`["1ba88b4f-e9da-42f0-9764-9a55c953e724","2ba88b4f-e9da-42f0-9764-9a55c953e724"]`,
 		},
+
+		// computed
+		{schema.SchemaField{Type: schema.FieldTypeComputed}, 123, `"123"`},
+		{schema.SchemaField{Type: schema.FieldTypeComputed, Options: &schema.ComputedOptions{ValueType: schema.FieldTypeText}}, 123, `"123"`},
+		{schema.SchemaField{Type: schema.FieldTypeComputed, Options: &schema.ComputedOptions{ValueType: schema.FieldTypeNumber}}, "12.5", `12.5`},
+		{schema.SchemaField{Type: schema.FieldTypeComputed, Options: &schema.ComputedOptions{ValueType: schema.FieldTypeBool}}, "1", `true`},
+		{schema.SchemaField{Type: schema.FieldTypeComputed, Options: &schema.ComputedOptions{ValueType: schema.FieldTypeBool}}, "0", `false`},
+
+		// geoPoint
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, nil, `{"lon":0,"lat":0}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, "invalid", `{"lon":0,"lat":0}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, `{"lon":1.5,"lat":-2}`, `{"lon":1.5,"lat":-2}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, map[string]any{"lon": 3, "lat": 4}, `{"lon":3,"lat":4}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, types.GeoPoint{Lon: 5, Lat: 6}, `{"lon":5,"lat":6}`},
+
+		// encrypted
+		{schema.SchemaField{Type: schema.FieldTypeEncrypted}, nil, `""`},
+		{schema.SchemaField{Type: schema.FieldTypeEncrypted}, 123, `"123"`},
+		{schema.SchemaField{Type: schema.FieldTypeEncrypted}, "test", `"test"`},
+
+		// sequence
+		{schema.SchemaField{Type: schema.FieldTypeSequence}, nil, `0`},
+		{schema.SchemaField{Type: schema.FieldTypeSequence}, 123, `123`},
+		{schema.SchemaField{Type: schema.FieldTypeSequence}, "123", `123`},
+		{schema.SchemaField{Type: schema.FieldTypeSequence}, "012", `12`},
+		{schema.SchemaField{Type: schema.FieldTypeSequence}, "invalid", `0`},
+		{schema.SchemaField{Type: schema.FieldTypeSequence, Options: &schema.SequenceOptions{Pattern: "INV-{seq}"}}, nil, `""`},
+		{schema.SchemaField{Type: schema.FieldTypeSequence, Options: &schema.SequenceOptions{Pattern: "INV-{seq}"}}, 123, `"123"`},
+		{schema.SchemaField{Type: schema.FieldTypeSequence, Options: &schema.SequenceOptions{Pattern: "INV-{seq}"}}, "INV-2024-00001", `"INV-2024-00001"`},
+
+		// autodate
+		{schema.SchemaField{Type: schema.FieldTypeAutodate}, nil, `""`},
+		{schema.SchemaField{Type: schema.FieldTypeAutodate}, "invalid", `""`},
+		{schema.SchemaField{Type: schema.FieldTypeAutodate}, "2022-01-01 10:00:00.123", `"2022-01-01 10:00:00.123Z"`},
+
+		// decimal
+		{schema.SchemaField{Type: schema.FieldTypeDecimal}, nil, `"0"`},
+		{schema.SchemaField{Type: schema.FieldTypeDecimal}, "invalid", `"0"`},
+		{schema.SchemaField{Type: schema.FieldTypeDecimal}, 12, `"12"`},
+		{schema.SchemaField{Type: schema.FieldTypeDecimal}, 0.1, `"0.1"`},
+		{schema.SchemaField{Type: schema.FieldTypeDecimal}, "-12.50", `"-12.50"`},
+		{schema.SchemaField{Type: schema.FieldTypeDecimal, Options: &schema.DecimalOptions{Precision: 5, Scale: 1}}, "1.25", `"1.25"`},
+		{schema.SchemaField{Type: schema.FieldTypeDecimal, Options: &schema.DecimalOptions{Precision: 5, Scale: 2}}, 1.5, `"1.50"`},
 	}
 
 	for i, s := range scenarios {
@@ -1003,6 +1315,40 @@ func TestSchemaFieldPrepareValueWithModifier(t *testing.T) {
 			`4`,
 		},
 
+		// decimal
+		{
+			"decimal with '+' modifier",
+			schema.SchemaField{Type: schema.FieldTypeDecimal},
+			"0.1",
+			"+",
+			"0.2",
+			`"0.3"`,
+		},
+		{
+			"decimal with '-' modifier",
+			schema.SchemaField{Type: schema.FieldTypeDecimal},
+			"10.50",
+			"-",
+			0.25,
+			`"10.25"`,
+		},
+		{
+			"decimal with unknown modifier",
+			schema.SchemaField{Type: schema.FieldTypeDecimal},
+			"1.5",
+			"?",
+			"1",
+			`"1.5"`,
+		},
+		{
+			"decimal cast check",
+			schema.SchemaField{Type: schema.FieldTypeDecimal},
+			"test",
+			"+",
+			"4",
+			`"4"`,
+		},
+
 		// bool
 		{
 			"bool with '+' modifier",
@@ -1744,6 +2090,41 @@ func TestTextOptionsValidate(t *testing.T) {
 			schema.TextOptions{Pattern: `^\#?\w+$`},
 			[]string{},
 		},
+		{
+			"deriveOnUpdate without deriveFrom",
+			schema.TextOptions{DeriveOnUpdate: true},
+			[]string{"deriveFrom"},
+		},
+		{
+			"invalid deriveFrom",
+			schema.TextOptions{DeriveFrom: "a b"},
+			[]string{"deriveFrom"},
+		},
+		{
+			"deriveFrom - success",
+			schema.TextOptions{DeriveFrom: "title", DeriveOnUpdate: true},
+			[]string{},
+		},
+		{
+			"default - unknown macro",
+			schema.TextOptions{Default: "@missing"},
+			[]string{"default"},
+		},
+		{
+			"default - static value",
+			schema.TextOptions{Default: "draft"},
+			[]string{},
+		},
+		{
+			"default - datetime macro",
+			schema.TextOptions{Default: "@now"},
+			[]string{},
+		},
+		{
+			"default - request macro",
+			schema.TextOptions{Default: schema.DefaultMacroRequestAuthId},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
@@ -1803,6 +2184,22 @@ func TestNumberOptionsValidate(t *testing.T) {
 			},
 			[]string{},
 		},
+		{
+			"NoDecimal default failure",
+			schema.NumberOptions{
+				Default:   &decimal1,
+				NoDecimal: true,
+			},
+			[]string{"default"},
+		},
+		{
+			"NoDecimal default success",
+			schema.NumberOptions{
+				Default:   &int1,
+				NoDecimal: true,
+			},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
@@ -1862,6 +2259,10 @@ func TestEmailOptionsValidate(t *testing.T) {
 				OnlyDomains:   []string{"test2.com"},
 			},
 			[]string{"exceptDomains", "onlyDomains"},
+		}, {
+			"default with macro",
+			schema.EmailOptions{Default: "@now"},
+			[]string{"default"},
 		},
 	}
 
@@ -1923,11 +2324,108 @@ func TestEditorOptionsValidate(t *testing.T) {
 			schema.EditorOptions{},
 			[]string{},
 		},
+		{
+			"unknown sanitize preset",
+			schema.EditorOptions{Sanitize: "missing"},
+			[]string{"sanitize"},
+		},
+		{
+			"custom lists without sanitize preset",
+			schema.EditorOptions{AllowedTags: []string{"p"}},
+			[]string{"sanitize"},
+		},
+		{
+			"invalid custom lists",
+			schema.EditorOptions{
+				Sanitize:          schema.EditorSanitizeBasic,
+				AllowedTags:       []string{"p", "script"},
+				AllowedAttributes: []string{"href", "onclick"},
+				AllowedSchemes:    []string{"https", "javascript"},
+			},
+			[]string{"allowedTags", "allowedAttributes", "allowedSchemes"},
+		},
+		{
+			"valid options",
+			schema.EditorOptions{
+				Sanitize:          schema.EditorSanitizeRich,
+				AllowedTags:       []string{"p", "h1"},
+				AllowedAttributes: []string{"href", "data-id"},
+				AllowedSchemes:    []string{"https", "ftp"},
+				PlainTextField:    "test",
+			},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
 }
 
+func TestEditorOptionsSanitizePolicy(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		options  schema.EditorOptions
+		html     string
+		expected string
+	}{
+		{
+			"disabled",
+			schema.EditorOptions{},
+			`<p onclick="alert(1)">a<script>b</script></p>`,
+			"",
+		},
+		{
+			"plain",
+			schema.EditorOptions{Sanitize: schema.EditorSanitizePlain},
+			`<p>a <strong>b</strong></p><script>c</script>`,
+			"a b",
+		},
+		{
+			"basic",
+			schema.EditorOptions{Sanitize: schema.EditorSanitizeBasic},
+			`<p class="x">a <a href="javascript:alert(1)">b</a><img src="/c.png"/></p>`,
+			`<p>a <a>b</a></p>`,
+		},
+		{
+			"rich",
+			schema.EditorOptions{Sanitize: schema.EditorSanitizeRich},
+			`<p class="x">a <a href="https://example.com">b</a><img src="/c.png"/></p>`,
+			`<p class="x">a <a href="https://example.com">b</a><img src="/c.png"/></p>`,
+		},
+		{
+			"preset with custom lists",
+			schema.EditorOptions{
+				Sanitize:          schema.EditorSanitizeBasic,
+				AllowedTags:       []string{"p", "a"},
+				AllowedAttributes: []string{"href", "data-id"},
+			},
+			`<p data-id="1">a <strong>b</strong> <a href="https://example.com" title="c">d</a></p>`,
+			`<p data-id="1">a b <a href="https://example.com">d</a></p>`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			policy := s.options.SanitizePolicy()
+
+			if s.options.Sanitize == "" {
+				if policy != nil {
+					t.Fatalf("Expected nil policy, got %v", policy)
+				}
+				return
+			}
+
+			result, err := policy.Sanitize(s.html)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, result)
+			}
+		})
+	}
+}
+
 func TestDateOptionsValidate(t *testing.T) {
 	date1 := types.NowDateTime()
 	date2, _ := types.ParseDateTime(date1.Time().AddDate(1, 0, 0))
@@ -1992,6 +2490,26 @@ func TestDateOptionsValidate(t *testing.T) {
 			},
 			[]string{},
 		},
+		{
+			"invalid default date",
+			schema.DateOptions{Default: "abc"},
+			[]string{"default"},
+		},
+		{
+			"unsupported default macro",
+			schema.DateOptions{Default: schema.DefaultMacroRequestAuthId},
+			[]string{"default"},
+		},
+		{
+			"valid default date",
+			schema.DateOptions{Default: "2023-01-01 00:00:00.000Z"},
+			[]string{},
+		},
+		{
+			"valid default macro",
+			schema.DateOptions{Default: "@yearStart"},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
@@ -2028,6 +2546,33 @@ func TestSelectOptionsValidate(t *testing.T) {
 			},
 			[]string{},
 		},
+		{
+			"default with more than MaxSelect values",
+			schema.SelectOptions{
+				Values:    []string{"test1", "test2"},
+				MaxSelect: 1,
+				Default:   []string{"test1", "test2"},
+			},
+			[]string{"default"},
+		},
+		{
+			"default with unknown value",
+			schema.SelectOptions{
+				Values:    []string{"test1", "test2"},
+				MaxSelect: 2,
+				Default:   []string{"test3"},
+			},
+			[]string{"default"},
+		},
+		{
+			"valid default",
+			schema.SelectOptions{
+				Values:    []string{"test1", "test2"},
+				MaxSelect: 2,
+				Default:   []string{"test1", "test2"},
+			},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
@@ -2072,6 +2617,157 @@ func TestJsonOptionsValidate(t *testing.T) {
 			schema.JsonOptions{MaxSize: 1},
 			[]string{},
 		},
+		{
+			"null schema",
+			schema.JsonOptions{MaxSize: 1, Schema: types.JsonRaw("null")},
+			[]string{},
+		},
+		{
+			"invalid schema",
+			schema.JsonOptions{MaxSize: 1, Schema: types.JsonRaw(`{"type":"missing"}`)},
+			[]string{"schema"},
+		},
+		{
+			"valid schema",
+			schema.JsonOptions{MaxSize: 1, Schema: types.JsonRaw(`{"type":"object","required":["a"]}`)},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestComputedOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.ComputedOptions{},
+			[]string{"expression", "valueType"},
+		},
+		{
+			"invalid expression",
+			schema.ComputedOptions{Expression: "random()", ValueType: schema.FieldTypeNumber},
+			[]string{"expression"},
+		},
+		{
+			"invalid value type",
+			schema.ComputedOptions{Expression: "a + b", ValueType: schema.FieldTypeJson},
+			[]string{"valueType"},
+		},
+		{
+			"valid options",
+			schema.ComputedOptions{Expression: "a + b", ValueType: schema.FieldTypeNumber, Stored: true},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestEncryptedOptionsValidate(t *testing.T) {
+	minus := -1
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.EncryptedOptions{},
+			[]string{},
+		},
+		{
+			"invalid text options",
+			schema.EncryptedOptions{TextOptions: schema.TextOptions{Min: &minus, Pattern: "(test"}},
+			[]string{"min", "pattern"},
+		},
+		{
+			"derived value",
+			schema.EncryptedOptions{TextOptions: schema.TextOptions{DeriveFrom: "title", DeriveOnUpdate: true}},
+			[]string{"deriveFrom", "deriveOnUpdate"},
+		},
+		{
+			"valid options",
+			schema.EncryptedOptions{TextOptions: schema.TextOptions{Pattern: `^\w+$`}, BlindIndex: true},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestSequenceOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.SequenceOptions{},
+			[]string{},
+		},
+		{
+			"invalid pattern",
+			schema.SequenceOptions{Pattern: "INV-{year}"},
+			[]string{"pattern"},
+		},
+		{
+			"valid options",
+			schema.SequenceOptions{Scope: "tenant", Pattern: "INV-{year}-{seq:05}"},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestAutodateOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.AutodateOptions{},
+			[]string{"onCreate"},
+		},
+		{
+			"only onCreate",
+			schema.AutodateOptions{OnCreate: true},
+			[]string{},
+		},
+		{
+			"only onUpdate",
+			schema.AutodateOptions{OnUpdate: true},
+			[]string{},
+		},
+		{
+			"onCreate and onUpdate",
+			schema.AutodateOptions{OnCreate: true, OnUpdate: true},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestDecimalOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.DecimalOptions{},
+			[]string{"precision"},
+		},
+		{
+			"precision > max",
+			schema.DecimalOptions{Precision: schema.MaxDecimalPrecision + 1},
+			[]string{"precision"},
+		},
+		{
+			"negative scale",
+			schema.DecimalOptions{Precision: 10, Scale: -1},
+			[]string{"scale"},
+		},
+		{
+			"scale > precision",
+			schema.DecimalOptions{Precision: 2, Scale: 3},
+			[]string{"scale"},
+		},
+		{
+			"valid options",
+			schema.DecimalOptions{Precision: 10, Scale: 2},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
@@ -2239,6 +2935,21 @@ func TestRelationOptionsValidate(t *testing.T) {
 				MaxSelect:    types.Pointer(1),
 			},
 			[]string{"maxSelect"},
+		}, {
+			"default with datetime macro",
+			schema.RelationOptions{
+				CollectionId: "abc",
+				Default:      "@now",
+			},
+			[]string{"default"},
+		},
+		{
+			"default with request macro",
+			schema.RelationOptions{
+				CollectionId: "abc",
+				Default:      schema.DefaultMacroRequestAuthId,
+			},
+			[]string{},
 		},
 	}
 
@@ -0,0 +1,173 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sequencePatternMaxWidth is the max allowed {seq:N} zero padding width.
+const sequencePatternMaxWidth = 20
+
+// sequenceDatePlaceholders maps the supported sequence pattern
+// date placeholders to their time layout.
+var sequenceDatePlaceholders = map[string]string{
+	"year":  "2006",
+	"month": "01",
+	"day":   "02",
+}
+
+// sequencePatternPart is a single literal or placeholder part of a sequence pattern.
+type sequencePatternPart struct {
+	literal     string
+	placeholder string // empty for literal parts
+	width       int    // the {seq:N} zero padding width (if any)
+}
+
+// FormatSequence formats the sequence number seq according to the
+// provided pattern, resolving the date placeholders with date.
+//
+// The following placeholders are supported:
+//   - {seq}     - the sequence number (required)
+//   - {seq:05}  - the sequence number left padded with zeros to 5 digits
+//   - {year}    - the 4 digits year of date
+//   - {month}   - the 2 digits month of date
+//   - {day}     - the 2 digits day of date
+//
+// Empty pattern returns the plain sequence number.
+func FormatSequence(pattern string, seq int, date time.Time) (string, error) {
+	if pattern == "" {
+		return strconv.Itoa(seq), nil
+	}
+
+	parts, err := splitSequencePattern(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+
+	for _, part := range parts {
+		switch part.placeholder {
+		case "":
+			result.WriteString(part.literal)
+		case "seq":
+			result.WriteString(fmt.Sprintf("%0*d", part.width, seq))
+		default:
+			result.WriteString(date.Format(sequenceDatePlaceholders[part.placeholder]))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// ParseSequence extracts the sequence number from a value
+// formatted with the provided pattern (see [FormatSequence]).
+//
+// Returns false if the value doesn't match the pattern.
+func ParseSequence(pattern string, value string) (int, bool) {
+	if pattern == "" {
+		seq, err := strconv.Atoi(value)
+		return seq, err == nil
+	}
+
+	parts, err := splitSequencePattern(pattern)
+	if err != nil {
+		return 0, false
+	}
+
+	var expr strings.Builder
+
+	expr.WriteString("^")
+	for _, part := range parts {
+		switch part.placeholder {
+		case "":
+			expr.WriteString(regexp.QuoteMeta(part.literal))
+		case "seq":
+			expr.WriteString(`(\d+)`)
+		case "year":
+			expr.WriteString(`\d{4}`)
+		default:
+			expr.WriteString(`\d{2}`)
+		}
+	}
+	expr.WriteString("$")
+
+	match := regexp.MustCompile(expr.String()).FindStringSubmatch(value)
+	if len(match) != 2 {
+		return 0, false
+	}
+
+	seq, err := strconv.Atoi(match[1])
+
+	return seq, err == nil
+}
+
+// splitSequencePattern validates and splits the provided
+// sequence pattern into literal and placeholder parts.
+func splitSequencePattern(pattern string) ([]sequencePatternPart, error) {
+	var parts []sequencePatternPart
+	var hasSeq bool
+
+	for i := 0; i < len(pattern); {
+		start := strings.IndexByte(pattern[i:], '{')
+		if start < 0 {
+			if strings.IndexByte(pattern[i:], '}') >= 0 {
+				return nil, errors.New("unexpected closing brace")
+			}
+			parts = append(parts, sequencePatternPart{literal: pattern[i:]})
+			break
+		}
+
+		if strings.IndexByte(pattern[i:i+start], '}') >= 0 {
+			return nil, errors.New("unexpected closing brace")
+		}
+		if start > 0 {
+			parts = append(parts, sequencePatternPart{literal: pattern[i : i+start]})
+		}
+
+		end := strings.IndexByte(pattern[i+start:], '}')
+		if end < 0 {
+			return nil, errors.New("unterminated placeholder")
+		}
+
+		placeholder := pattern[i+start+1 : i+start+end]
+		i += start + end + 1
+
+		name, width, hasWidth := strings.Cut(placeholder, ":")
+
+		switch name {
+		case "seq":
+			if hasSeq {
+				return nil, errors.New("the {seq} placeholder could be used only once")
+			}
+			hasSeq = true
+
+			part := sequencePatternPart{placeholder: name}
+
+			if hasWidth {
+				n, err := strconv.Atoi(width)
+				if err != nil || n <= 0 || n > sequencePatternMaxWidth {
+					return nil, fmt.Errorf("invalid {seq} width %q", width)
+				}
+				part.width = n
+			}
+
+			parts = append(parts, part)
+		default:
+			if _, ok := sequenceDatePlaceholders[name]; !ok || hasWidth {
+				return nil, fmt.Errorf("unknown placeholder {%s}", placeholder)
+			}
+			parts = append(parts, sequencePatternPart{placeholder: name})
+		}
+	}
+
+	if !hasSeq {
+		return nil, errors.New("missing {seq} placeholder")
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,86 @@
+package schema_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+func TestFormatSequence(t *testing.T) {
+	date := time.Date(2024, 3, 7, 10, 0, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		pattern     string
+		seq         int
+		expectError bool
+		expected    string
+	}{
+		{"", 12, false, "12"},
+		{"{seq}", 12, false, "12"},
+		{"INV-{seq:05}", 12, false, "INV-00012"},
+		{"INV-{seq:2}", 123, false, "INV-123"},
+		{"INV-{year}-{month}-{day}-{seq}", 1, false, "INV-2024-03-07-1"},
+		{"INV", 1, true, ""},
+		{"INV-{year}", 1, true, ""},
+		{"{seq}-{seq}", 1, true, ""},
+		{"{seq:abc}", 1, true, ""},
+		{"{seq:0}", 1, true, ""},
+		{"{seq:21}", 1, true, ""},
+		{"{year:4}-{seq}", 1, true, ""},
+		{"{unknown}-{seq}", 1, true, ""},
+		{"{seq", 1, true, ""},
+		{"seq}", 1, true, ""},
+		{"}{seq}", 1, true, ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.pattern, func(t *testing.T) {
+			result, err := schema.FormatSequence(s.pattern, s.seq, date)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseSequence(t *testing.T) {
+	scenarios := []struct {
+		pattern  string
+		value    string
+		expected int
+		ok       bool
+	}{
+		{"", "12", 12, true},
+		{"", "INV-12", 0, false},
+		{"{seq}", "12", 12, true},
+		{"INV-{seq:05}", "INV-00012", 12, true},
+		{"INV-{seq:05}", "INV-123456", 123456, true},
+		{"INV-{seq:05}", "inv-00012", 0, false},
+		{"INV-{seq:05}", "INV-00012-", 0, false},
+		{"INV.{year}-{month}-{day}-{seq}", "INV.2024-03-07-1", 1, true},
+		{"INV.{year}-{month}-{day}-{seq}", "INVx2024-03-07-1", 0, false},
+		{"INV.{year}-{month}-{day}-{seq}", "INV.24-03-07-1", 0, false},
+		{"{seq}-{seq}", "1-1", 0, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.pattern+"_"+s.value, func(t *testing.T) {
+			seq, ok := schema.ParseSequence(s.pattern, s.value)
+
+			if ok != s.ok {
+				t.Fatalf("Expected ok %v, got %v", s.ok, ok)
+			}
+
+			if seq != s.expected {
+				t.Fatalf("Expected %d, got %d", s.expected, seq)
+			}
+		})
+	}
+}
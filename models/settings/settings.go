@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -30,6 +33,8 @@ type Settings struct {
 	S3      S3Config      `form:"s3" json:"s3"`
 	Backups BackupsConfig `form:"backups" json:"backups"`
 
+	RateLimits RateLimitsConfig `form:"rateLimits" json:"rateLimits"`
+
 	AdminAuthToken           TokenConfig `form:"adminAuthToken" json:"adminAuthToken"`
 	AdminPasswordResetToken  TokenConfig `form:"adminPasswordResetToken" json:"adminPasswordResetToken"`
 	AdminFileToken           TokenConfig `form:"adminFileToken" json:"adminFileToken"`
@@ -95,6 +100,14 @@ func New() *Settings {
 		Backups: BackupsConfig{
 			CronMaxKeep: 3,
 		},
+		RateLimits: RateLimitsConfig{
+			Enabled: false,
+			Rules: []RateLimitRule{
+				{Label: "*:auth", MaxRequests: 3, Duration: 3},
+				{Label: "*:create", MaxRequests: 20, Duration: 5},
+				{Label: "/api/", MaxRequests: 300, Duration: 10},
+			},
+		},
 		AdminAuthToken: TokenConfig{
 			Secret:   security.RandomString(50),
 			Duration: 1209600, // 14 days
@@ -218,6 +231,7 @@ func (s *Settings) Validate() error {
 		validation.Field(&s.Smtp),
 		validation.Field(&s.S3),
 		validation.Field(&s.Backups),
+		validation.Field(&s.RateLimits),
 		validation.Field(&s.GoogleAuth),
 		validation.Field(&s.FacebookAuth),
 		validation.Field(&s.GithubAuth),
@@ -488,6 +502,164 @@ func checkCronExpression(value any) error {
 
 // -------------------------------------------------------------------
 
+// Rate limit rule audiences.
+const (
+	RateLimitAudienceGuest = "@guest"
+	RateLimitAudienceAuth  = "@auth"
+	RateLimitAudienceAdmin = "@admin"
+)
+
+// Collection actions that could be used in a rate limit rule label.
+const (
+	RateLimitActionList   = "list"
+	RateLimitActionView   = "view"
+	RateLimitActionCreate = "create"
+	RateLimitActionUpdate = "update"
+	RateLimitActionDelete = "delete"
+	RateLimitActionAuth   = "auth"
+)
+
+var (
+	rateLimitTagLabelRegex  = regexp.MustCompile(`^(\*|\w+):(list|view|create|update|delete|auth)$|^\w+$`)
+	rateLimitPathLabelRegex = regexp.MustCompile(`^((GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS) )?/\S*$`)
+)
+
+type RateLimitsConfig struct {
+	Enabled bool            `form:"enabled" json:"enabled"`
+	Rules   []RateLimitRule `form:"rules" json:"rules"`
+
+	// TrustProxy specifies whether to resolve the guests client IP from
+	// the common reverse proxy headers (eg. X-Forwarded-For).
+	//
+	// Enable it only if the app is behind a trusted reverse proxy,
+	// otherwise the client could spoof its IP and bypass the limits!
+	TrustProxy bool `form:"trustProxy" json:"trustProxy"`
+}
+
+// Validate makes RateLimitsConfig validatable by implementing [validation.Validatable] interface.
+func (c RateLimitsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Rules,
+			validation.When(c.Enabled, validation.Required),
+			validation.By(checkUniqueRateLimitRules),
+		),
+	)
+}
+
+// FindRule returns the first rule matching one of the provided labels
+// (in the order of their priority) and the specified audience.
+//
+// For each label, a rule with the exact audience is preferred
+// over the one without audience (aka. applicable for everyone).
+func (c RateLimitsConfig) FindRule(labels []string, audience string) (RateLimitRule, bool) {
+	for _, label := range labels {
+		var fallback *RateLimitRule
+
+		for i, rule := range c.Rules {
+			if rule.Label != label {
+				continue
+			}
+
+			if rule.Audience == audience {
+				return rule, true
+			}
+
+			if rule.Audience == "" && fallback == nil {
+				fallback = &c.Rules[i]
+			}
+		}
+
+		if fallback != nil {
+			return *fallback, true
+		}
+	}
+
+	return RateLimitRule{}, false
+}
+
+func checkUniqueRateLimitRules(value any) error {
+	v, _ := value.([]RateLimitRule)
+
+	existing := make(map[string]struct{}, len(v))
+
+	for i, rule := range v {
+		key := rule.Label + rule.Audience
+		if _, ok := existing[key]; ok {
+			return validation.Errors{
+				strconv.Itoa(i): validation.Errors{
+					"label": validation.NewError(
+						"validation_duplicated_rate_limit_rule",
+						"Rule with the same label and audience already exists.",
+					),
+				},
+			}
+		}
+		existing[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// RateLimitRule defines a single requests rate limit rule.
+type RateLimitRule struct {
+	// Label is the identifier of the rule and it could be either:
+	//   - a collection action tag in the format "collectionName:action" or "*:action"
+	//     (action could be "list", "view", "create", "update", "delete" or "auth")
+	//   - a custom tag used with the [apis.RateLimit] middleware (eg. "myTag")
+	//   - a request path (eg. "/api/hello"), optionally prefixed with a request method
+	//     (eg. "POST /api/hello"); paths with trailing slash match all of their subpaths
+	Label string `form:"label" json:"label"`
+
+	// Audience is an optional requester type the rule is applied for
+	// ("@guest", "@auth" or "@admin"). Leave it empty to apply the rule for everyone.
+	Audience string `form:"audience" json:"audience"`
+
+	// MaxRequests is the max allowed number of requests per Duration.
+	MaxRequests int `form:"maxRequests" json:"maxRequests"`
+
+	// Duration is the sliding window interval (in seconds) of the MaxRequests limit.
+	Duration int64 `form:"duration" json:"duration"`
+}
+
+// Validate makes RateLimitRule validatable by implementing [validation.Validatable] interface.
+func (r RateLimitRule) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Label, validation.Required, validation.Length(1, 255), validation.By(checkRateLimitLabel)),
+		validation.Field(&r.Audience, validation.In(RateLimitAudienceGuest, RateLimitAudienceAuth, RateLimitAudienceAdmin)),
+		validation.Field(&r.MaxRequests, validation.Required, validation.Min(1)),
+		validation.Field(&r.Duration, validation.Required, validation.Min(1)),
+	)
+}
+
+// DurationTime returns the rule Duration as [time.Duration].
+func (r RateLimitRule) DurationTime() time.Duration {
+	return time.Duration(r.Duration) * time.Second
+}
+
+// IsPathLabel checks whether the rule label is a request path pattern.
+func (r RateLimitRule) IsPathLabel() bool {
+	return strings.HasPrefix(r.Label, "/") || strings.Contains(r.Label, " /")
+}
+
+func checkRateLimitLabel(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if !rateLimitTagLabelRegex.MatchString(v) && !rateLimitPathLabelRegex.MatchString(v) {
+		return validation.NewError(
+			"validation_invalid_rate_limit_label",
+			"Invalid label format - expected a tag (eg. users:auth) or a request path (eg. POST /api/hello).",
+		)
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
 type MetaConfig struct {
 	AppName                    string        `form:"appName" json:"appName"`
 	AppUrl                     string        `form:"appUrl" json:"appUrl"`
@@ -78,6 +78,7 @@ func TestSettingsValidate(t *testing.T) {
 	s.PatreonAuth.ClientId = ""
 	s.MailcowAuth.Enabled = true
 	s.MailcowAuth.ClientId = ""
+	s.RateLimits.Rules = []settings.RateLimitRule{{Label: "invalid label"}}
 
 	// check if Validate() is triggering the members validate methods.
 	err := s.Validate()
@@ -121,6 +122,7 @@ func TestSettingsValidate(t *testing.T) {
 		`"yandexAuth":{`,
 		`"patreonAuth":{`,
 		`"mailcowAuth":{`,
+		`"rateLimits":{`,
 	}
 
 	errBytes, _ := json.Marshal(err)
@@ -741,6 +743,199 @@ func TestBackupsConfigValidate(t *testing.T) {
 	}
 }
 
+func TestRateLimitsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RateLimitsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.RateLimitsConfig{},
+			[]string{},
+		},
+		{
+			"enabled without rules",
+			settings.RateLimitsConfig{Enabled: true},
+			[]string{"rules"},
+		},
+		{
+			"invalid rule",
+			settings.RateLimitsConfig{
+				Rules: []settings.RateLimitRule{{Label: "test"}},
+			},
+			[]string{"rules"},
+		},
+		{
+			"duplicated rules",
+			settings.RateLimitsConfig{
+				Rules: []settings.RateLimitRule{
+					{Label: "test", MaxRequests: 1, Duration: 1},
+					{Label: "test", Audience: settings.RateLimitAudienceGuest, MaxRequests: 1, Duration: 1},
+					{Label: "test", MaxRequests: 2, Duration: 2},
+				},
+			},
+			[]string{"rules"},
+		},
+		{
+			"valid data",
+			settings.RateLimitsConfig{
+				Enabled: true,
+				Rules: []settings.RateLimitRule{
+					{Label: "test", MaxRequests: 1, Duration: 1},
+					{Label: "test", Audience: settings.RateLimitAudienceGuest, MaxRequests: 1, Duration: 1},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRateLimitsConfigFindRule(t *testing.T) {
+	config := settings.RateLimitsConfig{
+		Rules: []settings.RateLimitRule{
+			{Label: "users:auth", MaxRequests: 1},
+			{Label: "users:auth", Audience: settings.RateLimitAudienceGuest, MaxRequests: 2},
+			{Label: "*:auth", MaxRequests: 3},
+			{Label: "/api/", Audience: settings.RateLimitAudienceAdmin, MaxRequests: 4},
+		},
+	}
+
+	scenarios := []struct {
+		labels      []string
+		audience    string
+		expectedMax int // 0 for no rule
+	}{
+		{nil, "", 0},
+		{[]string{"missing"}, settings.RateLimitAudienceGuest, 0},
+		{[]string{"users:auth", "*:auth"}, settings.RateLimitAudienceGuest, 2},
+		{[]string{"users:auth", "*:auth"}, settings.RateLimitAudienceAuth, 1},
+		{[]string{"demo:auth", "*:auth"}, settings.RateLimitAudienceGuest, 3},
+		{[]string{"missing", "/api/"}, settings.RateLimitAudienceGuest, 0},
+		{[]string{"missing", "/api/"}, settings.RateLimitAudienceAdmin, 4},
+	}
+
+	for i, s := range scenarios {
+		rule, ok := config.FindRule(s.labels, s.audience)
+
+		if ok != (s.expectedMax > 0) {
+			t.Errorf("[%d] Expected found %v, got %v", i, s.expectedMax > 0, ok)
+			continue
+		}
+
+		if rule.MaxRequests != s.expectedMax {
+			t.Errorf("[%d] Expected rule with max %d, got %v", i, s.expectedMax, rule)
+		}
+	}
+}
+
+func TestRateLimitRuleValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		rule           settings.RateLimitRule
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.RateLimitRule{},
+			[]string{"label", "maxRequests", "duration"},
+		},
+		{
+			"invalid data",
+			settings.RateLimitRule{
+				Label:       "users:invalid",
+				Audience:    "invalid",
+				MaxRequests: -1,
+				Duration:    -1,
+			},
+			[]string{"label", "audience", "maxRequests", "duration"},
+		},
+		{
+			"invalid path label",
+			settings.RateLimitRule{Label: "INVALID /api/", MaxRequests: 1, Duration: 1},
+			[]string{"label"},
+		},
+		{
+			"valid tag label",
+			settings.RateLimitRule{Label: "*:create", Audience: settings.RateLimitAudienceAuth, MaxRequests: 1, Duration: 1},
+			[]string{},
+		},
+		{
+			"valid custom tag label",
+			settings.RateLimitRule{Label: "test", MaxRequests: 1, Duration: 1},
+			[]string{},
+		},
+		{
+			"valid path label",
+			settings.RateLimitRule{Label: "POST /api/test/", MaxRequests: 1, Duration: 1},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.rule.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRateLimitRuleIsPathLabel(t *testing.T) {
+	scenarios := []struct {
+		label    string
+		expected bool
+	}{
+		{"", false},
+		{"test", false},
+		{"*:auth", false},
+		{"/", true},
+		{"/api/test", true},
+		{"GET /api/test", true},
+	}
+
+	for _, s := range scenarios {
+		rule := settings.RateLimitRule{Label: s.label}
+		if v := rule.IsPathLabel(); v != s.expected {
+			t.Errorf("[%s] Expected %v, got %v", s.label, s.expected, v)
+		}
+	}
+}
+
 func TestEmailTemplateValidate(t *testing.T) {
 	scenarios := []struct {
 		emailTemplate  settings.EmailTemplate
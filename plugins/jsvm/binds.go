@@ -618,6 +618,7 @@ func apisBinds(vm *goja.Runtime) {
 	obj.Set("requireAdminOrRecordAuth", apis.RequireAdminOrRecordAuth)
 	obj.Set("requireAdminOrOwnerAuth", apis.RequireAdminOrOwnerAuth)
 	obj.Set("activityLogger", apis.ActivityLogger)
+	obj.Set("rateLimit", apis.RateLimit)
 
 	// record helpers
 	obj.Set("requestInfo", apis.RequestInfo)
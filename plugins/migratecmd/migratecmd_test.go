@@ -823,3 +823,71 @@ func TestAutomigrateCollectionNoChanges(t *testing.T) {
 		}
 	}
 }
+
+func TestAutomigrateFieldDefault(t *testing.T) {
+	scenarios := []struct {
+		lang            string
+		expectedContent string
+	}{
+		{
+			migratecmd.TemplateLangJS,
+			"\n      \"default\": \"draft\"\n",
+		},
+		{
+			migratecmd.TemplateLangGo,
+			"\n\t\t\t\t\"default\": \"draft\"\n",
+		},
+	}
+
+	for i, s := range scenarios {
+		t.Run(fmt.Sprintf("s%d", i), func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			migrationsDir := filepath.Join(app.DataDir(), "_test_migrations")
+
+			migratecmd.MustRegister(app, nil, migratecmd.Config{
+				TemplateLang: s.lang,
+				Automigrate:  true,
+				Dir:          migrationsDir,
+			})
+
+			// create dummy collection
+			collection := &models.Collection{}
+			collection.Name = "test123"
+			collection.Schema.AddField(&schema.SchemaField{
+				Id:   "status_id",
+				Name: "status",
+				Type: schema.FieldTypeText,
+			})
+
+			// use different dao to avoid triggering automigrate while saving the dummy collection
+			if err := daos.New(app.DB()).SaveCollection(collection); err != nil {
+				t.Fatalf("Failed to save dummy collection, got %v", err)
+			}
+
+			// @todo remove after collections cache is replaced
+			app.Bootstrap()
+
+			collection.Schema.GetFieldById("status_id").Options = &schema.TextOptions{Default: "draft"}
+
+			if err := app.Dao().SaveCollection(collection); err != nil {
+				t.Fatalf("Failed to save dummy collection update, got %v", err)
+			}
+
+			files, _ := os.ReadDir(migrationsDir)
+			if total := len(files); total != 1 {
+				t.Fatalf("Expected 1 file to be generated, got %d", total)
+			}
+
+			content, err := os.ReadFile(filepath.Join(migrationsDir, files[0].Name()))
+			if err != nil {
+				t.Fatalf("Failed to read the generated migration file: %v", err)
+			}
+
+			if !strings.Contains(string(content), s.expectedContent) {
+				t.Fatalf("Expected the migration to contain %q, got \n%s", s.expectedContent, content)
+			}
+		})
+	}
+}
@@ -136,6 +136,9 @@ func NewWithConfig(config Config) *PocketBase {
 func (pb *PocketBase) Start() error {
 	// register system commands
 	pb.RootCmd.AddCommand(cmd.NewAdminCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewEncryptionCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewExportCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewImportCommand(pb))
 	pb.RootCmd.AddCommand(cmd.NewServeCommand(pb, !pb.hideStartBanner))
 
 	return pb.Execute()
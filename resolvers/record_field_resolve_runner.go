@@ -13,6 +13,8 @@ import (
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
 )
 
 // parseAndRun starts a new one-off RecordFieldResolver.Resolve execution.
@@ -160,13 +162,14 @@ func (r *runner) processCollectionField() (*search.ResolverResult, error) {
 	r.withMultiMatch = true
 
 	// join the collection to the main query
-	r.resolver.registerJoin(inflector.Columnify(collection.Name), r.activeTableAlias, nil)
+	r.resolver.registerJoin(inflector.Columnify(collection.Name), r.activeTableAlias, withoutTrashed(collection, r.activeTableAlias, nil))
 
 	// join the collection to the multi-match subquery
 	r.multiMatchActiveTableAlias = "__mm" + r.activeTableAlias
 	r.multiMatch.joins = append(r.multiMatch.joins, &join{
 		tableName:  inflector.Columnify(collection.Name),
 		tableAlias: r.multiMatchActiveTableAlias,
+		on:         withoutTrashed(collection, r.multiMatchActiveTableAlias, nil),
 	})
 
 	// leave only the collection fields
@@ -306,10 +309,10 @@ func (r *runner) processRequestInfoRelationField(dataField *schema.SchemaField)
 	r.resolver.registerJoin(
 		inflector.Columnify(r.activeCollectionName),
 		r.activeTableAlias,
-		dbx.In(
+		withoutTrashed(dataRelCollection, r.activeTableAlias, dbx.In(
 			fmt.Sprintf("[[%s.id]]", inflector.Columnify(r.activeTableAlias)),
 			list.ToInterfaceSlice(dataRelIds)...,
-		),
+		)),
 	)
 
 	if options.MaxSelect == nil || *options.MaxSelect != 1 {
@@ -323,7 +326,11 @@ func (r *runner) processRequestInfoRelationField(dataField *schema.SchemaField)
 		&join{
 			tableName:  inflector.Columnify(r.activeCollectionName),
 			tableAlias: r.multiMatchActiveTableAlias,
-			on:         dbx.In(r.multiMatchActiveTableAlias+".id", list.ToInterfaceSlice(dataRelIds)...),
+			on: withoutTrashed(
+				dataRelCollection,
+				r.multiMatchActiveTableAlias,
+				dbx.In(r.multiMatchActiveTableAlias+".id", list.ToInterfaceSlice(dataRelIds)...),
+			),
 		},
 	)
 
@@ -385,6 +392,10 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 				return nil, fmt.Errorf("unknown field %q", name)
 			}
 
+			if err := r.checkRestrictedField(field); err != nil {
+				return nil, err
+			}
+
 			cleanFieldName := inflector.Columnify(field.Name)
 
 			// arrayble fields ":length" modifier
@@ -453,6 +464,72 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 				result.MultiMatchSubQuery = r.multiMatch
 			}
 
+			// the encrypted fields could be only compared by their blind index
+			if field.Type == schema.FieldTypeEncrypted {
+				field.InitOptions()
+				options, _ := field.Options.(*schema.EncryptedOptions)
+				if options == nil || !options.BlindIndex {
+					return nil, fmt.Errorf("encrypted field %q without blind index can't be used in filter and sort expressions", name)
+				}
+
+				result.Identifier = blindIndex(r.activeTableAlias + "." + cleanFieldName)
+				result.EqualityValueTransform = func(value any) any {
+					return models.FieldBlindIndex(cast.ToString(value), r.resolver.encryptionKey())
+				}
+				if r.withMultiMatch {
+					r.multiMatch.valueIdentifier = blindIndex(r.multiMatchActiveTableAlias + "." + cleanFieldName)
+				}
+			}
+
+			// compare and sort the formatted sequence values by their raw number
+			if col := schema.SequenceNumberColumn(field); col != "" && !collection.IsView() {
+				options, _ := field.Options.(*schema.SequenceOptions)
+
+				fallback := *result
+				if result.MultiMatchSubQuery != nil {
+					mm := *r.multiMatch
+					fallback.MultiMatchSubQuery = &mm
+				}
+
+				result.Identifier = fmt.Sprintf("[[%s.%s]]", r.activeTableAlias, col)
+				result.ValueTransform = func(value any) any {
+					return sequenceNumberValue(options.Pattern, value)
+				}
+				result.ValueFallback = &fallback
+				if r.withMultiMatch {
+					r.multiMatch.valueIdentifier = fmt.Sprintf("[[%s.%s]]", r.multiMatchActiveTableAlias, col)
+				}
+			}
+
+			// compare the decimal fields stored integer with the literal
+			// values scaled to the field scale to avoid float rounding errors
+			// (the comparisons with other fields use the unscaled value)
+			if field.Type == schema.FieldTypeDecimal {
+				field.InitOptions()
+				if options, _ := field.Options.(*schema.DecimalOptions); options != nil && options.Scale > 0 {
+					scale := options.Scale
+
+					fallback := *result
+					fallback.Identifier = unscaledDecimal(r.activeTableAlias+"."+cleanFieldName, scale)
+					if result.MultiMatchSubQuery != nil {
+						mm := *r.multiMatch
+						mm.valueIdentifier = unscaledDecimal(r.multiMatchActiveTableAlias+"."+cleanFieldName, scale)
+						fallback.MultiMatchSubQuery = &mm
+					}
+
+					result.ValueTransform = func(value any) any {
+						return scaledDecimalValue(value, scale)
+					}
+					result.ValueFallback = &fallback
+					result.AggregateValueTransform = func(value any) any {
+						if v, ok := value.(int64); ok {
+							return types.NewDecimal(v, int32(scale))
+						}
+						return value
+					}
+				}
+			}
+
 			// wrap in json_extract to ensure that top-level primitives
 			// stored as json work correctly when compared to their SQL equivalent
 			// (https://github.com/pocketbase/pocketbase/issues/4068)
@@ -475,6 +552,10 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 			return nil, fmt.Errorf("unknown field %q", prop)
 		}
 
+		if err := r.checkRestrictedField(field); err != nil {
+			return nil, err
+		}
+
 		// check if it is a json field
 		if field.Type == schema.FieldTypeJson {
 			var jsonPath strings.Builder
@@ -530,7 +611,7 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 			r.resolver.registerJoin(
 				inflector.Columnify(newCollectionName),
 				newTableAlias,
-				dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s]]", newTableAlias, prefixedFieldName)),
+				withoutTrashed(relCollection, newTableAlias, dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s]]", newTableAlias, prefixedFieldName))),
 			)
 		} else {
 			jeAlias := r.activeTableAlias + "_" + cleanFieldName + "_je"
@@ -538,7 +619,7 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 			r.resolver.registerJoin(
 				inflector.Columnify(newCollectionName),
 				newTableAlias,
-				dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s.value]]", newTableAlias, jeAlias)),
+				withoutTrashed(relCollection, newTableAlias, dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s.value]]", newTableAlias, jeAlias))),
 			)
 		}
 
@@ -561,7 +642,7 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 				&join{
 					tableName:  inflector.Columnify(newCollectionName),
 					tableAlias: newTableAlias2,
-					on:         dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s]]", newTableAlias2, prefixedFieldName2)),
+					on:         withoutTrashed(relCollection, newTableAlias2, dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s]]", newTableAlias2, prefixedFieldName2))),
 				},
 			)
 		} else {
@@ -575,7 +656,7 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 				&join{
 					tableName:  inflector.Columnify(newCollectionName),
 					tableAlias: newTableAlias2,
-					on:         dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s.value]]", newTableAlias2, jeAlias2)),
+					on:         withoutTrashed(relCollection, newTableAlias2, dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s.value]]", newTableAlias2, jeAlias2))),
 				},
 			)
 		}
@@ -587,6 +668,34 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 	return nil, fmt.Errorf("failed to resolve field %q", r.fieldName)
 }
 
+// checkRestrictedField returns an error if the provided field has a view rule
+// and the runner is not allowed to access it.
+//
+// The fields with view rule could be used in filter and sort expressions
+// only by admins to prevent guessing their values from the results.
+func (r *runner) checkRestrictedField(field *schema.SchemaField) error {
+	if field.ViewRule == "" || r.allowHiddenFields || r.resolver.isAdminRequest() {
+		return nil
+	}
+
+	return fmt.Errorf("field %q with view rule can be used in filter and sort expressions only by admins", field.Name)
+}
+
+// withoutTrashed combines the provided join expression with a condition
+// that excludes the trashed records of a soft delete collection.
+func withoutTrashed(collection *models.Collection, tableAlias string, on dbx.Expression) dbx.Expression {
+	if !collection.HasSoftDelete() {
+		return on
+	}
+
+	notTrashed := dbx.NewExp(fmt.Sprintf("[[%s.%s]] = ''", tableAlias, schema.FieldNameDeleted))
+	if on == nil {
+		return notTrashed
+	}
+
+	return dbx.And(on, notTrashed)
+}
+
 func jsonArrayLength(tableColumnPair string) string {
 	return fmt.Sprintf(
 		// note: the case is used to normalize value access for single and multiple relations.
@@ -615,6 +724,66 @@ func jsonExtract(tableColumnPair string, path string) string {
 	)
 }
 
+// blindIndex returns the blind index hash part of the stored encrypted field value.
+func blindIndex(tableColumnPair string) string {
+	return fmt.Sprintf("substr([[%s]], 1, 64)", tableColumnPair)
+}
+
+// unscaledDecimal returns the decimal value of the stored decimal field scaled integer.
+//
+// Note that the division result is a float and it is used only
+// as fallback in the expressions where the stored integer can't
+// be used directly (eg. when compared with another field).
+func unscaledDecimal(tableColumnPair string, scale int) string {
+	return fmt.Sprintf("([[%s]] / 1e%d)", tableColumnPair, scale)
+}
+
+// scaledDecimalValue converts the provided number literal value
+// into the decimal field stored integer (eg. 12.5 with scale 2 is 1250).
+//
+// Values with more fractional digits than the scale are converted
+// to a float to preserve the comparison result (eg. 12.505 is 1250.5).
+// Non-numeric values are returned as they are.
+func scaledDecimalValue(value any, scale int) any {
+	if str, ok := value.(string); value == nil || (ok && strings.TrimSpace(str) == "") {
+		return value
+	}
+
+	d := types.Decimal{}
+	if err := d.Scan(value); err != nil {
+		return value
+	}
+
+	if d.Scale() <= int32(scale) {
+		if v, err := d.ScaledInt64(int32(scale)); err == nil {
+			return v
+		}
+	}
+
+	return d.Mul(types.NewDecimal(1, -int32(scale))).Float64()
+}
+
+// sequenceNumberValue converts the provided formatted sequence value
+// or plain number string into the raw sequence number.
+//
+// Returns nil for strings that are neither of them.
+func sequenceNumberValue(pattern string, value any) any {
+	str, ok := value.(string)
+	if !ok {
+		return value // eg. a number literal
+	}
+
+	if seq, ok := schema.ParseSequence(pattern, str); ok {
+		return seq
+	}
+
+	if seq, err := strconv.Atoi(str); err == nil {
+		return seq
+	}
+
+	return nil
+}
+
 func resolvableSystemFieldNames(collection *models.Collection) []string {
 	result := schema.BaseModelFieldNames()
 
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/spf13/cast"
@@ -21,6 +23,15 @@ const (
 	lengthModifier string = "length"
 )
 
+// full-text search related constants
+const (
+	// searchRankKey is the special field that resolves to the
+	// bm25 relevance rank of the full-text search matched record.
+	searchRankKey string = "@rank"
+
+	searchTableAlias string = "__fts"
+)
+
 // list of auth filter fields that don't require join with the auth
 // collection or any other extra checks to be resolved.
 var plainRequestAuthFields = []string{
@@ -47,6 +58,12 @@ type CollectionsFinder interface {
 	FindCollectionByNameOrId(collectionNameOrId string) (*models.Collection, error)
 }
 
+// encryptionKeyProvider defines an optional CollectionsFinder interface
+// for providing the records encryption key (eg. [daos.Dao]).
+type encryptionKeyProvider interface {
+	EncryptionKey() string
+}
+
 // RecordFieldResolver defines a custom search resolver struct for
 // managing Record model search fields.
 //
@@ -70,6 +87,7 @@ type RecordFieldResolver struct {
 	loadedCollections []*models.Collection
 	joins             []*join
 	allowHiddenFields bool
+	search            string
 }
 
 // NewRecordFieldResolver creates and initializes a new `RecordFieldResolver`.
@@ -115,6 +133,26 @@ func NewRecordFieldResolver(
 	return r
 }
 
+// SetSearch enables the full-text search of the base collection records
+// matching all terms of the provided text (see [models.Collection.SearchFields]).
+//
+// Once enabled, the resolver could also resolve the special "@rank" field
+// that holds the bm25 relevance rank of the matched record (lower is better).
+func (r *RecordFieldResolver) SetSearch(text string) error {
+	if len(r.baseCollection.SearchFields()) == 0 {
+		return fmt.Errorf("the collection %q doesn't have full-text search fields", r.baseCollection.Name)
+	}
+
+	matchQuery := search.FullTextMatchQuery(text)
+	if matchQuery == "" {
+		return errors.New("missing full-text search terms")
+	}
+
+	r.search = matchQuery
+
+	return nil
+}
+
 // UpdateQuery implements `search.FieldResolver` interface.
 //
 // Conditionally updates the provided search query based on the
@@ -131,6 +169,22 @@ func (r *RecordFieldResolver) UpdateQuery(query *dbx.SelectQuery) error {
 		}
 	}
 
+	if r.search != "" {
+		searchTable := r.baseCollection.SearchTableName()
+
+		query.InnerJoin(
+			fmt.Sprintf("{{%s}} {{%s}}", searchTable, searchTableAlias),
+			dbx.NewExp(fmt.Sprintf("[[%s.id]] = [[%s.id]]", searchTableAlias, inflector.Columnify(r.baseCollection.Name))),
+		)
+
+		// note: FTS5 doesn't support table aliases as MATCH operand
+		// so the hidden column with the same name as the table is used
+		query.AndWhere(dbx.NewExp(
+			fmt.Sprintf("[[%s.%s]] MATCH {:__search}", searchTableAlias, searchTable),
+			dbx.Params{"__search": r.search},
+		))
+	}
+
 	return nil
 }
 
@@ -151,6 +205,14 @@ func (r *RecordFieldResolver) UpdateQuery(query *dbx.SelectQuery) error {
 //	@request.data.someField:isset
 //	@collection.product.name
 func (r *RecordFieldResolver) Resolve(fieldName string) (*search.ResolverResult, error) {
+	if fieldName == searchRankKey {
+		if r.search == "" {
+			return nil, fmt.Errorf("%s is available only with full-text search", searchRankKey)
+		}
+
+		return &search.ResolverResult{Identifier: fmt.Sprintf("[[%s.rank]]", searchTableAlias)}, nil
+	}
+
 	return parseAndRun(fieldName, r)
 }
 
@@ -187,7 +249,7 @@ func (r *RecordFieldResolver) resolveStaticRequestField(path ...string) (*search
 		// float in case of a numeric string value was used
 		// (this usually the case when the data is from a multipart/form-data request)
 		field := r.baseCollection.Schema.GetFieldByName(path[len(path)-1])
-		if field != nil && field.Type == schema.FieldTypeNumber {
+		if field != nil && (field.Type == schema.FieldTypeNumber || field.Type == schema.FieldTypeDecimal) {
 			if nv, err := strconv.ParseFloat(v, 64); err == nil {
 				resultVal = nv
 			}
@@ -219,6 +281,20 @@ func (r *RecordFieldResolver) resolveStaticRequestField(path ...string) (*search
 	}, nil
 }
 
+// isAdminRequest checks whether the resolver request info is from an admin.
+func (r *RecordFieldResolver) isAdminRequest() bool {
+	return r.requestInfo != nil && r.requestInfo.Admin != nil
+}
+
+// encryptionKey returns the records encryption key of the resolver dao (if any).
+func (r *RecordFieldResolver) encryptionKey() string {
+	if provider, ok := r.dao.(encryptionKeyProvider); ok {
+		return provider.EncryptionKey()
+	}
+
+	return ""
+}
+
 func (r *RecordFieldResolver) loadCollection(collectionNameOrId string) (*models.Collection, error) {
 	// return already loaded
 	for _, collection := range r.loadedCollections {
@@ -6,11 +6,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/resolvers"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 func TestRecordFieldResolverUpdateQuery(t *testing.T) {
@@ -554,3 +557,282 @@ func TestRecordFieldResolverResolveStaticRequestInfoFields(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordFieldResolverSetSearch(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// without search fields
+	r := resolvers.NewRecordFieldResolver(app.Dao(), collection, nil, false)
+	if err := r.SetSearch("test"); err == nil {
+		t.Fatal("Expected error for collection without search fields")
+	}
+	if _, err := r.Resolve("@rank"); err == nil {
+		t.Fatal("Expected @rank resolve error without full-text search")
+	}
+
+	collection.SetOptions(map[string]any{"searchFields": []string{"title"}})
+
+	// without search terms
+	r = resolvers.NewRecordFieldResolver(app.Dao(), collection, nil, false)
+	if err := r.SetSearch(` "*"- `); err == nil {
+		t.Fatal("Expected error for search text without terms")
+	}
+
+	// with search terms
+	if err := r.SetSearch(`Hello "wor`); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := r.Resolve("@rank")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := app.Dao().RecordQuery(collection)
+	if err := r.UpdateQuery(query); err != nil {
+		t.Fatal(err)
+	}
+	query.OrderBy(result.Identifier + " ASC")
+
+	searchTable := collection.SearchTableName()
+
+	expectedQuery := "SELECT `demo2`.* FROM `demo2` INNER JOIN {{" + searchTable + "}} {{__fts}} ON [[__fts.id]] = [[demo2.id]] WHERE [[__fts." + searchTable + "]] MATCH {:__search} ORDER BY [[__fts.rank]] ASC"
+
+	if rawQuery := query.Build().SQL(); rawQuery != expectedQuery {
+		t.Fatalf("Expected query\n %v \ngot:\n %v", expectedQuery, rawQuery)
+	}
+
+	expectedSearch := `"hello"* "wor"*`
+	if v := query.Build().Params()["__search"]; v != expectedSearch {
+		t.Fatalf("Expected search param %q, got %v", expectedSearch, v)
+	}
+}
+
+func TestRecordFieldResolverResolveEncryptedFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Dao().SetEncryptionKey("abcdabcdabcdabcdabcdabcdabcdabcd")
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "secret1",
+		Type: schema.FieldTypeEncrypted,
+	})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "secret2",
+		Type:    schema.FieldTypeEncrypted,
+		Options: &schema.EncryptedOptions{BlindIndex: true},
+	})
+
+	r := resolvers.NewRecordFieldResolver(app.Dao(), collection, nil, true)
+
+	if _, err := r.Resolve("secret1"); err == nil {
+		t.Fatal("Expected error for encrypted field without blind index")
+	}
+
+	result, err := r.Resolve("secret2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedIdentifier := "substr([[demo2.secret2]], 1, 64)"
+	if result.Identifier != expectedIdentifier {
+		t.Fatalf("Expected identifier %q, got %q", expectedIdentifier, result.Identifier)
+	}
+
+	if result.EqualityValueTransform == nil {
+		t.Fatal("Expected EqualityValueTransform to be set")
+	}
+
+	expectedHash := models.FieldBlindIndex("test", app.Dao().EncryptionKey())
+	if v := result.EqualityValueTransform("test"); v != expectedHash {
+		t.Fatalf("Expected transformed value %q, got %v", expectedHash, v)
+	}
+
+	// filter
+	expr, err := search.FilterData("secret2 = 'test'").BuildExpr(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := dbx.Params{}
+	rawExpr := expr.Build(app.Dao().DB().(*dbx.DB), params)
+	if !strings.Contains(rawExpr, expectedIdentifier) {
+		t.Fatalf("Expected %q to contain %q", rawExpr, expectedIdentifier)
+	}
+	var found bool
+	for _, v := range params {
+		if v == expectedHash {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected the blind index hash in the filter params, got %v", params)
+	}
+
+	// non-equality operators and non-literal operands are not allowed
+	invalidFilters := []string{
+		"secret2 ~ 'test'",
+		"secret2 > 'test'",
+		"secret2 = title",
+	}
+	for _, f := range invalidFilters {
+		if _, err := search.FilterData(f).BuildExpr(r); err == nil {
+			t.Errorf("Expected filter %q to fail", f)
+		}
+	}
+
+	// sort
+	if _, err := (&search.SortField{Name: "secret2", Direction: search.SortAsc}).BuildExpr(r); err == nil {
+		t.Fatal("Expected sort by encrypted field to fail")
+	}
+}
+
+func TestRecordFieldResolverResolveDecimalFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "price",
+		Type:    schema.FieldTypeDecimal,
+		Options: &schema.DecimalOptions{Precision: 10, Scale: 2},
+	})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "qty",
+		Type:    schema.FieldTypeDecimal,
+		Options: &schema.DecimalOptions{Precision: 5, Scale: 0},
+	})
+
+	r := resolvers.NewRecordFieldResolver(app.Dao(), collection, nil, true)
+
+	scenarios := []struct {
+		fieldName          string
+		expectedIdentifier string
+		expectedFallback   string
+	}{
+		{"price", "[[demo2.price]]", "([[demo2.price]] / 1e2)"},
+		{"qty", "[[demo2.qty]]", ""},
+	}
+
+	for _, s := range scenarios {
+		result, err := r.Resolve(s.fieldName)
+		if err != nil {
+			t.Fatalf("[%s] %v", s.fieldName, err)
+		}
+
+		if result.Identifier != s.expectedIdentifier {
+			t.Fatalf("[%s] Expected identifier %q, got %q", s.fieldName, s.expectedIdentifier, result.Identifier)
+		}
+
+		var fallback string
+		if result.ValueFallback != nil {
+			fallback = result.ValueFallback.Identifier
+		}
+		if fallback != s.expectedFallback {
+			t.Fatalf("[%s] Expected fallback identifier %q, got %q", s.fieldName, s.expectedFallback, fallback)
+		}
+	}
+
+	// literal values transform
+	// ---
+	result, err := r.Resolve("price")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valueScenarios := []struct {
+		value    any
+		expected any
+	}{
+		{nil, nil},
+		{"", ""},
+		{"abc", "abc"},
+		{12.5, int64(1250)},
+		{"-0.01", int64(-1)},
+		{"12.505", 1250.5},
+		{3, int64(300)},
+	}
+
+	for _, s := range valueScenarios {
+		if v := result.ValueTransform(s.value); v != s.expected {
+			t.Fatalf("[%v] Expected transformed value %v (%T), got %v (%T)", s.value, s.expected, s.expected, v, v)
+		}
+	}
+
+	// aggregate values transform
+	// ---
+	sum, _ := result.AggregateValueTransform(int64(123456789012345)).(types.Decimal)
+	if str := sum.String(); str != "1234567890123.45" {
+		t.Fatalf("Expected aggregate value %q, got %q", "1234567890123.45", str)
+	}
+
+	if v := result.AggregateValueTransform(nil); v != nil {
+		t.Fatalf("Expected nil aggregate value, got %v", v)
+	}
+}
+
+func TestRecordFieldResolverResolveRestrictedFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users.Schema.GetFieldByName("name").ViewRule = "id = @request.auth.id"
+	if err := app.Dao().SaveCollection(users); err != nil {
+		t.Fatal(err)
+	}
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name              string
+		collection        *models.Collection
+		requestInfo       *models.RequestInfo
+		allowHiddenFields bool
+		fieldName         string
+		expectError       bool
+	}{
+		{"guest restricted field", users, &models.RequestInfo{}, false, "name", true},
+		{"guest restricted relation field", demo1, &models.RequestInfo{}, false, "rel_many.name", true},
+		{"guest restricted field via @collection", demo1, &models.RequestInfo{}, false, "@collection.users.name", false},
+		{"guest unrestricted field", users, &models.RequestInfo{}, false, "username", false},
+		{"hidden fields allowed", users, &models.RequestInfo{}, true, "name", false},
+		{"admin restricted field", users, &models.RequestInfo{Admin: admin}, false, "name", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			r := resolvers.NewRecordFieldResolver(app.Dao(), s.collection, s.requestInfo, s.allowHiddenFields)
+
+			_, err := r.Resolve(s.fieldName)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
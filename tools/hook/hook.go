@@ -78,6 +78,14 @@ func (h *Hook[T]) RemoveAll() {
 	h.handlers = nil
 }
 
+// Length returns the total number of registered hook handlers.
+func (h *Hook[T]) Length() int {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	return len(h.handlers)
+}
+
 // Trigger executes all registered hook handlers one by one
 // with the specified `data` as an argument.
 //
@@ -89,6 +89,21 @@ func TestHookRemoveAll(t *testing.T) {
 	}
 }
 
+func TestHookLength(t *testing.T) {
+	h := Hook[int]{}
+
+	if total := h.Length(); total != 0 {
+		t.Fatalf("Expected no handlers, got %d", total)
+	}
+
+	h.Add(func(data int) error { return nil })
+	h.PreAdd(func(data int) error { return nil })
+
+	if total := h.Length(); total != 2 {
+		t.Fatalf("Expected %d handlers, got %d", 2, total)
+	}
+}
+
 func TestHookTrigger(t *testing.T) {
 	err1 := errors.New("demo")
 	err2 := errors.New("demo")
@@ -83,3 +83,40 @@ func Snakecase(str string) string {
 
 	return strings.ToLower(result.String())
 }
+
+// Slugify converts the provided text into a lowercase url friendly
+// slug (eg. "Žluťoučký kůň & Co." -> "zlutoucky-kun-co").
+//
+// The common latin diacritics, ligatures and the cyrillic and greek
+// letters are transliterated into their closest ascii equivalent.
+// All other non alphanumeric characters are treated as words separator.
+func Slugify(str string) string {
+	var result strings.Builder
+
+	var pendingSeparator bool
+
+	for _, c := range strings.ToLower(str) {
+		var replacement string
+
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			replacement = string(c)
+		} else if t, ok := transliterations[c]; ok {
+			if t == "" {
+				continue // silent letter (eg. the cyrillic soft sign)
+			}
+			replacement = t
+		} else {
+			pendingSeparator = true
+			continue
+		}
+
+		if pendingSeparator && result.Len() > 0 {
+			result.WriteString("-")
+		}
+		pendingSeparator = false
+
+		result.WriteString(replacement)
+	}
+
+	return result.String()
+}
@@ -132,3 +132,29 @@ func TestSnakecase(t *testing.T) {
 		}
 	}
 }
+
+func TestSlugify(t *testing.T) {
+	scenarios := []struct {
+		val      string
+		expected string
+	}{
+		{"", ""},
+		{"  ", ""},
+		{"!@#$%^", ""},
+		{"Hello World", "hello-world"},
+		{"  --Hello,   World!-- ", "hello-world"},
+		{"snake_case and 123", "snake-case-and-123"},
+		{"Žluťoučký kůň & Co.", "zlutoucky-kun-co"},
+		{"Straße Æsir Œuvre", "strasse-aesir-oeuvre"},
+		{"Привет, Мир", "privet-mir"},
+		{"Пьер", "per"},
+		{"Καλημέρα κόσμε", "kalimera-kosme"},
+		{"日本語 test", "test"},
+	}
+
+	for i, scenario := range scenarios {
+		if result := inflector.Slugify(scenario.val); result != scenario.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, scenario.expected, result)
+		}
+	}
+}
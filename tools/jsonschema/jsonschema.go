@@ -0,0 +1,816 @@
+// Package jsonschema implements a minimal JSON Schema validator
+// supporting the most commonly used draft 7 and 2020-12 keywords.
+//
+// The supported keywords are:
+//   - $ref (local json pointers only, eg. "#/$defs/item"), $defs, definitions
+//   - type, enum, const
+//   - allOf, anyOf, oneOf, not
+//   - properties, patternProperties, additionalProperties, required, minProperties, maxProperties
+//   - items, prefixItems, minItems, maxItems, uniqueItems
+//   - minLength, maxLength, pattern, format (date-time, date, time, email, uri, uuid, ipv4, ipv6)
+//   - minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf
+//
+// All other keywords are ignored (aka. treated as annotations).
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// maxValidationDepth limits the nested schemas evaluation depth
+// (eg. to prevent infinite loops with recursive $ref).
+const maxValidationDepth = 100
+
+// maxValidationEvaluations limits the total number of the schema nodes
+// evaluations of a single validation (eg. to prevent the exponential
+// evaluation time of recursive anyOf/oneOf combinations).
+const maxValidationEvaluations = 1000000
+
+var (
+	uuidRegex         = regexp.MustCompile(`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	plainPathKeyRegex = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+)
+
+var supportedTypes = []string{"null", "boolean", "object", "array", "number", "integer", "string"}
+
+// ValidationError defines a single JSON Schema validation error.
+type ValidationError struct {
+	// Path is the location of the invalid value (eg. "$.items[0].name").
+	Path string
+
+	// Keyword is the name of the failed schema keyword (eg. "type", "required").
+	Keyword string
+
+	// Message is the human readable error message.
+	Message string
+}
+
+// Error implements the [error] interface.
+func (e *ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Schema defines a compiled JSON Schema document.
+type Schema struct {
+	root *node
+}
+
+// Compile parses and compiles the provided raw JSON Schema document.
+func Compile(raw []byte) (*Schema, error) {
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("invalid json: %w", err)
+	}
+
+	c := &compiler{doc: doc, refs: map[string]*node{}}
+
+	root, err := c.compile(doc, "#")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.resolveRefs(); err != nil {
+		return nil, err
+	}
+
+	return &Schema{root: root}, nil
+}
+
+// Validate validates the provided decoded json value
+// (eg. the result of [json.Unmarshal] into any) against the schema.
+//
+// Returns nil if there are no errors.
+func (s *Schema) Validate(value any) []*ValidationError {
+	v := &validator{state: &validatorState{}}
+
+	v.validate(s.root, value, "$", 0)
+
+	// the collected errors could be incomplete
+	if v.state.exceeded {
+		return []*ValidationError{{
+			Path:    "$",
+			Keyword: "$ref",
+			Message: "The schema evaluation is too complex.",
+		}}
+	}
+
+	return v.errs
+}
+
+// ValidateJSON decodes and validates the provided raw json value against the schema.
+func (s *Schema) ValidateJSON(raw []byte) ([]*ValidationError, error) {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	return s.Validate(value), nil
+}
+
+// -------------------------------------------------------------------
+
+type patternNode struct {
+	pattern *regexp.Regexp
+	schema  *node
+}
+
+type node struct {
+	// boolean schema (true - always valid, false - always invalid)
+	boolean *bool
+
+	ref     string
+	refNode *node
+
+	types    []string
+	enum     []any
+	hasConst bool
+	constVal any
+
+	allOf []*node
+	anyOf []*node
+	oneOf []*node
+	not   *node
+
+	properties           map[string]*node
+	patternProperties    []*patternNode
+	additionalProperties *node
+	required             []string
+	minProperties        *int
+	maxProperties        *int
+
+	items       *node
+	prefixItems []*node
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+	format    string
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+}
+
+type compiler struct {
+	doc  any
+	refs map[string]*node // pointer -> resolved node
+	all  []*node
+}
+
+func (c *compiler) compile(raw any, location string) (*node, error) {
+	n := &node{}
+	c.all = append(c.all, n)
+
+	if b, ok := raw.(bool); ok {
+		n.boolean = &b
+		return n, nil
+	}
+
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: the schema must be an object or boolean", location)
+	}
+
+	var err error
+
+	if v, ok := obj["$ref"]; ok {
+		if n.ref, ok = v.(string); !ok || !strings.HasPrefix(n.ref, "#") {
+			return nil, fmt.Errorf("%s/$ref: only local json pointer references are supported (eg. \"#/$defs/item\")", location)
+		}
+	}
+
+	if v, ok := obj["type"]; ok {
+		switch t := v.(type) {
+		case string:
+			n.types = []string{t}
+		case []any:
+			for _, item := range t {
+				str, _ := item.(string)
+				n.types = append(n.types, str)
+			}
+		}
+		if len(n.types) == 0 {
+			return nil, fmt.Errorf("%s/type: must be a string or array of strings", location)
+		}
+		for _, t := range n.types {
+			if !list.ExistInSlice(t, supportedTypes) {
+				return nil, fmt.Errorf("%s/type: unknown type %q", location, t)
+			}
+		}
+	}
+
+	if v, ok := obj["enum"]; ok {
+		if n.enum, ok = v.([]any); !ok {
+			return nil, fmt.Errorf("%s/enum: must be an array", location)
+		}
+	}
+
+	if v, ok := obj["const"]; ok {
+		n.hasConst = true
+		n.constVal = v
+	}
+
+	for keyword, target := range map[string]*[]*node{"allOf": &n.allOf, "anyOf": &n.anyOf, "oneOf": &n.oneOf} {
+		if *target, err = c.compileList(obj, keyword, location); err != nil {
+			return nil, err
+		}
+	}
+
+	if n.not, err = c.compileChild(obj, "not", location); err != nil {
+		return nil, err
+	}
+
+	// object
+	if v, ok := obj["properties"]; ok {
+		props, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/properties: must be an object", location)
+		}
+		n.properties = make(map[string]*node, len(props))
+		for name, prop := range props {
+			if n.properties[name], err = c.compile(prop, location+"/properties/"+escapePointer(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if v, ok := obj["patternProperties"]; ok {
+		props, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/patternProperties: must be an object", location)
+		}
+		for _, pattern := range sortedKeys(props) {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s/patternProperties: invalid pattern %q", location, pattern)
+			}
+			child, err := c.compile(props[pattern], location+"/patternProperties/"+escapePointer(pattern))
+			if err != nil {
+				return nil, err
+			}
+			n.patternProperties = append(n.patternProperties, &patternNode{pattern: re, schema: child})
+		}
+	}
+
+	if n.additionalProperties, err = c.compileChild(obj, "additionalProperties", location); err != nil {
+		return nil, err
+	}
+
+	if v, ok := obj["required"]; ok {
+		names, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/required: must be an array of strings", location)
+		}
+		for _, item := range names {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s/required: must be an array of strings", location)
+			}
+			n.required = append(n.required, str)
+		}
+	}
+
+	// array
+	if v, ok := obj["items"]; ok {
+		if _, isList := v.([]any); isList {
+			// draft 7 tuple validation
+			if n.prefixItems, err = c.compileList(obj, "items", location); err != nil {
+				return nil, err
+			}
+		} else if n.items, err = c.compile(v, location+"/items"); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := obj["prefixItems"]; ok {
+		if n.prefixItems, err = c.compileList(obj, "prefixItems", location); err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := obj["uniqueItems"]; ok {
+		if n.uniqueItems, ok = v.(bool); !ok {
+			return nil, fmt.Errorf("%s/uniqueItems: must be a boolean", location)
+		}
+	}
+
+	// string
+	if v, ok := obj["pattern"]; ok {
+		str, _ := v.(string)
+		if n.pattern, err = regexp.Compile(str); err != nil {
+			return nil, fmt.Errorf("%s/pattern: invalid pattern %q", location, str)
+		}
+	}
+
+	if v, ok := obj["format"]; ok {
+		if n.format, ok = v.(string); !ok {
+			return nil, fmt.Errorf("%s/format: must be a string", location)
+		}
+	}
+
+	// counters
+	for keyword, target := range map[string]**int{
+		"minProperties": &n.minProperties,
+		"maxProperties": &n.maxProperties,
+		"minItems":      &n.minItems,
+		"maxItems":      &n.maxItems,
+		"minLength":     &n.minLength,
+		"maxLength":     &n.maxLength,
+	} {
+		if v, ok := obj[keyword]; ok {
+			f, ok := v.(float64)
+			if !ok || f < 0 || f != math.Trunc(f) {
+				return nil, fmt.Errorf("%s/%s: must be a non-negative integer", location, keyword)
+			}
+			i := int(f)
+			*target = &i
+		}
+	}
+
+	// numbers
+	for keyword, target := range map[string]**float64{
+		"minimum":          &n.minimum,
+		"maximum":          &n.maximum,
+		"exclusiveMinimum": &n.exclusiveMinimum,
+		"exclusiveMaximum": &n.exclusiveMaximum,
+		"multipleOf":       &n.multipleOf,
+	} {
+		if v, ok := obj[keyword]; ok {
+			f, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s/%s: must be a number", location, keyword)
+			}
+			*target = &f
+		}
+	}
+	if n.multipleOf != nil && *n.multipleOf <= 0 {
+		return nil, fmt.Errorf("%s/multipleOf: must be greater than 0", location)
+	}
+
+	return n, nil
+}
+
+func (c *compiler) compileChild(obj map[string]any, keyword string, location string) (*node, error) {
+	v, ok := obj[keyword]
+	if !ok {
+		return nil, nil
+	}
+
+	return c.compile(v, location+"/"+keyword)
+}
+
+func (c *compiler) compileList(obj map[string]any, keyword string, location string) ([]*node, error) {
+	v, ok := obj[keyword]
+	if !ok {
+		return nil, nil
+	}
+
+	items, ok := v.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("%s/%s: must be a non-empty array", location, keyword)
+	}
+
+	result := make([]*node, len(items))
+	for i, item := range items {
+		child, err := c.compile(item, location+"/"+keyword+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		result[i] = child
+	}
+
+	return result, nil
+}
+
+// resolveRefs resolves the $ref nodes of all compiled schemas.
+func (c *compiler) resolveRefs() error {
+	// note: c.all could grow while resolving
+	for i := 0; i < len(c.all); i++ {
+		n := c.all[i]
+		if n.ref == "" {
+			continue
+		}
+
+		if resolved, ok := c.refs[n.ref]; ok {
+			n.refNode = resolved
+			continue
+		}
+
+		target, err := lookupPointer(c.doc, n.ref)
+		if err != nil {
+			return err
+		}
+
+		resolved, err := c.compile(target, n.ref)
+		if err != nil {
+			return err
+		}
+
+		c.refs[n.ref] = resolved
+		n.refNode = resolved
+	}
+
+	return nil
+}
+
+// lookupPointer returns the value of doc at the specified json pointer fragment (eg. "#/$defs/item").
+func lookupPointer(doc any, ref string) (any, error) {
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return doc, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid $ref %q", ref)
+	}
+
+	current := doc
+
+	for _, part := range strings.Split(pointer[1:], "/") {
+		part, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid $ref %q", ref)
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+
+		switch v := current.(type) {
+		case map[string]any:
+			var ok bool
+			if current, ok = v[part]; !ok {
+				return nil, fmt.Errorf("unresolvable $ref %q", ref)
+			}
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("unresolvable $ref %q", ref)
+			}
+			current = v[i]
+		default:
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+	}
+
+	return current, nil
+}
+
+// -------------------------------------------------------------------
+
+// validatorState is the shared state of a validation
+// and its nested (eg. anyOf) sub validations.
+type validatorState struct {
+	evaluations int
+	exceeded    bool
+}
+
+type validator struct {
+	state *validatorState
+	errs  []*ValidationError
+}
+
+func (v *validator) addError(path string, keyword string, message string) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Keyword: keyword, Message: message})
+}
+
+// isValid checks whether value is valid against n without collecting the errors.
+func (v *validator) isValid(n *node, value any, path string, depth int) bool {
+	sub := &validator{state: v.state}
+	sub.validate(n, value, path, depth)
+
+	return len(sub.errs) == 0
+}
+
+func (v *validator) validate(n *node, value any, path string, depth int) {
+	if v.state.exceeded {
+		return
+	}
+
+	v.state.evaluations++
+	if v.state.evaluations > maxValidationEvaluations {
+		v.state.exceeded = true
+		return
+	}
+
+	if depth > maxValidationDepth {
+		v.addError(path, "$ref", "The schema nesting is too deep.")
+		return
+	}
+
+	if n.boolean != nil {
+		if !*n.boolean {
+			v.addError(path, "false", "No value is allowed.")
+		}
+		return
+	}
+
+	if n.refNode != nil {
+		v.validate(n.refNode, value, path, depth+1)
+	}
+
+	if len(n.types) > 0 && !matchesAnyType(value, n.types) {
+		v.addError(path, "type", fmt.Sprintf("Must be of type %s.", strings.Join(n.types, " or ")))
+		return // no need to check the other type specific keywords
+	}
+
+	if n.enum != nil && !containsEqual(n.enum, value) {
+		v.addError(path, "enum", "Must be one of the allowed values.")
+	}
+
+	if n.hasConst && !isEqual(n.constVal, value) {
+		v.addError(path, "const", "Must be equal to the constant value.")
+	}
+
+	for _, child := range n.allOf {
+		v.validate(child, value, path, depth+1)
+	}
+
+	if len(n.anyOf) > 0 {
+		var matched bool
+		for _, child := range n.anyOf {
+			if v.isValid(child, value, path, depth+1) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.addError(path, "anyOf", "Must match at least one of the allowed schemas.")
+		}
+	}
+
+	if len(n.oneOf) > 0 {
+		var matched int
+		for _, child := range n.oneOf {
+			if v.isValid(child, value, path, depth+1) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.addError(path, "oneOf", "Must match exactly one of the allowed schemas.")
+		}
+	}
+
+	if n.not != nil && v.isValid(n.not, value, path, depth+1) {
+		v.addError(path, "not", "Must not match the disallowed schema.")
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		v.validateObject(n, val, path, depth)
+	case []any:
+		v.validateArray(n, val, path, depth)
+	case string:
+		v.validateString(n, val, path)
+	case float64:
+		v.validateNumber(n, val, path)
+	}
+}
+
+func (v *validator) validateObject(n *node, obj map[string]any, path string, depth int) {
+	for _, name := range n.required {
+		if _, ok := obj[name]; !ok {
+			v.addError(joinPath(path, name), "required", "Missing required value.")
+		}
+	}
+
+	if n.minProperties != nil && len(obj) < *n.minProperties {
+		v.addError(path, "minProperties", fmt.Sprintf("Must have at least %d properties.", *n.minProperties))
+	}
+
+	if n.maxProperties != nil && len(obj) > *n.maxProperties {
+		v.addError(path, "maxProperties", fmt.Sprintf("Must have no more than %d properties.", *n.maxProperties))
+	}
+
+	for _, name := range sortedKeys(obj) {
+		propPath := joinPath(path, name)
+
+		var matched bool
+
+		if prop, ok := n.properties[name]; ok {
+			matched = true
+			v.validate(prop, obj[name], propPath, depth+1)
+		}
+
+		for _, pp := range n.patternProperties {
+			if pp.pattern.MatchString(name) {
+				matched = true
+				v.validate(pp.schema, obj[name], propPath, depth+1)
+			}
+		}
+
+		if !matched && n.additionalProperties != nil {
+			if n.additionalProperties.boolean != nil && !*n.additionalProperties.boolean {
+				v.addError(propPath, "additionalProperties", "Unknown property.")
+			} else {
+				v.validate(n.additionalProperties, obj[name], propPath, depth+1)
+			}
+		}
+	}
+}
+
+func (v *validator) validateArray(n *node, arr []any, path string, depth int) {
+	if n.minItems != nil && len(arr) < *n.minItems {
+		v.addError(path, "minItems", fmt.Sprintf("Must have at least %d items.", *n.minItems))
+	}
+
+	if n.maxItems != nil && len(arr) > *n.maxItems {
+		v.addError(path, "maxItems", fmt.Sprintf("Must have no more than %d items.", *n.maxItems))
+	}
+
+	if n.uniqueItems {
+		for i := 1; i < len(arr); i++ {
+			if containsEqual(arr[:i], arr[i]) {
+				v.addError(path, "uniqueItems", "Must contain only unique items.")
+				break
+			}
+		}
+	}
+
+	for i, item := range arr {
+		itemPath := path + "[" + strconv.Itoa(i) + "]"
+
+		if i < len(n.prefixItems) {
+			v.validate(n.prefixItems[i], item, itemPath, depth+1)
+		} else if n.items != nil {
+			v.validate(n.items, item, itemPath, depth+1)
+		}
+	}
+}
+
+func (v *validator) validateString(n *node, str string, path string) {
+	length := utf8.RuneCountInString(str)
+
+	if n.minLength != nil && length < *n.minLength {
+		v.addError(path, "minLength", fmt.Sprintf("Must be at least %d character(s).", *n.minLength))
+	}
+
+	if n.maxLength != nil && length > *n.maxLength {
+		v.addError(path, "maxLength", fmt.Sprintf("Must be no more than %d character(s).", *n.maxLength))
+	}
+
+	if n.pattern != nil && !n.pattern.MatchString(str) {
+		v.addError(path, "pattern", "Invalid value format.")
+	}
+
+	if n.format != "" && !isValidFormat(n.format, str) {
+		v.addError(path, "format", fmt.Sprintf("Must be a valid %s.", n.format))
+	}
+}
+
+func (v *validator) validateNumber(n *node, num float64, path string) {
+	if n.minimum != nil && num < *n.minimum {
+		v.addError(path, "minimum", fmt.Sprintf("Must be no less than %v.", *n.minimum))
+	}
+
+	if n.maximum != nil && num > *n.maximum {
+		v.addError(path, "maximum", fmt.Sprintf("Must be no greater than %v.", *n.maximum))
+	}
+
+	if n.exclusiveMinimum != nil && num <= *n.exclusiveMinimum {
+		v.addError(path, "exclusiveMinimum", fmt.Sprintf("Must be greater than %v.", *n.exclusiveMinimum))
+	}
+
+	if n.exclusiveMaximum != nil && num >= *n.exclusiveMaximum {
+		v.addError(path, "exclusiveMaximum", fmt.Sprintf("Must be less than %v.", *n.exclusiveMaximum))
+	}
+
+	if n.multipleOf != nil {
+		quo := num / *n.multipleOf
+		if math.Abs(quo-math.Round(quo)) > 1e-9 {
+			v.addError(path, "multipleOf", fmt.Sprintf("Must be a multiple of %v.", *n.multipleOf))
+		}
+	}
+}
+
+// -------------------------------------------------------------------
+
+func matchesAnyType(value any, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if f, ok := value.(float64); ok && f == math.Trunc(f) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isValidFormat(format string, str string) bool {
+	var err error
+
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, str)
+	case "date":
+		_, err = time.Parse(time.DateOnly, str)
+	case "time":
+		_, err = time.Parse("15:04:05Z07:00", str)
+	case "email":
+		var addr *mail.Address
+		if addr, err = mail.ParseAddress(str); err == nil && addr.Address != str {
+			err = errors.New("invalid email")
+		}
+	case "uri":
+		var u *url.URL
+		if u, err = url.Parse(str); err == nil && u.Scheme == "" {
+			err = errors.New("missing uri scheme")
+		}
+	case "uuid":
+		if !uuidRegex.MatchString(str) {
+			err = errors.New("invalid uuid")
+		}
+	case "ipv4":
+		if ip := net.ParseIP(str); ip == nil || ip.To4() == nil {
+			err = errors.New("invalid ipv4")
+		}
+	case "ipv6":
+		if ip := net.ParseIP(str); ip == nil || ip.To4() != nil {
+			err = errors.New("invalid ipv6")
+		}
+	}
+
+	return err == nil
+}
+
+func isEqual(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func containsEqual(items []any, value any) bool {
+	for _, item := range items {
+		if isEqual(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// joinPath appends the property name to the provided value path
+// (eg. "$.a" + "b" -> "$.a.b" and "$.a" + "b c" -> `$.a["b c"]`).
+func joinPath(path string, name string) string {
+	if plainPathKeyRegex.MatchString(name) {
+		return path + "." + name
+	}
+
+	return path + "[" + strconv.Quote(name) + "]"
+}
+
+// escapePointer escapes the provided json pointer reference token.
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
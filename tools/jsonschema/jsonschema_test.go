@@ -0,0 +1,250 @@
+package jsonschema_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/jsonschema"
+)
+
+func TestCompile(t *testing.T) {
+	scenarios := []struct {
+		schema      string
+		expectError bool
+	}{
+		{``, true},
+		{`"test"`, true},
+		{`123`, true},
+		{`true`, false},
+		{`false`, false},
+		{`{}`, false},
+		{`{"type":"missing"}`, true},
+		{`{"type":["string", 1]}`, true},
+		{`{"type":["string", "null"]}`, false},
+		{`{"enum":"test"}`, true},
+		{`{"properties":[]}`, true},
+		{`{"properties":{"a":1}}`, true},
+		{`{"required":"a"}`, true},
+		{`{"pattern":"("}`, true},
+		{`{"patternProperties":{"(":{}}}`, true},
+		{`{"minLength":-1}`, true},
+		{`{"minLength":1.5}`, true},
+		{`{"minimum":"1"}`, true},
+		{`{"multipleOf":0}`, true},
+		{`{"anyOf":[]}`, true},
+		{`{"$ref":"https://example.com/schema.json"}`, true},
+		{`{"$ref":"#/$defs/missing"}`, true},
+		{`{"$ref":"#/$defs/item","$defs":{"item":{"type":"string"}}}`, false},
+		{`{"$ref":"#"}`, false},
+		{`{"unknownKeyword":123,"title":"test"}`, false},
+	}
+
+	for i, s := range scenarios {
+		_, err := jsonschema.Compile([]byte(s.schema))
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema, err := jsonschema.Compile([]byte(`{
+		"type": "object",
+		"required": ["name", "theme"],
+		"additionalProperties": false,
+		"properties": {
+			"name":    {"type": "string", "minLength": 2, "maxLength": 5, "pattern": "^[a-z]+$"},
+			"age":     {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+			"score":   {"type": "number", "multipleOf": 0.5},
+			"email":   {"type": "string", "format": "email"},
+			"created": {"type": "string", "format": "date-time"},
+			"role":    {"enum": ["admin", "user"]},
+			"version": {"const": 1},
+			"tags":    {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true},
+			"point":   {"type": "array", "prefixItems": [{"type": "number"}, {"type": "number"}], "minItems": 2},
+			"theme":   {"$ref": "#/$defs/theme"},
+			"contact": {"oneOf": [{"required": ["email"]}, {"required": ["phone"]}]},
+			"nick":    {"anyOf": [{"type": "null"}, {"type": "string", "minLength": 1}]},
+			"notNull": {"not": {"type": "null"}},
+			"labels":  {"type": "object", "patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": {"type": "number"}}
+		},
+		"$defs": {
+			"theme": {
+				"type": "object",
+				"required": ["color"],
+				"properties": {
+					"color": {"type": "string"},
+					"dark mode": {"type": "boolean"}
+				}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name     string
+		value    string
+		expected []string // path:keyword
+	}{
+		{
+			"invalid root type",
+			`[]`,
+			[]string{"$:type"},
+		},
+		{
+			"missing required",
+			`{}`,
+			[]string{"$.name:required", "$.theme:required"},
+		},
+		{
+			"valid minimal",
+			`{"name":"abc","theme":{"color":"red"}}`,
+			nil,
+		},
+		{
+			"valid full",
+			`{
+				"name":"abc",
+				"age":30,
+				"score":1.5,
+				"email":"test@example.com",
+				"created":"2024-01-01T10:00:00Z",
+				"role":"admin",
+				"version":1,
+				"tags":["a","b"],
+				"point":[1.5,2],
+				"theme":{"color":"red","dark mode":true},
+				"contact":{"phone":"123"},
+				"nick":null,
+				"notNull":0,
+				"labels":{"x-test":"a","other":1}
+			}`,
+			nil,
+		},
+		{
+			"invalid values",
+			`{
+				"name":"A",
+				"age":30.5,
+				"score":1.2,
+				"email":"invalid",
+				"created":"2024-01-01",
+				"role":"guest",
+				"version":2,
+				"tags":["a","a","b"],
+				"point":[1,"2"],
+				"theme":{"dark mode":"yes"},
+				"contact":{"email":"a","phone":"b"},
+				"nick":"",
+				"notNull":null,
+				"labels":{"x-test":1,"other":"a"},
+				"unknown":1
+			}`,
+			[]string{
+				"$.name:minLength",
+				"$.name:pattern",
+				"$.age:type",
+				"$.score:multipleOf",
+				"$.email:format",
+				"$.created:format",
+				"$.role:enum",
+				"$.version:const",
+				"$.tags:maxItems",
+				"$.tags:uniqueItems",
+				"$.point[1]:type",
+				"$.theme.color:required",
+				`$.theme["dark mode"]:type`,
+				"$.contact:oneOf",
+				"$.nick:anyOf",
+				"$.notNull:not",
+				"$.labels.other:type",
+				`$.labels["x-test"]:type`,
+				"$.unknown:additionalProperties",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			errs, err := schema.ValidateJSON([]byte(s.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := make([]string, len(errs))
+			for i, e := range errs {
+				result[i] = e.Path + ":" + e.Keyword
+			}
+
+			if len(result) != len(s.expected) {
+				t.Fatalf("Expected errors\n%v\ngot\n%v", s.expected, result)
+			}
+
+			for _, expected := range s.expected {
+				if !strings.Contains(strings.Join(result, "\n")+"\n", expected+"\n") {
+					t.Fatalf("Missing expected error %q in\n%v", expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaValidateRecursiveRef(t *testing.T) {
+	schema, err := jsonschema.Compile([]byte(`{
+		"type": "object",
+		"properties": {
+			"value":    {"type": "number"},
+			"children": {"type": "array", "items": {"$ref": "#"}}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs, err := schema.ValidateJSON([]byte(`{"value":1,"children":[{"value":2,"children":[{"value":"3"}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs) != 1 || errs[0].Path != "$.children[0].children[0].value" {
+		t.Fatalf("Expected single nested value error, got %v", errs)
+	}
+
+	// infinite recursion
+	loop, err := jsonschema.Compile([]byte(`{"$ref":"#"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := loop.Validate(1); len(errs) != 1 || errs[0].Keyword != "$ref" {
+		t.Fatalf("Expected recursion limit error, got %v", errs)
+	}
+}
+
+func TestSchemaValidateRecursiveCombinators(t *testing.T) {
+	schema, err := jsonschema.Compile([]byte(`{
+		"$defs": {"a": {"oneOf": [{"$ref": "#/$defs/a"}, {"$ref": "#/$defs/a"}]}},
+		"$ref": "#/$defs/a"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan []*jsonschema.ValidationError, 1)
+	go func() {
+		done <- schema.Validate(1)
+	}()
+
+	select {
+	case errs := <-done:
+		if len(errs) != 1 || errs[0].Path != "$" || errs[0].Keyword != "$ref" {
+			t.Fatalf("Expected single evaluation limit error, got %v", errs)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Expected the validation to be aborted after exceeding the evaluations limit")
+	}
+}
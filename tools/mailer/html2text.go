@@ -20,7 +20,8 @@ var inlineTags = []string{
 	"sub", "sup", "em", "b", "u", "i",
 }
 
-// Very rudimentary auto HTML to Text mail body converter.
+// HTML2Text is a very rudimentary auto HTML to Text converter
+// (used for the mail plain text body and the editor fields plain text companion).
 //
 // Caveats:
 // - This method doesn't check for correctness of the HTML document.
@@ -29,7 +30,7 @@ var inlineTags = []string{
 // - Indentation is stripped (both tabs and spaces).
 // - Trailing spaces are preserved.
 // - Multiple consequence newlines are collapsed as one unless multiple <br> tags are used.
-func html2Text(htmlDocument string) (string, error) {
+func HTML2Text(htmlDocument string) (string, error) {
 	doc, err := html.Parse(strings.NewReader(htmlDocument))
 	if err != nil {
 		return "", err
@@ -93,7 +93,7 @@ func (c *SmtpClient) Send(m *Message) error {
 
 	if m.Text == "" {
 		// try to generate a plain text version of the HTML
-		if plain, err := html2Text(m.HTML); err == nil {
+		if plain, err := HTML2Text(m.HTML); err == nil {
 			yak.Plain().Set(plain)
 		}
 	} else {
@@ -0,0 +1,103 @@
+// Package ratelimit implements an in-memory requests rate limiter store.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// cleanupInterval is the min interval between the expired entries cleanups.
+const cleanupInterval = 1 * time.Minute
+
+type windowEntry struct {
+	hits   []time.Time // the time of the allowed hits within the window (oldest first)
+	window time.Duration
+}
+
+// SlidingWindow is a concurrent safe in-memory sliding window log rate limiter store.
+type SlidingWindow struct {
+	mux         sync.Mutex
+	entries     map[string]*windowEntry
+	lastCleanup time.Time
+}
+
+// NewSlidingWindow creates and returns a new empty SlidingWindow store.
+func NewSlidingWindow() *SlidingWindow {
+	return &SlidingWindow{
+		entries:     map[string]*windowEntry{},
+		lastCleanup: time.Now(),
+	}
+}
+
+// Allow registers a new hit for the specified key and reports
+// whether the hit is within the max allowed hits for the sliding window.
+//
+// If the hit is not allowed, it is not registered and the duration after
+// which the next hit will be allowed is also returned.
+func (s *SlidingWindow) Allow(key string, max int, window time.Duration) (bool, time.Duration) {
+	now := time.Now()
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if now.Sub(s.lastCleanup) >= cleanupInterval {
+		s.cleanup(now)
+	}
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &windowEntry{}
+		s.entries[key] = entry
+	}
+	entry.window = window
+
+	// remove the hits outside of the current window
+	windowStart := now.Add(-window)
+	expired := 0
+	for expired < len(entry.hits) && !entry.hits[expired].After(windowStart) {
+		expired++
+	}
+	entry.hits = entry.hits[expired:]
+
+	if len(entry.hits) >= max {
+		if max <= 0 {
+			return false, window
+		}
+
+		// the oldest hit that needs to expire to make room for a new one
+		return false, entry.hits[len(entry.hits)-max].Add(window).Sub(now)
+	}
+
+	entry.hits = append(entry.hits, now)
+
+	return true, 0
+}
+
+// Reset removes all registered hits.
+func (s *SlidingWindow) Reset() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.entries = map[string]*windowEntry{}
+}
+
+// Length returns the total number of the currently tracked keys.
+func (s *SlidingWindow) Length() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return len(s.entries)
+}
+
+// cleanup removes the entries without hits in their current window.
+//
+// NB! Expects the store to be already locked.
+func (s *SlidingWindow) cleanup(now time.Time) {
+	for key, entry := range s.entries {
+		if len(entry.hits) == 0 || !entry.hits[len(entry.hits)-1].Add(entry.window).After(now) {
+			delete(s.entries, key)
+		}
+	}
+
+	s.lastCleanup = now
+}
@@ -0,0 +1,111 @@
+package ratelimit_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+)
+
+func TestSlidingWindowAllow(t *testing.T) {
+	t.Parallel()
+
+	s := ratelimit.NewSlidingWindow()
+
+	window := 100 * time.Millisecond
+
+	for i := 0; i < 3; i++ {
+		if ok, retryAfter := s.Allow("a", 3, window); !ok || retryAfter != 0 {
+			t.Fatalf("[%d] Expected the hit to be allowed, got %v (%v)", i, ok, retryAfter)
+		}
+	}
+
+	ok, retryAfter := s.Allow("a", 3, window)
+	if ok {
+		t.Fatal("Expected the 4th hit to not be allowed")
+	}
+	if retryAfter <= 0 || retryAfter > window {
+		t.Fatalf("Expected retryAfter to be in the range (0, %v], got %v", window, retryAfter)
+	}
+
+	// other keys should not be affected
+	if ok, _ := s.Allow("b", 3, window); !ok {
+		t.Fatal("Expected the hit for key b to be allowed")
+	}
+
+	if total := s.Length(); total != 2 {
+		t.Fatalf("Expected %d tracked keys, got %d", 2, total)
+	}
+
+	// wait for the window to slide
+	time.Sleep(retryAfter + 10*time.Millisecond)
+
+	if ok, _ := s.Allow("a", 3, window); !ok {
+		t.Fatal("Expected the hit to be allowed after the window slide")
+	}
+}
+
+func TestSlidingWindowAllowZeroMax(t *testing.T) {
+	t.Parallel()
+
+	s := ratelimit.NewSlidingWindow()
+
+	ok, retryAfter := s.Allow("a", 0, time.Second)
+	if ok {
+		t.Fatal("Expected the hit to not be allowed")
+	}
+	if retryAfter != time.Second {
+		t.Fatalf("Expected retryAfter %v, got %v", time.Second, retryAfter)
+	}
+}
+
+func TestSlidingWindowReset(t *testing.T) {
+	t.Parallel()
+
+	s := ratelimit.NewSlidingWindow()
+
+	s.Allow("a", 1, time.Minute)
+	s.Allow("b", 1, time.Minute)
+
+	if ok, _ := s.Allow("a", 1, time.Minute); ok {
+		t.Fatal("Expected the hit to not be allowed")
+	}
+
+	s.Reset()
+
+	if total := s.Length(); total != 0 {
+		t.Fatalf("Expected no tracked keys, got %d", total)
+	}
+
+	if ok, _ := s.Allow("a", 1, time.Minute); !ok {
+		t.Fatal("Expected the hit to be allowed after reset")
+	}
+}
+
+func TestSlidingWindowConcurrentAllow(t *testing.T) {
+	t.Parallel()
+
+	s := ratelimit.NewSlidingWindow()
+
+	var mux sync.Mutex
+	var allowed int
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := s.Allow("a", 10, time.Minute); ok {
+				mux.Lock()
+				allowed++
+				mux.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 10 {
+		t.Fatalf("Expected %d allowed hits, got %d", 10, allowed)
+	}
+}
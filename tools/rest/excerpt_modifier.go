@@ -71,9 +71,31 @@ func (m *excerptModifier) Modify(value any) (any, error) {
 		return value, nil
 	}
 
+	result, err := extractPlainText(strValue, m.max)
+	if err != nil {
+		return "", err
+	}
+
+	if len(result) > m.max {
+		result = strings.TrimSpace(result[:m.max])
+
+		if m.withEllipsis {
+			result += "..."
+		}
+	}
+
+	return result, nil
+}
+
+// extractPlainText strips the html tags from the provided string and
+// returns its normalized plain text content (with collapsed whitespaces).
+//
+// If limit > 0, the html nodes traversing stops once the plain text
+// length reaches approximately the specified limit.
+func extractPlainText(value string, limit int) (string, error) {
 	var builder strings.Builder
 
-	doc, err := html.Parse(strings.NewReader(strValue))
+	doc, err := html.Parse(strings.NewReader(value))
 	if err != nil {
 		return "", err
 	}
@@ -100,9 +122,9 @@ func (m *excerptModifier) Modify(value any) (any, error) {
 			}
 		}
 
-		// excerpt max has been reached => no need to further iterate
+		// max has been reached => no need to further iterate
 		// (+2 for the extra whitespace suffix/prefix that will be trimmed later)
-		if builder.Len() > m.max+2 {
+		if limit > 0 && builder.Len() > limit+2 {
 			return
 		}
 
@@ -126,15 +148,5 @@ func (m *excerptModifier) Modify(value any) (any, error) {
 	}
 	stripTags(doc)
 
-	result := strings.TrimSpace(builder.String())
-
-	if len(result) > m.max {
-		result = strings.TrimSpace(result[:m.max])
-
-		if m.withEllipsis {
-			result += "..."
-		}
-	}
-
-	return result, nil
+	return strings.TrimSpace(builder.String()), nil
 }
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// highlightWordRegex matches a single word that could be highlighted
+// (uses the same token characters as [search.FullTextTerms]).
+var highlightWordRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+const (
+	defaultHighlightOpen  = "<mark>"
+	defaultHighlightClose = "</mark>"
+)
+
+var _ FieldModifier = (*highlightModifier)(nil)
+
+type highlightModifier struct {
+	open  string   // the string to insert before each matching word
+	close string   // the string to insert after each matching word
+	terms []string // the lowercased full-text search terms to highlight
+}
+
+// newHighlightModifier validates the specified raw string arguments and
+// initializes a new highlightModifier for the provided full-text search text.
+//
+// This method is usually invoked in initModifer().
+func newHighlightModifier(searchText string, args ...string) (*highlightModifier, error) {
+	totalArgs := len(args)
+
+	if totalArgs > 2 {
+		return nil, errors.New("too many arguments - expected (open?, close?)")
+	}
+
+	m := &highlightModifier{
+		open:  defaultHighlightOpen,
+		close: defaultHighlightClose,
+		terms: search.FullTextTerms(searchText),
+	}
+
+	if totalArgs > 0 {
+		m.open = trimQuotes(args[0])
+	}
+
+	if totalArgs > 1 {
+		m.close = trimQuotes(args[1])
+	}
+
+	return m, nil
+}
+
+// Modify implements the [FieldModifier.Modify] interface method.
+//
+// It returns the html escaped plain text of a formatted html string
+// with all words matching the full-text search terms wrapped
+// in the modifier open and close strings (non-string values are kept untouched).
+func (m *highlightModifier) Modify(value any) (any, error) {
+	strValue, ok := value.(string)
+	if !ok {
+		// not a string -> return as it is without applying the modifier
+		// (we don't throw an error because the modifier could be applied for a missing expand field)
+		return value, nil
+	}
+
+	text, err := extractPlainText(strValue, 0)
+	if err != nil {
+		return "", err
+	}
+
+	return highlightText(text, m.terms, m.open, m.close), nil
+}
+
+// highlightText html escapes the provided plain text and wraps
+// with open and close every word that starts with one of the terms.
+func highlightText(text string, terms []string, open string, close string) string {
+	var builder strings.Builder
+
+	var last int
+
+	for _, loc := range highlightWordRegex.FindAllStringIndex(text, -1) {
+		word := text[loc[0]:loc[1]]
+		if !matchesAnyTerm(word, terms) {
+			continue
+		}
+
+		builder.WriteString(html.EscapeString(text[last:loc[0]]))
+		builder.WriteString(open)
+		builder.WriteString(html.EscapeString(word))
+		builder.WriteString(close)
+
+		last = loc[1]
+	}
+
+	builder.WriteString(html.EscapeString(text[last:]))
+
+	return builder.String()
+}
+
+// matchesAnyTerm checks whether the lowercased word starts with any of the terms
+// (aka. the same prefix match as the one used in [search.FullTextMatchQuery]).
+func matchesAnyTerm(word string, terms []string) bool {
+	word = strings.ToLower(word)
+
+	for _, t := range terms {
+		if strings.HasPrefix(word, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trimQuotes removes the surrounding single or double quotes of a modifier argument.
+func trimQuotes(arg string) string {
+	if len(arg) >= 2 && (arg[0] == '\'' || arg[0] == '"') && arg[len(arg)-1] == arg[0] {
+		return arg[1 : len(arg)-1]
+	}
+
+	return arg
+}
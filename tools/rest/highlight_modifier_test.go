@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cast"
+)
+
+func TestNewHighlightModifier(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		searchText    string
+		args          []string
+		expectError   bool
+		expectedOpen  string
+		expectedClose string
+		expectedTerms []string
+	}{
+		{
+			"no arguments",
+			"Hello, world hello",
+			nil,
+			false,
+			"<mark>",
+			"</mark>",
+			[]string{"hello", "world"},
+		},
+		{
+			"only open argument",
+			"",
+			[]string{"'<b>'"},
+			false,
+			"<b>",
+			"</mark>",
+			nil,
+		},
+		{
+			"open and close arguments",
+			"test",
+			[]string{`"["`, "]"},
+			false,
+			"[",
+			"]",
+			[]string{"test"},
+		},
+		{
+			"too many arguments",
+			"test",
+			[]string{"[", "]", "!"},
+			true,
+			"",
+			"",
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			m, err := newHighlightModifier(s.searchText, s.args...)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				if m != nil {
+					t.Fatalf("Expected nil modifier, got %v", m)
+				}
+
+				return
+			}
+
+			if m.open != s.expectedOpen {
+				t.Fatalf("Expected open %q, got %q", s.expectedOpen, m.open)
+			}
+
+			if m.close != s.expectedClose {
+				t.Fatalf("Expected close %q, got %q", s.expectedClose, m.close)
+			}
+
+			if strings.Join(m.terms, ",") != strings.Join(s.expectedTerms, ",") {
+				t.Fatalf("Expected terms %v, got %v", s.expectedTerms, m.terms)
+			}
+		})
+	}
+}
+
+func TestHighlightModifierModify(t *testing.T) {
+	scenarios := []struct {
+		name       string
+		searchText string
+		args       []string
+		value      any
+		expected   any
+	}{
+		{
+			"non-string value",
+			"test",
+			nil,
+			123,
+			123,
+		},
+		{
+			"no search terms",
+			"",
+			nil,
+			"<p>Hello</p> <b>world</b> & co",
+			"Hello world &amp; co",
+		},
+		{
+			"no matching words",
+			"missing",
+			nil,
+			"<p>Hello</p> <b>world</b>",
+			"Hello world",
+		},
+		{
+			"prefix and case insensitive matches",
+			"HEL wor",
+			nil,
+			"<p>Hello <b>World</b>, help<script>hello</script></p><i>a</i>",
+			"<mark>Hello</mark> <mark>World</mark>, <mark>help</mark> a",
+		},
+		{
+			"non-prefix matches",
+			"orld",
+			nil,
+			"Hello world",
+			"Hello world",
+		},
+		{
+			"custom marks and escaped text",
+			"<lorem>",
+			[]string{"'[['", "']]'"},
+			"<p>&lt;lorem&gt; ipsum <span>Lorem</span></p>",
+			"&lt;[[lorem]]&gt; ipsum [[Lorem]]",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			m, err := newHighlightModifier(s.searchText, s.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			raw, err := m.Modify(s.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cast.ToString(raw) != cast.ToString(s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, raw)
+			}
+		})
+	}
+}
@@ -27,12 +27,19 @@ type Serializer struct {
 	echo.DefaultJSONSerializer
 
 	FieldsParam string
+
+	// SearchParam is the name of the full-text search query parameter
+	// whose terms are marked by the highlight() and snippet() field modifiers (default to "search").
+	SearchParam string
 }
 
 // Serialize converts an interface into a json and writes it to the response.
 //
 // It also provides a generic response data fields picker via the FieldsParam query parameter (default to "fields").
 //
+// The SearchParam query parameter (default to "search") is used as search text
+// for the highlight() and snippet() field modifiers.
+//
 // Note: for the places where it is safe, the std encoding/json is replaced
 // with goccy due to its slightly better Unmarshal/Marshal performance.
 func (s *Serializer) Serialize(c echo.Context, i any, indent string) error {
@@ -41,6 +48,11 @@ func (s *Serializer) Serialize(c echo.Context, i any, indent string) error {
 		fieldsParam = "fields"
 	}
 
+	searchParam := s.SearchParam
+	if searchParam == "" {
+		searchParam = "search"
+	}
+
 	statusCode := c.Response().Status
 
 	rawFields := c.QueryParam(fieldsParam)
@@ -48,7 +60,7 @@ func (s *Serializer) Serialize(c echo.Context, i any, indent string) error {
 		return s.DefaultJSONSerializer.Serialize(c, i, indent)
 	}
 
-	decoded, err := PickFields(i, rawFields)
+	decoded, err := PickFieldsWithSearch(i, rawFields, c.QueryParam(searchParam))
 	if err != nil {
 		return err
 	}
@@ -71,7 +83,19 @@ func (s *Serializer) Serialize(c echo.Context, i any, indent string) error {
 // 	data := map[string]any{"a": 1, "b": 2, "c": map[string]any{"c1": 11, "c2": 22}}
 // 	PickFields(data, "a,c.c1") // map[string]any{"a": 1, "c": map[string]any{"c1": 11}}
 func PickFields(data any, rawFields string) (any, error) {
-	parsedFields, err := parseFields(rawFields)
+	return PickFieldsWithSearch(data, rawFields, "")
+}
+
+// PickFieldsWithSearch is similar to [PickFields] but additionally
+// allows specifying the full-text search text whose terms will be
+// marked by the highlight() and snippet() field modifiers.
+//
+// Example:
+//
+// 	data := map[string]any{"a": "Hello world!"}
+// 	PickFieldsWithSearch(data, "a:highlight", "wor") // map[string]any{"a": "Hello <mark>world</mark>!"}
+func PickFieldsWithSearch(data any, rawFields string, searchText string) (any, error) {
+	parsedFields, err := parseFields(rawFields, searchText)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +135,7 @@ func PickFields(data any, rawFields string) (any, error) {
 	return decoded, nil
 }
 
-func parseFields(rawFields string) (map[string]FieldModifier, error) {
+func parseFields(rawFields string, searchText string) (map[string]FieldModifier, error) {
 	t := tokenizer.NewFromString(rawFields)
 
 	fields, err := t.ScanAll()
@@ -125,7 +149,7 @@ func parseFields(rawFields string) (map[string]FieldModifier, error) {
 		parts := strings.SplitN(strings.TrimSpace(f), ":", 2)
 
 		if len(parts) > 1 {
-			m, err := initModifer(parts[1])
+			m, err := initModifer(parts[1], searchText)
 			if err != nil {
 				return nil, err
 			}
@@ -138,7 +162,7 @@ func parseFields(rawFields string) (map[string]FieldModifier, error) {
 	return result, nil
 }
 
-func initModifer(rawModifier string) (FieldModifier, error) {
+func initModifer(rawModifier string, searchText string) (FieldModifier, error) {
 	t := tokenizer.NewFromString(rawModifier)
 	t.Separators('(', ')', ',', ' ')
 	t.IgnoreParenthesis(true)
@@ -162,6 +186,18 @@ func initModifer(rawModifier string) (FieldModifier, error) {
 			return nil, fmt.Errorf("invalid excerpt modifier: %w", err)
 		}
 		return m, nil
+	case "highlight":
+		m, err := newHighlightModifier(searchText, args...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid highlight modifier: %w", err)
+		}
+		return m, nil
+	case "snippet":
+		m, err := newSnippetModifier(searchText, args...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snippet modifier: %w", err)
+		}
+		return m, nil
 	}
 
 	return nil, fmt.Errorf("missing or invalid modifier %q", name)
@@ -2,6 +2,7 @@ package rest_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -396,3 +397,66 @@ func TestPickFields(t *testing.T) {
 		})
 	}
 }
+
+func TestPickFieldsWithSearch(t *testing.T) {
+	data := map[string]any{
+		"id":    "123",
+		"title": "<p>Hello world</p>",
+		"rel": map[string]any{
+			"title": "Lorem ipsum dolor sit amet",
+		},
+	}
+
+	scenarios := []struct {
+		name        string
+		fields      string
+		searchText  string
+		expectError bool
+		result      map[string]any
+	}{
+		{
+			"invalid snippet modifier",
+			"title:snippet",
+			"world",
+			true,
+			nil,
+		},
+		{
+			"without search text",
+			"title:highlight",
+			"",
+			false,
+			map[string]any{"title": "Hello world"},
+		},
+		{
+			"with search text",
+			"id,title:highlight,rel.title:snippet(11, true)",
+			"wor dolor",
+			false,
+			map[string]any{
+				"id":    "123",
+				"title": "Hello <mark>world</mark>",
+				"rel":   map[string]any{"title": "...<mark>dolor</mark> sit..."},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := rest.PickFieldsWithSearch(data, s.fields, s.searchText)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if fmt.Sprint(result) != fmt.Sprint(s.result) {
+				t.Fatalf("Expected \n%v \ngot \n%v", s.result, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+var _ FieldModifier = (*snippetModifier)(nil)
+
+type snippetModifier struct {
+	max          int      // approximate max snippet length (excluding the highlight marks)
+	withEllipsis bool     // if enabled will add ellipsis when the snippet is cut from the plain text
+	terms        []string // the lowercased full-text search terms to highlight
+}
+
+// newSnippetModifier validates the specified raw string arguments and
+// initializes a new snippetModifier for the provided full-text search text.
+//
+// This method is usually invoked in initModifer().
+func newSnippetModifier(searchText string, args ...string) (*snippetModifier, error) {
+	totalArgs := len(args)
+
+	if totalArgs == 0 {
+		return nil, errors.New("max argument is required - expected (max, withEllipsis?)")
+	}
+
+	if totalArgs > 2 {
+		return nil, errors.New("too many arguments - expected (max, withEllipsis?)")
+	}
+
+	max := cast.ToInt(args[0])
+	if max <= 0 {
+		return nil, errors.New("max argument must be > 0")
+	}
+
+	var withEllipsis bool
+	if totalArgs > 1 {
+		withEllipsis = cast.ToBool(args[1])
+	}
+
+	return &snippetModifier{max, withEllipsis, search.FullTextTerms(searchText)}, nil
+}
+
+// Modify implements the [FieldModifier.Modify] interface method.
+//
+// It returns a short plain text fragment of a formatted html string
+// around the first word matching the full-text search terms, with
+// all matching words wrapped in <mark></mark> (non-string values are kept untouched).
+//
+// If there is no matching word, the fragment is taken from the beginning of the text.
+func (m *snippetModifier) Modify(value any) (any, error) {
+	strValue, ok := value.(string)
+	if !ok {
+		// not a string -> return as it is without applying the modifier
+		// (we don't throw an error because the modifier could be applied for a missing expand field)
+		return value, nil
+	}
+
+	text, err := extractPlainText(strValue, 0)
+	if err != nil {
+		return "", err
+	}
+
+	start, end := m.bounds(text)
+
+	result := highlightText(strings.TrimSpace(text[start:end]), m.terms, defaultHighlightOpen, defaultHighlightClose)
+
+	if m.withEllipsis {
+		if start > 0 {
+			result = "..." + result
+		}
+		if end < len(text) {
+			result += "..."
+		}
+	}
+
+	return result, nil
+}
+
+// bounds returns the start and end byte positions of the snippet in text.
+func (m *snippetModifier) bounds(text string) (int, int) {
+	if len(text) <= m.max {
+		return 0, len(text)
+	}
+
+	// the position of the first matching word (if any)
+	matchStart := len(text)
+	for _, loc := range highlightWordRegex.FindAllStringIndex(text, -1) {
+		if matchesAnyTerm(text[loc[0]:loc[1]], m.terms) {
+			matchStart = loc[0]
+			break
+		}
+	}
+
+	var start int
+
+	if matchStart < len(text) {
+		// start a few words before the first match to give some context
+		start = matchStart - m.max/4
+	}
+
+	if start+m.max > len(text) {
+		// fill the rest of the snippet with the text before the match
+		start = len(text) - m.max
+	}
+
+	if start < 0 {
+		start = 0
+	}
+
+	// ensure that the snippet starts with a full word
+	// (without skipping the first matching word)
+	if start > 0 && text[start-1] != ' ' {
+		if idx := strings.IndexByte(text[start:matchStart], ' '); idx >= 0 {
+			start += idx + 1
+		} else {
+			start = matchStart
+		}
+	}
+
+	end := start + m.max
+	if end >= len(text) {
+		return start, len(text)
+	}
+
+	// prefer to end at a word boundary
+	if idx := strings.LastIndexByte(text[start:end], ' '); idx > 0 {
+		end = start + idx
+	}
+
+	// ensure that a multi-byte character is not cut
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	return start, end
+}
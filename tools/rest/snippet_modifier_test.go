@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/spf13/cast"
+)
+
+func TestNewSnippetModifier(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{
+			"no arguments",
+			nil,
+			true,
+		},
+		{
+			"too many arguments",
+			[]string{"12", "false", "something"},
+			true,
+		},
+		{
+			"non-numeric max argument",
+			[]string{"something"},
+			true,
+		},
+		{
+			"negative max argument",
+			[]string{"-10"},
+			true,
+		},
+		{
+			"only max argument",
+			[]string{"12"},
+			false,
+		},
+		{
+			"max and withEllipsis arguments",
+			[]string{"12", "t"},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			m, err := newSnippetModifier("test", s.args...)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				if m != nil {
+					t.Fatalf("Expected nil modifier, got %v", m)
+				}
+
+				return
+			}
+
+			if m.max != cast.ToInt(s.args[0]) {
+				t.Fatalf("Expected max %s, got %d", s.args[0], m.max)
+			}
+
+			var argWithEllipsis bool
+			if len(s.args) > 1 {
+				argWithEllipsis = cast.ToBool(s.args[1])
+			}
+
+			if m.withEllipsis != argWithEllipsis {
+				t.Fatalf("Expected withEllipsis %v, got %v", argWithEllipsis, m.withEllipsis)
+			}
+
+			if len(m.terms) != 1 || m.terms[0] != "test" {
+				t.Fatalf("Expected terms [test], got %v", m.terms)
+			}
+		})
+	}
+}
+
+func TestSnippetModifierModify(t *testing.T) {
+	html := `<h1>Lorem ipsum</h1><p>dolor sit amet, <b>consectetur</b> adipiscing elit, sed do eiusmod tempor</p>`
+
+	scenarios := []struct {
+		name       string
+		searchText string
+		args       []string
+		value      any
+		expected   any
+	}{
+		{
+			"non-string value",
+			"test",
+			[]string{"10"},
+			123,
+			123,
+		},
+		{
+			"max >= plain text length",
+			"dolor",
+			[]string{"100", "t"},
+			html,
+			"Lorem ipsum <mark>dolor</mark> sit amet, consectetur adipiscing elit, sed do eiusmod tempor",
+		},
+		{
+			"no matching words",
+			"missing",
+			[]string{"20"},
+			html,
+			"Lorem ipsum dolor",
+		},
+		{
+			"no matching words with ellipsis",
+			"missing",
+			[]string{"20", "t"},
+			html,
+			"Lorem ipsum dolor...",
+		},
+		{
+			"match at the beginning",
+			"lor",
+			[]string{"20", "t"},
+			html,
+			"<mark>Lorem</mark> ipsum dolor...",
+		},
+		{
+			"match in the middle",
+			"consec",
+			[]string{"32", "t"},
+			html,
+			"...amet, <mark>consectetur</mark> adipiscing...",
+		},
+		{
+			"match at the end",
+			"tempor",
+			[]string{"32"},
+			html,
+			"elit, sed do eiusmod <mark>tempor</mark>",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			m, err := newSnippetModifier(s.searchText, s.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			raw, err := m.Modify(s.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cast.ToString(raw) != cast.ToString(s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, raw)
+			}
+		})
+	}
+}
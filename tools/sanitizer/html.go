@@ -0,0 +1,181 @@
+package sanitizer
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/list"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ForbiddenTags is the list of the html elements that are always
+// removed together with their content, regardless of the policy.
+var ForbiddenTags = []string{"script", "style"}
+
+// droppedTags is the list of the html elements that are removed together
+// with their content when not explicitly allowed by the policy
+// (for all other not allowed elements only their content is kept).
+var droppedTags = []string{
+	"noscript", "template", "iframe", "frame", "frameset", "object",
+	"embed", "applet", "svg", "math", "textarea", "select", "title",
+}
+
+// urlAttributes is the list of the html attributes with url value
+// that are checked against the policy allowed schemes.
+var urlAttributes = []string{
+	"href", "src", "cite", "action", "formaction",
+	"poster", "background", "longdesc", "data",
+}
+
+// HTMLPolicy defines the allowed html elements, attributes and url schemes.
+//
+// The zero value policy strips all html tags and keeps only the text content.
+type HTMLPolicy struct {
+	// AllowedTags is the list of the allowed lowercase html tag names.
+	//
+	// The [ForbiddenTags] are never allowed.
+	AllowedTags []string
+
+	// AllowedAttributes is the list of the allowed lowercase attribute
+	// names of the allowed tags (eg. "href", "title", "class").
+	//
+	// The event handler attributes (eg. "onclick") are never allowed.
+	AllowedAttributes []string
+
+	// AllowedSchemes is the list of the allowed url schemes
+	// of the url attributes (eg. "https", "mailto").
+	//
+	// Relative urls are always allowed.
+	AllowedSchemes []string
+}
+
+// Sanitize parses the provided html fragment and returns its normalized
+// html representation containing only the policy allowed elements and attributes.
+//
+// Comments and the not allowed elements are removed (only the content
+// of the not allowed inline and block elements is preserved).
+func (p *HTMLPolicy) Sanitize(fragment string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	root := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	for _, n := range nodes {
+		root.AppendChild(n)
+	}
+
+	p.sanitizeChildren(root)
+
+	var builder strings.Builder
+
+	for c := root.FirstChild; c != nil; c = c.NextSibling {
+		if err := html.Render(&builder, c); err != nil {
+			return "", err
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func (p *HTMLPolicy) sanitizeChildren(parent *html.Node) {
+	var next *html.Node
+
+	for c := parent.FirstChild; c != nil; c = next {
+		next = c.NextSibling
+
+		switch c.Type {
+		case html.TextNode:
+			// keep as it is (it is escaped on render)
+		case html.ElementNode:
+			if p.isAllowedTag(c.Data) {
+				c.Attr = p.sanitizeAttributes(c.Attr)
+				p.sanitizeChildren(c)
+				continue
+			}
+
+			if !list.ExistInSlice(c.Data, droppedTags) && !list.ExistInSlice(c.Data, ForbiddenTags) {
+				// unwrap the not allowed element (aka. keep only its sanitized content)
+				p.sanitizeChildren(c)
+				for c.FirstChild != nil {
+					child := c.FirstChild
+					c.RemoveChild(child)
+					parent.InsertBefore(child, c)
+				}
+			}
+
+			parent.RemoveChild(c)
+		default:
+			// comments, doctypes, etc.
+			parent.RemoveChild(c)
+		}
+	}
+}
+
+func (p *HTMLPolicy) isAllowedTag(tag string) bool {
+	return list.ExistInSlice(tag, p.AllowedTags) && !list.ExistInSlice(tag, ForbiddenTags)
+}
+
+func (p *HTMLPolicy) sanitizeAttributes(attrs []html.Attribute) []html.Attribute {
+	result := make([]html.Attribute, 0, len(attrs))
+
+	for _, attr := range attrs {
+		if attr.Namespace != "" ||
+			strings.HasPrefix(attr.Key, "on") ||
+			!list.ExistInSlice(attr.Key, p.AllowedAttributes) {
+			continue
+		}
+
+		if attr.Key == "srcset" && !p.isAllowedSrcset(attr.Val) {
+			continue
+		}
+
+		if list.ExistInSlice(attr.Key, urlAttributes) && !p.isAllowedUrl(attr.Val) {
+			continue
+		}
+
+		result = append(result, attr)
+	}
+
+	return result
+}
+
+// isAllowedSrcset checks whether all image candidate urls of
+// the provided srcset attribute value are allowed.
+func (p *HTMLPolicy) isAllowedSrcset(srcset string) bool {
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 && !p.isAllowedUrl(fields[0]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *HTMLPolicy) isAllowedUrl(rawUrl string) bool {
+	// the browsers ignore the whitespaces and control characters
+	// in the url scheme (eg. "java\tscript:")
+	normalized := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, rawUrl)
+
+	u, err := url.Parse(normalized)
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "" {
+		return true // relative url
+	}
+
+	return list.ExistInSlice(strings.ToLower(u.Scheme), p.AllowedSchemes)
+}
@@ -0,0 +1,109 @@
+package sanitizer_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/sanitizer"
+)
+
+func TestHTMLPolicySanitize(t *testing.T) {
+	policy := &sanitizer.HTMLPolicy{
+		AllowedTags:       []string{"p", "a", "strong", "img", "script"},
+		AllowedAttributes: []string{"href", "src", "srcset", "title", "onclick"},
+		AllowedSchemes:    []string{"https", "mailto"},
+	}
+
+	scenarios := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			"empty",
+			"",
+			"",
+		},
+		{
+			"plain text",
+			"a < b & c",
+			"a &lt; b &amp; c",
+		},
+		{
+			"allowed tags",
+			"<p>Hello <strong>world</strong></p>",
+			"<p>Hello <strong>world</strong></p>",
+		},
+		{
+			"not allowed tags are unwrapped",
+			"<div><p>Hello <em>world</em></p></div>",
+			"<p>Hello world</p>",
+		},
+		{
+			"forbidden and dropped tags are removed with their content",
+			"<p>a<script>alert(1)</script><style>p{}</style><iframe src='https://example.com'>b</iframe>c</p>",
+			"<p>ac</p>",
+		},
+		{
+			"comments are removed",
+			"<p>a<!-- comment -->b</p>",
+			"<p>ab</p>",
+		},
+		{
+			"not allowed and event handler attributes are removed",
+			`<p class="test" title="t" onclick="alert(1)">a</p>`,
+			`<p title="t">a</p>`,
+		},
+		{
+			"url schemes",
+			`<a href="https://example.com">1</a>` +
+				`<a href="mailto:test@example.com">2</a>` +
+				`<a href="/relative?a=1">3</a>` +
+				`<a href="javascript:alert(1)">4</a>` +
+				`<a href=" JaVa&#09;Script:alert(1)">5</a>` +
+				`<a href="data:text/html;base64,PHNjcmlwdD4=">6</a>`,
+			`<a href="https://example.com">1</a>` +
+				`<a href="mailto:test@example.com">2</a>` +
+				`<a href="/relative?a=1">3</a>` +
+				`<a>4</a>` +
+				`<a>5</a>` +
+				`<a>6</a>`,
+		},
+		{
+			"srcset",
+			`<img srcset="https://example.com/a.png 1x, /b.png 2x"/><img srcset="/a.png 1x, javascript:alert(1) 2x"/>`,
+			`<img srcset="https://example.com/a.png 1x, /b.png 2x"/><img/>`,
+		},
+		{
+			"unclosed tags are normalized",
+			"<p><strong>test",
+			"<p><strong>test</strong></p>",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := policy.Sanitize(s.html)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestHTMLPolicySanitizeZeroValue(t *testing.T) {
+	policy := &sanitizer.HTMLPolicy{}
+
+	result, err := policy.Sanitize(`<p>Hello <a href="https://example.com">world</a><script>alert(1)</script></p>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Hello world"
+	if result != expected {
+		t.Fatalf("Expected %q, got %q", expected, result)
+	}
+}
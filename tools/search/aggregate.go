@@ -0,0 +1,103 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// supported aggregate functions
+const (
+	AggregateCount string = "count"
+	AggregateSum   string = "sum"
+	AggregateAvg   string = "avg"
+	AggregateMin   string = "min"
+	AggregateMax   string = "max"
+)
+
+var aggregateRegex = regexp.MustCompile(`^(\w+)\(\s*([\w\.\@\:]*)\s*\)$`)
+
+// AggregateField defines a single search aggregate expression (eg. "sum(total)").
+type AggregateField struct {
+	Func  string `json:"func"`
+	Field string `json:"field"`
+}
+
+// Key returns the name of the aggregate field in the search result
+// (eg. "count", "sum_total", "avg_author_age").
+func (a *AggregateField) Key() string {
+	if a.Field == "" {
+		return a.Func
+	}
+
+	return a.Func + "_" + strings.NewReplacer(".", "_", ":", "_", "@", "").Replace(a.Field)
+}
+
+// BuildExpr resolves the aggregate field into a valid db select expression.
+//
+// countExpr is used as the counted expression when the field is empty.
+func (a *AggregateField) BuildExpr(fieldResolver FieldResolver, countExpr string) (string, error) {
+	fn := strings.ToUpper(a.Func)
+
+	switch a.Func {
+	case AggregateCount:
+		if a.Field == "" {
+			return fmt.Sprintf("COUNT(DISTINCT %s)", countExpr), nil
+		}
+	case AggregateSum, AggregateAvg, AggregateMin, AggregateMax:
+		if a.Field == "" {
+			return "", fmt.Errorf("missing %s aggregate field", a.Func)
+		}
+	default:
+		return "", fmt.Errorf("unsupported aggregate function %q", a.Func)
+	}
+
+	sortField := SortField{Name: a.Field}
+	result, err := sortField.resolve(fieldResolver)
+	if err != nil {
+		return "", fmt.Errorf("invalid aggregate field %q", a.Field)
+	}
+
+	return fmt.Sprintf("%s(%s)", fn, valueFallbackResult(result).Identifier), nil
+}
+
+// ParseAggregateFromString parses the provided string expression
+// into a slice of AggregateFields.
+//
+// Example:
+//
+//	fields, err := search.ParseAggregateFromString("count(),sum(total),max(author.age)")
+func ParseAggregateFromString(str string) ([]AggregateField, error) {
+	fields := []AggregateField{}
+
+	for _, part := range strings.Split(str, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field, ok := parseAggregate(part)
+		if !ok {
+			return nil, fmt.Errorf("invalid aggregate expression %q", part)
+		}
+
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, errors.New("at least one aggregate expression is required")
+	}
+
+	return fields, nil
+}
+
+// parseAggregate parses a single aggregate expression (eg. "sum(total)").
+func parseAggregate(str string) (AggregateField, bool) {
+	matches := aggregateRegex.FindStringSubmatch(strings.TrimSpace(str))
+	if len(matches) != 3 {
+		return AggregateField{}, false
+	}
+
+	return AggregateField{Func: strings.ToLower(matches[1]), Field: matches[2]}, true
+}
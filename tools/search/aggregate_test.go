@@ -0,0 +1,97 @@
+package search_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+func TestAggregateFieldKey(t *testing.T) {
+	scenarios := []struct {
+		field    search.AggregateField
+		expected string
+	}{
+		{search.AggregateField{"count", ""}, "count"},
+		{search.AggregateField{"count", "test1"}, "count_test1"},
+		{search.AggregateField{"sum", "test4.sub"}, "sum_test4_sub"},
+		{search.AggregateField{"max", "rel.name:length"}, "max_rel_name_length"},
+	}
+
+	for i, s := range scenarios {
+		if v := s.field.Key(); v != s.expected {
+			t.Errorf("(%d) Expected key %q, got %q", i, s.expected, v)
+		}
+	}
+}
+
+func TestAggregateFieldBuildExpr(t *testing.T) {
+	resolver := search.NewSimpleFieldResolver("test1", "test2", "test4.sub")
+
+	scenarios := []struct {
+		field            search.AggregateField
+		expectError      bool
+		expectExpression string
+	}{
+		// unsupported function
+		{search.AggregateField{"median", "test1"}, true, ""},
+		// missing field
+		{search.AggregateField{"sum", ""}, true, ""},
+		// unknown field
+		{search.AggregateField{"sum", "unknown"}, true, ""},
+		// null field
+		{search.AggregateField{"min", "null"}, true, ""},
+		// count without field
+		{search.AggregateField{"count", ""}, false, "COUNT(DISTINCT [[test.id]])"},
+		// count with field
+		{search.AggregateField{"count", "test2"}, false, "COUNT([[test2]])"},
+		{search.AggregateField{"sum", "test1"}, false, "SUM([[test1]])"},
+		{search.AggregateField{"avg", "test1"}, false, "AVG([[test1]])"},
+		{search.AggregateField{"min", "test4.sub"}, false, "MIN(JSON_EXTRACT([[test4]], '$.sub'))"},
+		{search.AggregateField{"max", "test4.sub"}, false, "MAX(JSON_EXTRACT([[test4]], '$.sub'))"},
+	}
+
+	for i, s := range scenarios {
+		result, err := s.field.BuildExpr(resolver, "[[test.id]]")
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if result != s.expectExpression {
+			t.Errorf("(%d) Expected expression %v, got %v", i, s.expectExpression, result)
+		}
+	}
+}
+
+func TestParseAggregateFromString(t *testing.T) {
+	scenarios := []struct {
+		value        string
+		expectError  bool
+		expectedJson string
+	}{
+		{"", true, "null"},
+		{"count", true, "null"},
+		{"sum(a,b)", true, "null"},
+		{"sum(a b)", true, "null"},
+		{"count()", false, `[{"func":"count","field":""}]`},
+		{" COUNT( ) , sum(test1),avg( rel.test2 )", false, `[{"func":"count","field":""},{"func":"sum","field":"test1"},{"func":"avg","field":"rel.test2"}]`},
+	}
+
+	for i, s := range scenarios {
+		result, err := search.ParseAggregateFromString(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		encoded, _ := json.Marshal(result)
+		if string(encoded) != s.expectedJson {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expectedJson, string(encoded))
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package search
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+)
+
+// cursorValuesAlias is the column alias of the selected row sort values.
+const cursorValuesAlias = "__cursorValues"
+
+var identifierColumnRegex = regexp.MustCompile(`^(?:\w+\.)?(\w+)$`)
+
+// cursorSortItem defines a single resolved keyset sort column.
+type cursorSortItem struct {
+	identifier string
+	column     string // the plain column name (if the identifier is a column)
+	desc       bool
+}
+
+// identifierColumn returns the plain column name of the provided
+// resolved identifier (eg. "[[demo.title]]" -> "title") or empty
+// string if the identifier is not a column (eg. json_extract(...)).
+func identifierColumn(identifier string) string {
+	if strings.HasPrefix(identifier, "[[") && strings.HasSuffix(identifier, "]]") {
+		identifier = identifier[2 : len(identifier)-2]
+	}
+
+	match := identifierColumnRegex.FindStringSubmatch(identifier)
+	if len(match) != 2 {
+		return ""
+	}
+
+	return match[1]
+}
+
+// buildCursorValuesSelect builds a select expression that returns the
+// row sort values as json array (aka. with preserved value types).
+func buildCursorValuesSelect(sortItems []cursorSortItem) string {
+	identifiers := make([]string, len(sortItems))
+	for i, item := range sortItems {
+		identifiers[i] = item.identifier
+	}
+
+	return "json_array(" + strings.Join(identifiers, ", ") + ") AS [[" + cursorValuesAlias + "]]"
+}
+
+// itemCursor encodes into a cursor string the sort values of the provided fetched item.
+//
+// Map items (eg. dbx.NullStringMap) are expected to contain the selected
+// sort values column, while for struct items the values are read from
+// the struct fields with the same db names as the sort columns.
+func itemCursor(item reflect.Value, sortItems []cursorSortItem) (string, error) {
+	item = indirectItem(item)
+
+	var values []any
+
+	switch item.Kind() {
+	case reflect.Map:
+		if item.Type().Key().Kind() != reflect.String {
+			return "", errors.New("unsupported cursor pagination map item key type")
+		}
+
+		raw := item.MapIndex(reflect.ValueOf(cursorValuesAlias).Convert(item.Type().Key()))
+		if !raw.IsValid() {
+			return "", errors.New("missing cursor pagination item sort values")
+		}
+
+		var str string
+		switch v := raw.Interface().(type) {
+		case sql.NullString:
+			str = v.String
+		case string:
+			str = v
+		case []byte:
+			str = string(v)
+		}
+
+		var err error
+		values, err = parseCursorValues([]byte(str), len(sortItems))
+		if err != nil {
+			return "", err
+		}
+	case reflect.Struct:
+		values = make([]any, len(sortItems))
+		for i, sortItem := range sortItems {
+			field, ok := structFieldByDbName(item, sortItem.column)
+			if sortItem.column == "" || !ok {
+				return "", fmt.Errorf("the sort field %s is not supported with cursor pagination", sortItem.identifier)
+			}
+
+			values[i] = field.Interface()
+			if valuer, ok := values[i].(driver.Valuer); ok {
+				v, err := valuer.Value()
+				if err != nil {
+					return "", err
+				}
+				values[i] = v
+			}
+		}
+	default:
+		return "", errors.New("unsupported cursor pagination item type")
+	}
+
+	return encodeCursor(values)
+}
+
+// deleteItemCursorValues removes the selected sort values column from the provided map item.
+func deleteItemCursorValues(item reflect.Value) {
+	item = indirectItem(item)
+
+	if item.Kind() != reflect.Map || item.IsNil() || item.Type().Key().Kind() != reflect.String {
+		return
+	}
+
+	item.SetMapIndex(reflect.ValueOf(cursorValuesAlias).Convert(item.Type().Key()), reflect.Value{})
+}
+
+// indirectItem returns the value that the provided item pointer or interface points to.
+func indirectItem(item reflect.Value) reflect.Value {
+	for (item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface) && !item.IsNil() {
+		item = item.Elem()
+	}
+
+	return item
+}
+
+// structFieldByDbName returns the struct field (including from the
+// embedded structs) mapped to the provided db column name.
+func structFieldByDbName(rv reflect.Value, name string) (reflect.Value, bool) {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && tag == "" {
+			embedded := indirectItem(rv.Field(i))
+			if embedded.Kind() == reflect.Struct {
+				if v, ok := structFieldByDbName(embedded, name); ok {
+					return v, true
+				}
+			}
+			continue
+		}
+
+		if tag == "" {
+			tag = dbx.DefaultFieldMapFunc(f.Name)
+		}
+
+		if tag == name {
+			return rv.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// encodeCursor encodes the provided sort values into an opaque cursor string.
+func encodeCursor(values []any) (string, error) {
+	normalized := make([]any, len(values))
+	for i, v := range values {
+		if b, ok := v.([]byte); ok {
+			normalized[i] = string(b)
+		} else {
+			normalized[i] = v
+		}
+	}
+
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor decodes the provided opaque cursor string and
+// returns its sort values.
+//
+// Returns an error if the cursor is malformed or if it doesn't
+// have exactly expectedLen values (eg. the sort has changed).
+func decodeCursor(cursor string, expectedLen int) ([]any, error) {
+	invalidErr := errors.New("invalid or outdated pagination cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalidErr
+	}
+
+	values, err := parseCursorValues(raw, expectedLen)
+	if err != nil {
+		return nil, invalidErr
+	}
+
+	return values, nil
+}
+
+// parseCursorValues parses the provided json array of sort values.
+//
+// Returns an error if the json is malformed or if it doesn't
+// have exactly expectedLen scalar values.
+func parseCursorValues(raw []byte, expectedLen int) ([]any, error) {
+	invalidErr := errors.New("invalid cursor values")
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	values := []any{}
+	if err := decoder.Decode(&values); err != nil || len(values) != expectedLen {
+		return nil, invalidErr
+	}
+
+	for i, v := range values {
+		switch cv := v.(type) {
+		case nil, string, bool:
+			// as it is
+		case json.Number:
+			if n, err := cv.Int64(); err == nil {
+				values[i] = n
+			} else if n, err := cv.Float64(); err == nil {
+				values[i] = n
+			} else {
+				return nil, invalidErr
+			}
+		default:
+			return nil, invalidErr
+		}
+	}
+
+	return values, nil
+}
+
+// buildCursorExpr builds a keyset pagination expression that matches
+// all rows positioned after the provided cursor values
+// (or before them if backward is set).
+//
+// NULL values are considered smaller than any other value
+// (aka. the default SQLite sort order).
+func buildCursorExpr(sortItems []cursorSortItem, values []any, backward bool) dbx.Expression {
+	params := dbx.Params{}
+	terms := make([]string, 0, len(sortItems))
+
+	for i, item := range sortItems {
+		parts := make([]string, 0, i+1)
+
+		// the preceding sort columns must be the same as in the cursor
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s IS {:__cursor%d}", sortItems[j].identifier, j))
+		}
+
+		param := fmt.Sprintf("{:__cursor%d}", i)
+		params[fmt.Sprintf("__cursor%d", i)] = values[i]
+
+		// ASC + after or DESC + before
+		if item.desc == backward {
+			if values[i] == nil {
+				parts = append(parts, item.identifier+" IS NOT NULL")
+			} else {
+				parts = append(parts, item.identifier+" > "+param)
+			}
+		} else {
+			if values[i] == nil {
+				continue // nothing could be smaller than NULL
+			}
+			parts = append(parts, fmt.Sprintf("(%s < %s OR %s IS NULL)", item.identifier, param, item.identifier))
+		}
+
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	if len(terms) == 0 {
+		return dbx.NewExp("1=0")
+	}
+
+	return dbx.NewExp("("+strings.Join(terms, " OR ")+")", params)
+}
+
+// reverseItems reverses in place the elements of the provided slice pointer.
+func reverseItems(items any) error {
+	rv := reflect.ValueOf(items)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("items must be a pointer to a slice")
+	}
+
+	slice := rv.Elem()
+	swap := reflect.Swapper(slice.Interface())
+	for i, j := 0, slice.Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+
+	return nil
+}
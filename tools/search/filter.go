@@ -64,6 +64,15 @@ func (f FilterData) BuildExpr(
 		}
 	}
 
+	// replace the supported function calls (eg. geoDistance) with placeholder identifiers
+	raw, calls, err := extractFunctionCalls(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(calls) > 0 {
+		fieldResolver = &functionsResolver{FieldResolver: fieldResolver, calls: calls}
+	}
+
 	if parsedFilterData.Has(raw) {
 		return buildParsedFilterExpr(parsedFilterData.Get(raw), fieldResolver)
 	}
@@ -137,7 +146,138 @@ func resolveTokenizedExpr(expr fexpr.Expr, fieldResolver FieldResolver) (dbx.Exp
 		return nil, fmt.Errorf("invalid right operand %q - %v", expr.Right.Literal, rErr)
 	}
 
-	return buildResolversExpr(lResult, expr.Op, rResult)
+	if lResult.ValueTransform != nil || rResult.ValueTransform != nil {
+		lResult, rResult = applyValueTransform(lResult, expr.Op, rResult)
+	}
+
+	if lResult.EqualityValueTransform != nil || rResult.EqualityValueTransform != nil {
+		var err error
+		lResult, rResult, err = applyEqualityValueTransform(lResult, expr.Op, rResult)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := buildResolversExpr(lResult, expr.Op, rResult)
+	if err != nil {
+		return nil, err
+	}
+
+	// narrow the function comparisons with an index friendly expression (if any)
+	if fr, ok := fieldResolver.(*functionsResolver); ok {
+		if bound := fr.boundExpr(expr); bound != nil {
+			result = dbx.Enclose(dbx.And(bound, result))
+		}
+	}
+
+	return result, nil
+}
+
+// applyEqualityValueTransform ensures that the operand(s) with
+// EqualityValueTransform are used only in equality comparisons and
+// returns the other operand with transformed params values.
+func applyEqualityValueTransform(
+	left *ResolverResult,
+	op fexpr.SignOp,
+	right *ResolverResult,
+) (*ResolverResult, *ResolverResult, error) {
+	switch op {
+	case fexpr.SignEq, fexpr.SignAnyEq, fexpr.SignNeq, fexpr.SignAnyNeq:
+	default:
+		return nil, nil, fmt.Errorf("only the equality operators are allowed, got %q", op)
+	}
+
+	// both sides have the same transformed values
+	if left.EqualityValueTransform != nil && right.EqualityValueTransform != nil {
+		return left, right, nil
+	}
+
+	transform := func(operand *ResolverResult, other *ResolverResult) (*ResolverResult, error) {
+		if len(other.Params) == 0 {
+			if isEmptyIdentifier(other) {
+				return other, nil
+			}
+			return nil, errors.New("the operand could be compared only with literal values")
+		}
+
+		clone := *other
+		clone.Params = make(dbx.Params, len(other.Params))
+		for k, v := range other.Params {
+			clone.Params[k] = operand.EqualityValueTransform(v)
+		}
+
+		return &clone, nil
+	}
+
+	var err error
+	if left.EqualityValueTransform != nil {
+		right, err = transform(left, right)
+	} else {
+		left, err = transform(right, left)
+	}
+
+	return left, right, err
+}
+
+// applyValueTransform transforms the literal operand params values with
+// the ValueTransform of the other operand or, if the transform is not
+// applicable, replaces the operand with its ValueFallback result.
+func applyValueTransform(
+	left *ResolverResult,
+	op fexpr.SignOp,
+	right *ResolverResult,
+) (*ResolverResult, *ResolverResult) {
+	var isLike bool
+	switch op {
+	case fexpr.SignLike, fexpr.SignAnyLike, fexpr.SignNlike, fexpr.SignAnyNlike:
+		isLike = true
+	}
+
+	transform := func(operand *ResolverResult, other *ResolverResult) (*ResolverResult, *ResolverResult) {
+		if operand.ValueTransform == nil {
+			return operand, other
+		}
+
+		if isLike || !isLiteralResult(other) {
+			return valueFallbackResult(operand), other
+		}
+
+		clone := *other
+		clone.Params = make(dbx.Params, len(other.Params))
+		for k, v := range other.Params {
+			clone.Params[k] = operand.ValueTransform(v)
+		}
+
+		return operand, &clone
+	}
+
+	left, right = transform(left, right)
+	right, left = transform(right, left)
+
+	return left, right
+}
+
+// isLiteralResult checks whether the provided result is a single placeholder literal value.
+func isLiteralResult(result *ResolverResult) bool {
+	if len(result.Params) != 1 {
+		return false
+	}
+
+	for k := range result.Params {
+		return result.Identifier == "{:"+k+"}"
+	}
+
+	return false
+}
+
+// valueFallbackResult returns the ValueFallback of the provided
+// result (if any), otherwise - the result itself.
+func valueFallbackResult(result *ResolverResult) *ResolverResult {
+	if result.ValueFallback != nil {
+		return result.ValueFallback
+	}
+
+	return result
 }
 
 func buildResolversExpr(
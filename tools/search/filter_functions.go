@@ -0,0 +1,468 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// GeoDistanceFunctionName is the name of the SQLite function used by
+// the geoDistance filter function.
+//
+// The function is expected to be registered for the db connections
+// and to call [GeoDistance] with its 4 arguments.
+const GeoDistanceFunctionName string = "geo_distance"
+
+// earthRadius is the mean Earth radius in meters.
+const earthRadius float64 = 6371008.8
+
+// functionPlaceholderPrefix is the prefix of the identifiers
+// that replace the filter function calls before parsing the filter.
+const functionPlaceholderPrefix string = "@__fn"
+
+// filterFunctions lists the supported filter functions.
+var filterFunctions = map[string]func(resolver FieldResolver, args []fexpr.Token) (*functionResult, error){
+	"geoDistance": resolveGeoDistanceFunction,
+}
+
+// GeoDistance returns the great-circle distance in meters between
+// two geographic points using the haversine formula.
+func GeoDistance(lonA, latA, lonB, latB float64) float64 {
+	dLat := toRadians(latB - latA)
+	dLon := toRadians(lonB - lonA)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(latA))*math.Cos(toRadians(latB))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// -------------------------------------------------------------------
+
+type functionCall struct {
+	name   string
+	args   []fexpr.Token
+	result *functionResult // lazily resolved
+}
+
+type functionResult struct {
+	*ResolverResult
+
+	// maxBoundExpr is an optional index friendly expression builder
+	// that narrows the `fn() < max` comparisons (eg. bounding box).
+	maxBoundExpr func(limit float64) dbx.Expression
+}
+
+// functionsResolver is a FieldResolver wrapper that resolves
+// the extracted filter function calls placeholders.
+type functionsResolver struct {
+	FieldResolver
+
+	calls []*functionCall
+}
+
+// Resolve implements the [FieldResolver.Resolve] interface method.
+func (r *functionsResolver) Resolve(field string) (*ResolverResult, error) {
+	result, err := r.resolveCall(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return r.FieldResolver.Resolve(field)
+	}
+
+	return result.ResolverResult, nil
+}
+
+// resolveCall resolves the function call associated with the
+// provided placeholder (returns nil if it is not a placeholder).
+func (r *functionsResolver) resolveCall(placeholder string) (*functionResult, error) {
+	if !strings.HasPrefix(placeholder, functionPlaceholderPrefix) {
+		return nil, nil
+	}
+
+	index, err := strconv.Atoi(strings.TrimPrefix(placeholder, functionPlaceholderPrefix))
+	if err != nil || index < 0 || index >= len(r.calls) {
+		return nil, nil
+	}
+
+	call := r.calls[index]
+
+	if call.result == nil {
+		result, err := filterFunctions[call.name](r.FieldResolver, call.args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s function call - %w", call.name, err)
+		}
+		call.result = result
+	}
+
+	return call.result, nil
+}
+
+// boundExpr returns an optional index friendly expression that could
+// be used to narrow the `fn() < number` (and its inverse) comparisons.
+func (r *functionsResolver) boundExpr(expr fexpr.Expr) dbx.Expression {
+	var fnToken, maxToken fexpr.Token
+
+	switch expr.Op {
+	case fexpr.SignLt, fexpr.SignLte:
+		fnToken, maxToken = expr.Left, expr.Right
+	case fexpr.SignGt, fexpr.SignGte:
+		fnToken, maxToken = expr.Right, expr.Left
+	default:
+		return nil
+	}
+
+	if fnToken.Type != fexpr.TokenIdentifier || maxToken.Type != fexpr.TokenNumber {
+		return nil
+	}
+
+	result, _ := r.resolveCall(fnToken.Literal)
+	if result == nil || result.maxBoundExpr == nil {
+		return nil
+	}
+
+	limit, err := strconv.ParseFloat(maxToken.Literal, 64)
+	if err != nil {
+		return nil
+	}
+
+	return result.maxBoundExpr(limit)
+}
+
+// extractFunctionCalls replaces the supported function calls in the
+// raw expression with placeholder identifiers (eg. "@__fn0").
+//
+// Returns the normalized expression and the extracted calls.
+func extractFunctionCalls(raw string) (string, []*functionCall, error) {
+	var result strings.Builder
+	var calls []*functionCall
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		// skip quoted text
+		if c == '\'' || c == '"' {
+			end := findQuoteEnd(raw, i)
+			if end < 0 {
+				end = len(raw) - 1 // let the parser report the error
+			}
+			result.WriteString(raw[i : end+1])
+			i = end
+			continue
+		}
+
+		if !isFunctionNameStart(raw, i) {
+			result.WriteByte(c)
+			continue
+		}
+
+		end := i
+		for end < len(raw) && isFunctionNameChar(raw[end]) {
+			end++
+		}
+		name := raw[i:end]
+
+		open := end
+		for open < len(raw) && (raw[open] == ' ' || raw[open] == '\t') {
+			open++
+		}
+
+		if _, ok := filterFunctions[name]; !ok || open >= len(raw) || raw[open] != '(' {
+			result.WriteString(name)
+			i = end - 1
+			continue
+		}
+
+		closing := findClosingParenthesis(raw, open)
+		if closing < 0 {
+			return "", nil, fmt.Errorf("missing closing parenthesis for function %s", name)
+		}
+
+		args, err := parseFunctionArgs(raw[open+1 : closing])
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid %s function arguments - %w", name, err)
+		}
+
+		result.WriteString(functionPlaceholderPrefix + strconv.Itoa(len(calls)))
+		calls = append(calls, &functionCall{name: name, args: args})
+
+		i = closing
+	}
+
+	return result.String(), calls, nil
+}
+
+// parseFunctionArgs parses the comma separated function arguments.
+//
+// Each argument must be a single identifier, number or quoted text token.
+func parseFunctionArgs(raw string) ([]fexpr.Token, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	parts := splitTopLevel(raw, ',')
+
+	args := make([]fexpr.Token, 0, len(parts))
+
+	for _, part := range parts {
+		scanner := fexpr.NewScanner(strings.NewReader(strings.TrimSpace(part)))
+
+		token, err := scanner.Scan()
+		if err != nil {
+			return nil, err
+		}
+
+		switch token.Type {
+		case fexpr.TokenIdentifier, fexpr.TokenNumber, fexpr.TokenText:
+		default:
+			return nil, fmt.Errorf("unsupported argument %q", part)
+		}
+
+		if next, _ := scanner.Scan(); next.Type != fexpr.TokenEOF {
+			return nil, fmt.Errorf("unsupported argument %q", part)
+		}
+
+		args = append(args, token)
+	}
+
+	return args, nil
+}
+
+// splitTopLevel splits the provided string by the separator
+// ignoring the ones in quotes or parenthesis.
+func splitTopLevel(str string, sep byte) []string {
+	var result []string
+	var depth int
+
+	start := 0
+
+	for i := 0; i < len(str); i++ {
+		switch c := str[i]; {
+		case c == '\'' || c == '"':
+			if end := findQuoteEnd(str, i); end > 0 {
+				i = end
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == sep && depth <= 0:
+			result = append(result, str[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(result, str[start:])
+}
+
+// findQuoteEnd returns the position of the closing quote
+// of the text starting at the start position (or -1 if missing).
+func findQuoteEnd(str string, start int) int {
+	for i := start + 1; i < len(str); i++ {
+		if str[i] == str[start] && str[i-1] != '\\' {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findClosingParenthesis returns the position of the closing parenthesis
+// of the group starting at the open position (or -1 if missing).
+func findClosingParenthesis(str string, open int) int {
+	var depth int
+
+	for i := open; i < len(str); i++ {
+		switch str[i] {
+		case '\'', '"':
+			end := findQuoteEnd(str, i)
+			if end < 0 {
+				return -1
+			}
+			i = end
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func isFunctionNameStart(str string, i int) bool {
+	c := str[i]
+	if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')) {
+		return false
+	}
+
+	// part of another identifier (eg. "a.geoDistance")
+	if i > 0 {
+		prev := str[i-1]
+		if isFunctionNameChar(prev) || prev == '.' || prev == ':' || prev == '@' || prev == '#' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isFunctionNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// -------------------------------------------------------------------
+
+// resolveGeoDistanceFunction resolves the `geoDistance(field, lon, lat)`
+// function call into a distance in meters db expression.
+func resolveGeoDistanceFunction(resolver FieldResolver, args []fexpr.Token) (*functionResult, error) {
+	if len(args) != 3 {
+		return nil, errors.New("expected exactly 3 arguments - geoDistance(field, lon, lat)")
+	}
+
+	if args[0].Type != fexpr.TokenIdentifier {
+		return nil, errors.New("the first argument must be a geoPoint field")
+	}
+
+	field, err := resolver.Resolve(args[0].Literal)
+	if err != nil || field.Identifier == "" {
+		return nil, fmt.Errorf("failed to resolve field %q", args[0].Literal)
+	}
+	if field.EqualityValueTransform != nil {
+		return nil, fmt.Errorf("field %q could be used only in equality comparisons", args[0].Literal)
+	}
+	if field.MultiMatchSubQuery != nil {
+		return nil, fmt.Errorf("multiple values field %q is not supported", args[0].Literal)
+	}
+
+	lon, lonValue, err := resolveGeoCoordinateArg(args[1], resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	lat, latValue, err := resolveGeoCoordinateArg(args[2], resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &functionResult{
+		ResolverResult: &ResolverResult{
+			Identifier: fmt.Sprintf(
+				"%s(json_extract(%s, '$.lon'), json_extract(%s, '$.lat'), %s, %s)",
+				GeoDistanceFunctionName,
+				field.Identifier,
+				field.Identifier,
+				lon.Identifier,
+				lat.Identifier,
+			),
+			Params:     mergeParams(field.Params, lon.Params, lat.Params),
+			AfterBuild: field.AfterBuild,
+		},
+	}
+
+	if lonValue != nil && latValue != nil {
+		result.maxBoundExpr = func(limit float64) dbx.Expression {
+			return geoBoundingBoxExpr(field.Identifier, *lonValue, *latValue, limit)
+		}
+	}
+
+	return result, nil
+}
+
+// resolveGeoCoordinateArg resolves a single geoDistance coordinate argument.
+//
+// Number literals are inlined (so that the expression could be also used for sorting)
+// and their value is returned as second result.
+func resolveGeoCoordinateArg(token fexpr.Token, resolver FieldResolver) (*ResolverResult, *float64, error) {
+	if token.Type == fexpr.TokenNumber {
+		v, err := strconv.ParseFloat(token.Literal, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid coordinate %q", token.Literal)
+		}
+
+		return &ResolverResult{Identifier: strconv.FormatFloat(v, 'f', -1, 64)}, &v, nil
+	}
+
+	result, err := resolveToken(token, resolver)
+	if err != nil || result.Identifier == "" {
+		return nil, nil, fmt.Errorf("invalid coordinate %q", token.Literal)
+	}
+
+	return valueFallbackResult(result), nil, nil
+}
+
+// geoBoundingBoxExpr returns a bounding box expression containing
+// all points within distance meters from the specified center point.
+//
+// The expression uses only json_extract lat/lon comparisons so that
+// it could benefit from the related expression indexes.
+func geoBoundingBoxExpr(fieldIdentifier string, lon, lat, distance float64) dbx.Expression {
+	if distance < 0 {
+		return nil
+	}
+
+	// small tolerance to compensate the float rounding errors
+	const tolerance = 1e-9
+
+	angular := distance / earthRadius
+	dLat := toDegrees(angular) + tolerance
+
+	params := dbx.Params{}
+	addParam := func(v float64) string {
+		placeholder := "t" + security.PseudorandomString(5)
+		params[placeholder] = v
+		return "{:" + placeholder + "}"
+	}
+
+	minLat := math.Max(lat-dLat, -90)
+	maxLat := math.Min(lat+dLat, 90)
+
+	latExpr := fmt.Sprintf(
+		"json_extract(%s, '$.lat') BETWEEN %s AND %s",
+		fieldIdentifier,
+		addParam(minLat),
+		addParam(maxLat),
+	)
+
+	// the circle contains one of the poles
+	// (all longitudes are possible)
+	if minLat <= -90 || maxLat >= 90 {
+		return dbx.NewExp(latExpr, params)
+	}
+
+	dLon := toDegrees(math.Asin(math.Sin(angular)/math.Cos(toRadians(lat)))) + tolerance
+
+	// the circle crosses the antimeridian
+	// (for simplicity constraint only the latitude)
+	if lon-dLon < -180 || lon+dLon > 180 {
+		return dbx.NewExp(latExpr, params)
+	}
+
+	lonExpr := fmt.Sprintf(
+		"json_extract(%s, '$.lon') BETWEEN %s AND %s",
+		fieldIdentifier,
+		addParam(lon-dLon),
+		addParam(lon+dLon),
+	)
+
+	return dbx.NewExp(latExpr+" AND "+lonExpr, params)
+}
@@ -0,0 +1,121 @@
+package search_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+	"modernc.org/sqlite"
+)
+
+func TestGeoDistance(t *testing.T) {
+	scenarios := []struct {
+		lonA, latA, lonB, latB float64
+		expected               float64 // in meters
+	}{
+		{0, 0, 0, 0, 0},
+		{23.32, 42.69, 23.32, 42.69, 0},
+		{0, 0, 1, 0, 111195},
+		{0, 0, 0, 1, 111195},
+		{-180, 0, 180, 0, 0},
+		{23.3219, 42.6977, 24.7453, 42.1354, 132522},    // Sofia - Plovdiv
+		{-0.1276, 51.5072, 2.3522, 48.8566, 343530},     // London - Paris
+		{-74.006, 40.7128, 139.6503, 35.6762, 10851748}, // New York - Tokyo
+	}
+
+	for i, s := range scenarios {
+		result := search.GeoDistance(s.lonA, s.latA, s.lonB, s.latB)
+
+		// 1m tolerance
+		if math.Abs(result-s.expected) > 1 {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestGeoDistanceFilterAndSort(t *testing.T) {
+	sqlite.MustRegisterDeterministicScalarFunction(
+		search.GeoDistanceFunctionName,
+		4,
+		func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			return search.GeoDistance(
+				cast.ToFloat64(args[0]),
+				cast.ToFloat64(args[1]),
+				cast.ToFloat64(args[2]),
+				cast.ToFloat64(args[3]),
+			), nil
+		},
+	)
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := dbx.NewFromDB(sqlDB, "sqlite")
+	defer db.Close()
+
+	_, err = db.NewQuery(`
+		CREATE TABLE places (name TEXT, point JSON);
+		INSERT INTO places VALUES
+			('sofia',   '{"lon":23.3219,"lat":42.6977}'),
+			('plovdiv', '{"lon":24.7453,"lat":42.1354}'),
+			('varna',   '{"lon":27.9147,"lat":43.2141}'),
+			('london',  '{"lon":-0.1276,"lat":51.5072}');
+	`).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := search.NewSimpleFieldResolver("name", "point")
+
+	scenarios := []struct {
+		filter   string
+		sort     string
+		expected []string
+	}{
+		{"geoDistance(point, 23.3219, 42.6977) < 1", "name", []string{"sofia"}},
+		{"geoDistance(point, 23.3219, 42.6977) <= 132000", "name", []string{"sofia"}},
+		{"geoDistance(point, 23.3219, 42.6977) <= 132523", "geoDistance(point, 23.3219, 42.6977)", []string{"sofia", "plovdiv"}},
+		{"geoDistance(point, 23.3219, 42.6977) < 140000", "-name", []string{"sofia", "plovdiv"}},
+		{"400000 > geoDistance(point, 23.3219, 42.6977)", "-geoDistance(point, 23.3219, 42.6977)", []string{"varna", "plovdiv", "sofia"}},
+		{"geoDistance(point, 23.3219, 42.6977) > 400000", "name", []string{"london"}},
+		{"geoDistance(point, 0, 51) < 10000000", "geoDistance(point,0,51),name", []string{"london", "sofia", "plovdiv", "varna"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.filter, func(t *testing.T) {
+			expr, err := search.FilterData(s.filter).BuildExpr(resolver)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			query := db.Select("name").From("places").Where(expr)
+
+			for _, sortField := range search.ParseSortFromString(s.sort) {
+				sortExpr, err := sortField.BuildExpr(resolver)
+				if err != nil {
+					t.Fatal(err)
+				}
+				query.AndOrderBy(sortExpr)
+			}
+
+			var names []string
+			if err := query.Column(&names); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(names) != len(s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, names)
+			}
+			for i, name := range s.expected {
+				if names[i] != name {
+					t.Fatalf("Expected %v, got %v", s.expected, names)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package search_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"regexp"
 	"strings"
 	"testing"
@@ -138,6 +139,66 @@ func TestFilterDataBuildExpr(t *testing.T) {
 			false,
 			"((COALESCE([[test1]], '') = COALESCE([[test2]], '') OR COALESCE([[test2]], '') IS NOT COALESCE([[test3]], '')) AND ([[test2]] LIKE {:TEST} ESCAPE '\\' OR [[test2]] NOT LIKE {:TEST} ESCAPE '\\') AND {:TEST} LIKE ('%' || [[test1]] || '%') ESCAPE '\\' AND {:TEST} NOT LIKE ('%' || [[test2]] || '%') ESCAPE '\\' AND [[test3]] > {:TEST} AND [[test3]] >= {:TEST} AND [[test3]] <= {:TEST} AND {:TEST} < {:TEST})",
 		},
+		{
+			"geoDistance with unknown field",
+			"geoDistance(unknown, 1, 2) < 10",
+			true,
+			"",
+		},
+		{
+			"geoDistance with invalid number of arguments",
+			"geoDistance(test1, 1) < 10",
+			true,
+			"",
+		},
+		{
+			"geoDistance with invalid argument",
+			"geoDistance(test1, 1 + 2, 3) < 10",
+			true,
+			"",
+		},
+		{
+			"geoDistance with missing closing parenthesis",
+			"geoDistance(test1, 1, 2 < 10",
+			true,
+			"",
+		},
+		{
+			"geoDistance inside quoted text",
+			"test1 = 'geoDistance(test2, 1, 2)'",
+			false,
+			"[[test1]] = {:TEST}",
+		},
+		{
+			"geoDistance with bounding box",
+			"geoDistance(test1, 23.32, 42.69) < 1000",
+			false,
+			"(((json_extract([[test1]], '$.lat') BETWEEN {:TEST} AND {:TEST} AND json_extract([[test1]], '$.lon') BETWEEN {:TEST} AND {:TEST}) AND (geo_distance(json_extract([[test1]], '$.lon'), json_extract([[test1]], '$.lat'), 23.32, 42.69) < {:TEST})))",
+		},
+		{
+			"geoDistance with inverse bounding box",
+			"1000 >= geoDistance(test1, -23, 42)",
+			false,
+			"(((json_extract([[test1]], '$.lat') BETWEEN {:TEST} AND {:TEST} AND json_extract([[test1]], '$.lon') BETWEEN {:TEST} AND {:TEST}) AND ({:TEST} >= geo_distance(json_extract([[test1]], '$.lon'), json_extract([[test1]], '$.lat'), -23, 42))))",
+		},
+		{
+			"geoDistance bounding box containing a pole",
+			"geoDistance(test1, 23, 89.99) < 5000",
+			false,
+			"(((json_extract([[test1]], '$.lat') BETWEEN {:TEST} AND {:TEST}) AND (geo_distance(json_extract([[test1]], '$.lon'), json_extract([[test1]], '$.lat'), 23, 89.99) < {:TEST})))",
+		},
+		{
+			"geoDistance bounding box crossing the antimeridian",
+			"geoDistance(test1, 179.99, 0) < 5000",
+			false,
+			"(((json_extract([[test1]], '$.lat') BETWEEN {:TEST} AND {:TEST}) AND (geo_distance(json_extract([[test1]], '$.lon'), json_extract([[test1]], '$.lat'), 179.99, 0) < {:TEST})))",
+		},
+		{
+			"geoDistance without bounding box",
+			"geoDistance(test1, test2, '42') > 1000 && geoDistance (test1,1,2) = 3",
+			false,
+			"(geo_distance(json_extract([[test1]], '$.lon'), json_extract([[test1]], '$.lat'), [[test2]], {:TEST}) > {:TEST} AND geo_distance(json_extract([[test1]], '$.lon'), json_extract([[test1]], '$.lat'), 1, 2) = {:TEST})",
+		},
 	}
 
 	for _, s := range scenarios {
@@ -238,3 +299,68 @@ func TestFilterDataBuildExprWithParams(t *testing.T) {
 		t.Fatalf("Expected query \n%s, \ngot \n%s", expectedQuery, calledQueries[0])
 	}
 }
+
+// testValueTransformResolver resolves the "raw" field with a doubling
+// literal values transform and "formatted" column value fallback.
+type testValueTransformResolver struct{}
+
+func (r *testValueTransformResolver) UpdateQuery(query *dbx.SelectQuery) error {
+	return nil
+}
+
+func (r *testValueTransformResolver) Resolve(field string) (*search.ResolverResult, error) {
+	switch field {
+	case "raw":
+		return &search.ResolverResult{
+			Identifier: "[[raw]]",
+			ValueTransform: func(value any) any {
+				v, _ := value.(float64)
+				return v * 2
+			},
+			ValueFallback: &search.ResolverResult{Identifier: "[[formatted]]"},
+		}, nil
+	case "other":
+		return &search.ResolverResult{Identifier: "[[other]]"}, nil
+	}
+
+	return nil, errors.New("unknown field")
+}
+
+func TestFilterDataBuildExprWithValueTransform(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := dbx.NewFromDB(sqlDB, "sqlite")
+
+	scenarios := []struct {
+		filter   search.FilterData
+		expected string
+	}{
+		{"raw > 2", "SELECT * WHERE [[raw]] > 4"},
+		{"3 <= raw", "SELECT * WHERE 6 <= [[raw]]"},
+		{"raw = 1", "SELECT * WHERE [[raw]] = 2"},
+		{"raw ~ 2", "SELECT * WHERE [[formatted]] LIKE '%2%' ESCAPE '\\'"},
+		{"raw > other", "SELECT * WHERE [[formatted]] > [[other]]"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(string(s.filter), func(t *testing.T) {
+			var calledQuery string
+			db.ExecLogFunc = func(ctx context.Context, t time.Duration, sql string, result sql.Result, err error) {
+				calledQuery = sql
+			}
+
+			expr, err := s.filter.BuildExpr(&testValueTransformResolver{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			db.Select().Where(expr).Build().Execute()
+
+			if calledQuery != s.expected {
+				t.Fatalf("Expected query \n%s, \ngot \n%s", s.expected, calledQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// fullTextTermRegex matches a single full-text search term
+// (similar to the FTS5 "unicode61" tokenizer token characters).
+var fullTextTermRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// FullTextTerms extracts the unique lowercased search terms from the provided text.
+//
+// Example:
+//
+//	search.FullTextTerms(`Hello, "world" hello`) // []string{"hello", "world"}
+func FullTextTerms(text string) []string {
+	matches := fullTextTermRegex.FindAllString(strings.ToLower(text), -1)
+
+	terms := make([]string, 0, len(matches))
+
+	for _, m := range matches {
+		if !list.ExistInSlice(m, terms) {
+			terms = append(terms, m)
+		}
+	}
+
+	return terms
+}
+
+// FullTextMatchQuery converts the provided plain text into a safe FTS5
+// MATCH query expression that matches all of its terms as prefixes.
+//
+// Returns an empty string if the text doesn't have any search term.
+//
+// Example:
+//
+//	search.FullTextMatchQuery(`hello "wor`) // `"hello"* "wor"*`
+func FullTextMatchQuery(text string) string {
+	terms := FullTextTerms(text)
+
+	for i, t := range terms {
+		terms[i] = `"` + t + `"*`
+	}
+
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,46 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+func TestFullTextTerms(t *testing.T) {
+	scenarios := []struct {
+		text     string
+		expected string
+	}{
+		{"", ""},
+		{` " * - `, ""},
+		{"Hello", "hello"},
+		{`Hello, "world" hello OR ß_Ä1`, "hello,world,or,ß,ä1"},
+	}
+
+	for i, s := range scenarios {
+		result := strings.Join(search.FullTextTerms(s.text), ",")
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestFullTextMatchQuery(t *testing.T) {
+	scenarios := []struct {
+		text     string
+		expected string
+	}{
+		{"", ""},
+		{` " * - `, ""},
+		{`hello "wor`, `"hello"* "wor"*`},
+		{`NEAR(a b) OR c*`, `"near"* "a"* "b"* "or"* "c"*`},
+	}
+
+	for i, s := range scenarios {
+		result := search.FullTextMatchQuery(s.text)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
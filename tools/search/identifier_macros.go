@@ -113,3 +113,22 @@ var identifierMacros = map[string]func() (any, error){
 		return d.String(), nil
 	},
 }
+
+// IsIdentifierMacro checks whether the provided name is a
+// known filter identifier macro (eg. "@now", "@todayStart", etc.).
+func IsIdentifierMacro(name string) bool {
+	_, ok := identifierMacros[name]
+
+	return ok
+}
+
+// IdentifierMacroValue resolves and returns the current value of the
+// provided filter identifier macro (eg. "@now").
+func IdentifierMacroValue(name string) (any, error) {
+	macroFunc, ok := identifierMacros[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown identifier macro %q", name)
+	}
+
+	return macroFunc()
+}
@@ -54,3 +54,46 @@ func TestIdentifierMacros(t *testing.T) {
 	// restore
 	timeNow = originalTimeNow
 }
+
+func TestIsIdentifierMacro(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		expected bool
+	}{
+		{"", false},
+		{"now", false},
+		{"@missing", false},
+		{"@now", true},
+		{"@yearEnd", true},
+	}
+
+	for _, s := range scenarios {
+		if result := IsIdentifierMacro(s.name); result != s.expected {
+			t.Errorf("[%s] Expected %v, got %v", s.name, s.expected, result)
+		}
+	}
+}
+
+func TestIdentifierMacroValue(t *testing.T) {
+	originalTimeNow := timeNow
+	defer func() {
+		timeNow = originalTimeNow
+	}()
+
+	timeNow = func() time.Time {
+		return time.Date(2023, 2, 3, 4, 5, 6, 7, time.UTC)
+	}
+
+	if _, err := IdentifierMacroValue("@missing"); err == nil {
+		t.Fatal("Expected error for unknown macro, got nil")
+	}
+
+	result, err := IdentifierMacroValue("@now")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "2023-02-03 04:05:06.000Z"; result != expected {
+		t.Fatalf("Expected %q, got %q", expected, result)
+	}
+}
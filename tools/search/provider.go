@@ -2,9 +2,12 @@ package search
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/pocketbase/dbx"
 	"golang.org/x/sync/errgroup"
@@ -23,6 +26,10 @@ const (
 	SortQueryParam      string = "sort"
 	FilterQueryParam    string = "filter"
 	SkipTotalQueryParam string = "skipTotal"
+	AfterQueryParam     string = "after"
+	BeforeQueryParam    string = "before"
+	GroupByQueryParam   string = "groupBy"
+	AggregateQueryParam string = "aggregate"
 )
 
 // Result defines the returned search result structure.
@@ -32,6 +39,12 @@ type Result struct {
 	TotalItems int `json:"totalItems"`
 	TotalPages int `json:"totalPages"`
 	Items      any `json:"items"`
+
+	// NextCursor and PrevCursor are set only in cursor pagination mode
+	// and could be used as "after" and "before" query param values to
+	// fetch the next and previous page (empty if there are no more items).
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
 }
 
 // Provider represents a single configured search provider instance.
@@ -44,6 +57,11 @@ type Provider struct {
 	perPage       int
 	sort          []SortField
 	filter        []FilterData
+	cursor        string
+	cursorBefore  bool
+	cursorMode    bool
+	groupBy       []string
+	aggregate     []AggregateField
 }
 
 // NewProvider creates and returns a new search provider.
@@ -65,6 +83,8 @@ func NewProvider(fieldResolver FieldResolver) *Provider {
 		perPage:       DefaultPerPage,
 		sort:          []SortField{},
 		filter:        []FilterData{},
+		groupBy:       []string{},
+		aggregate:     []AggregateField{},
 	}
 }
 
@@ -105,6 +125,33 @@ func (s *Provider) PerPage(perPage int) *Provider {
 	return s
 }
 
+// After enables the cursor (aka. keyset) pagination mode and
+// fetches the items positioned after the provided cursor.
+//
+// An empty cursor fetches the first page.
+// The `page` field is ignored in cursor pagination mode.
+//
+// The page cursors are built from the fetched items, so they must be either
+// map rows (eg. dbx.NullStringMap) or structs with db mapped sort columns.
+func (s *Provider) After(cursor string) *Provider {
+	s.cursor = cursor
+	s.cursorBefore = false
+	s.cursorMode = true
+	return s
+}
+
+// Before enables the cursor (aka. keyset) pagination mode and
+// fetches the items positioned before the provided cursor.
+//
+// An empty cursor fetches the last page.
+// The `page` field is ignored in cursor pagination mode.
+func (s *Provider) Before(cursor string) *Provider {
+	s.cursor = cursor
+	s.cursorBefore = true
+	s.cursorMode = true
+	return s
+}
+
 // Sort sets the `sort` field of the current search provider.
 func (s *Provider) Sort(sort []SortField) *Provider {
 	s.sort = sort
@@ -131,6 +178,36 @@ func (s *Provider) AddFilter(filter FilterData) *Provider {
 	return s
 }
 
+// GroupBy sets the `groupBy` field of the current search provider.
+//
+// The group by fields are used only by `ExecAggregate()`.
+func (s *Provider) GroupBy(fields []string) *Provider {
+	s.groupBy = fields
+	return s
+}
+
+// AddGroupBy appends the provided field to the existing provider's groupBy field.
+func (s *Provider) AddGroupBy(field string) *Provider {
+	if field != "" {
+		s.groupBy = append(s.groupBy, field)
+	}
+	return s
+}
+
+// Aggregate sets the `aggregate` field of the current search provider.
+//
+// The aggregate fields are used only by `ExecAggregate()`.
+func (s *Provider) Aggregate(fields []AggregateField) *Provider {
+	s.aggregate = fields
+	return s
+}
+
+// AddAggregate appends the provided AggregateField to the existing provider's aggregate field.
+func (s *Provider) AddAggregate(field AggregateField) *Provider {
+	s.aggregate = append(s.aggregate, field)
+	return s
+}
+
 // Parse parses the search query parameter from the provided query string
 // and assigns the found fields to the current search provider.
 //
@@ -167,6 +244,16 @@ func (s *Provider) Parse(urlQuery string) error {
 		s.PerPage(v)
 	}
 
+	if params.Has(AfterQueryParam) && params.Has(BeforeQueryParam) {
+		return errors.New("the after and before cursors cannot be used together")
+	}
+
+	if params.Has(AfterQueryParam) {
+		s.After(params.Get(AfterQueryParam))
+	} else if params.Has(BeforeQueryParam) {
+		s.Before(params.Get(BeforeQueryParam))
+	}
+
 	if raw := params.Get(SortQueryParam); raw != "" {
 		for _, sortField := range ParseSortFromString(raw) {
 			s.AddSort(sortField)
@@ -177,6 +264,22 @@ func (s *Provider) Parse(urlQuery string) error {
 		s.AddFilter(FilterData(raw))
 	}
 
+	if raw := params.Get(GroupByQueryParam); raw != "" {
+		for _, field := range strings.Split(raw, ",") {
+			s.AddGroupBy(strings.TrimSpace(field))
+		}
+	}
+
+	if raw := params.Get(AggregateQueryParam); raw != "" {
+		fields, err := ParseAggregateFromString(raw)
+		if err != nil {
+			return err
+		}
+		for _, field := range fields {
+			s.AddAggregate(field)
+		}
+	}
+
 	return nil
 }
 
@@ -202,13 +305,31 @@ func (s *Provider) Exec(items any) (*Result, error) {
 	}
 
 	// apply sorting
-	for _, sortField := range s.sort {
-		expr, err := sortField.BuildExpr(s.fieldResolver)
+	var cursorSort []cursorSortItem
+	if s.cursorMode {
+		var err error
+		cursorSort, err = s.resolveCursorSort(&modelsQuery)
 		if err != nil {
 			return nil, err
 		}
-		if expr != "" {
-			modelsQuery.AndOrderBy(expr)
+
+		for _, item := range cursorSort {
+			// the order is reversed when fetching the items before the cursor
+			if item.desc != s.cursorBefore {
+				modelsQuery.AndOrderBy(item.identifier + " " + SortDesc)
+			} else {
+				modelsQuery.AndOrderBy(item.identifier + " " + SortAsc)
+			}
+		}
+	} else {
+		for _, sortField := range s.sort {
+			expr, err := sortField.BuildExpr(s.fieldResolver)
+			if err != nil {
+				return nil, err
+			}
+			if expr != "" {
+				modelsQuery.AndOrderBy(expr)
+			}
 		}
 	}
 
@@ -256,38 +377,422 @@ func (s *Provider) Exec(items any) (*Result, error) {
 		return nil
 	}
 
+	// apply the cursor to the models query only
+	// (the total count is not affected by the cursor position)
+	if s.cursorMode {
+		if s.cursor != "" {
+			values, err := decodeCursor(s.cursor, len(cursorSort))
+			if err != nil {
+				return nil, err
+			}
+			modelsQuery.AndWhere(buildCursorExpr(cursorSort, values, s.cursorBefore))
+		}
+
+		// select the sort values together with the models
+		// so that the page cursors could be built from the fetched items
+		if len(modelsQuery.Info().Selects) == 0 {
+			modelsQuery.AndSelect("*")
+		}
+		modelsQuery.AndSelect(buildCursorValuesSelect(cursorSort))
+	}
+
 	// apply pagination to the original query and fetch the models
 	modelsExec := func() error {
+		if s.cursorMode {
+			// fetch one extra item to check whether there are more items
+			modelsQuery.Limit(int64(s.perPage) + 1)
+			return modelsQuery.All(items)
+		}
+
 		modelsQuery.Limit(int64(s.perPage))
 		modelsQuery.Offset(int64(s.perPage * (s.page - 1)))
 
 		return modelsQuery.All(items)
 	}
 
+	// execute the queries concurrently
+	errg := new(errgroup.Group)
+	errg.SetLimit(2)
+	errg.Go(modelsExec)
 	if !s.skipTotal {
-		// execute the 2 queries concurrently
-		errg := new(errgroup.Group)
-		errg.SetLimit(2)
 		errg.Go(countExec)
-		errg.Go(modelsExec)
-		if err := errg.Wait(); err != nil {
+	}
+	if err := errg.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Page:       s.page,
+		PerPage:    s.perPage,
+		TotalItems: totalCount,
+		TotalPages: totalPages,
+		Items:      items,
+	}
+
+	if s.cursorMode {
+		if err := s.fillCursors(result, cursorSort); err != nil {
 			return nil, err
 		}
+	}
+
+	return result, nil
+}
+
+// resolveCursorSort resolves the provider sort fields into keyset
+// sort columns, always ending with the unique count column as tiebreaker.
+func (s *Provider) resolveCursorSort(query *dbx.SelectQuery) ([]cursorSortItem, error) {
+	result := make([]cursorSortItem, 0, len(s.sort)+1)
+
+	for _, sortField := range s.sort {
+		if sortField.Name == randomSortKey {
+			return nil, errors.New("random sort is not supported with cursor pagination")
+		}
+
+		identifier, err := sortField.resolveIdentifier(s.fieldResolver)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, cursorSortItem{
+			identifier: identifier,
+			column:     identifierColumn(identifier),
+			desc:       strings.EqualFold(sortField.Direction, SortDesc),
+		})
+	}
+
+	idCol := s.countCol
+	if from := query.Info().From; len(from) > 0 {
+		idCol = from[0] + "." + idCol
+	}
+	idCol = "[[" + idCol + "]]"
+
+	if len(result) == 0 || result[len(result)-1].identifier != idCol {
+		result = append(result, cursorSortItem{identifier: idCol, column: s.countCol})
+	}
+
+	return result, nil
+}
+
+// fillCursors populates the result next and previous page cursors
+// from the fetched items sort values, trims the extra fetched item
+// and restores the items order when fetching backward.
+func (s *Provider) fillCursors(result *Result, sortItems []cursorSortItem) error {
+	rv := reflect.ValueOf(result.Items)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("items must be a pointer to a slice")
+	}
+	slice := rv.Elem()
+
+	hasMore := slice.Len() > s.perPage
+	if hasMore {
+		slice.Set(slice.Slice(0, s.perPage))
+	}
+
+	if slice.Len() == 0 {
+		return nil
+	}
+
+	// the items are in the fetch order (reversed for "before")
+	firstFetched, err := itemCursor(slice.Index(0), sortItems)
+	if err != nil {
+		return err
+	}
+	lastFetched, err := itemCursor(slice.Index(slice.Len()-1), sortItems)
+	if err != nil {
+		return err
+	}
+
+	// remove the helper sort values column from the items (if any)
+	for i := 0; i < slice.Len(); i++ {
+		deleteItemCursorValues(slice.Index(i))
+	}
+
+	if s.cursorBefore {
+		if err := reverseItems(result.Items); err != nil {
+			return err
+		}
+		if hasMore {
+			result.PrevCursor = lastFetched
+		}
+		if s.cursor != "" {
+			result.NextCursor = firstFetched
+		}
 	} else {
-		if err := modelsExec(); err != nil {
+		if hasMore {
+			result.NextCursor = lastFetched
+		}
+		if s.cursor != "" {
+			result.PrevCursor = firstFetched
+		}
+	}
+
+	return nil
+}
+
+// aggregateRowIdAlias is the column alias of the aggregated rows identifier.
+const aggregateRowIdAlias = "__rowId"
+
+// ExecAggregate executes the search provider in aggregate mode
+// and returns the found groups as Result items ([]map[string]any).
+//
+// Each result item contains the `groupBy` field values and the
+// `aggregate` values keyed by their `AggregateField.Key()`.
+// The sort fields could reference only the group by fields and
+// the aggregate expressions (eg. "-count()").
+//
+// The aggregation is performed over the distinct filtered rows
+// (one per each row and group values combination) so that the resolver
+// joins (eg. from a multiple relation filter) don't duplicate the
+// aggregated values. For the same reason the aggregate fields must
+// be single value fields.
+//
+// The groups are paginated with the provider's page and perPage
+// fields but they are not counted (aka. TotalItems and TotalPages are -1).
+func (s *Provider) ExecAggregate() (*Result, error) {
+	if s.query == nil {
+		return nil, errors.New("query is not set")
+	}
+
+	if len(s.aggregate) == 0 {
+		return nil, errors.New("at least one aggregate field is required")
+	}
+
+	// shallow clone the provider's query
+	rowsQuery := *s.query
+
+	// build filters
+	for _, f := range s.filter {
+		expr, err := f.BuildExpr(s.fieldResolver)
+		if err != nil {
 			return nil, err
 		}
+		if expr != nil {
+			rowsQuery.AndWhere(expr)
+		}
 	}
 
-	result := &Result{
+	countExpr := s.countCol
+	if from := rowsQuery.Info().From; len(from) > 0 {
+		countExpr = from[0] + "." + countExpr
+	}
+
+	// the distinct rows query columns (row id, group values and aggregate fields)
+	rowsSelects := []string{"[[" + countExpr + "]] AS [[" + aggregateRowIdAlias + "]]"}
+
+	keys := make([]string, 0, len(s.groupBy)+len(s.aggregate))
+	selects := make([]string, 0, len(s.groupBy)+len(s.aggregate))
+
+	// apply grouping
+	groupColumns := make(map[string]string, len(s.groupBy))
+	groupBy := make([]string, 0, len(s.groupBy))
+	for _, name := range s.groupBy {
+		field := SortField{Name: name}
+		result, err := field.resolve(s.fieldResolver)
+		if err != nil {
+			return nil, fmt.Errorf("invalid group by field %q", name)
+		}
+		identifier := valueFallbackResult(result).Identifier
+
+		column := fmt.Sprintf("__group%d", len(groupColumns))
+		groupColumns[name] = "[[" + column + "]]"
+		rowsSelects = append(rowsSelects, identifier+" AS [["+column+"]]")
+		groupBy = append(groupBy, groupColumns[name])
+
+		keys = append(keys, name)
+		selects = append(selects, groupColumns[name])
+	}
+
+	// resolve the aggregate fields as distinct rows query columns
+	aggregateColumns := aggregateColumnsResolver{}
+	rawAggregateColumns := aggregateColumnsResolver{}
+	aggregateTransforms := map[string]func(value any) any{}
+	resolveAggregateField := func(name string) error {
+		if _, ok := aggregateColumns[name]; name == "" || ok {
+			return nil // no field or already resolved
+		}
+
+		sortField := SortField{Name: name}
+		result, err := sortField.resolve(s.fieldResolver)
+		if err != nil {
+			return fmt.Errorf("invalid aggregate field %q", name)
+		}
+		fallback := valueFallbackResult(result)
+		if fallback.MultiMatchSubQuery != nil {
+			return fmt.Errorf("the aggregate field %q must be a single value field", name)
+		}
+
+		column := fmt.Sprintf("__aggregate%d", len(aggregateColumns))
+		aggregateColumns[name] = "[[" + column + "]]"
+		rowsSelects = append(rowsSelects, fallback.Identifier+" AS [["+column+"]]")
+
+		if result.AggregateValueTransform != nil && result.MultiMatchSubQuery == nil {
+			rawAggregateColumns[name] = "[[" + column + "_raw]]"
+			rowsSelects = append(rowsSelects, result.Identifier+" AS "+rawAggregateColumns[name])
+			aggregateTransforms[name] = result.AggregateValueTransform
+		}
+
+		return nil
+	}
+	buildAggregate := func(field AggregateField) (string, func(value any) any, error) {
+		if err := resolveAggregateField(field.Field); err != nil {
+			return "", nil, err
+		}
+
+		if transform := aggregateTransforms[field.Field]; transform != nil {
+			switch field.Func {
+			case AggregateSum, AggregateMin, AggregateMax:
+				expr, err := field.BuildExpr(rawAggregateColumns, "[["+aggregateRowIdAlias+"]]")
+				return expr, transform, err
+			}
+		}
+
+		expr, err := field.BuildExpr(aggregateColumns, "[["+aggregateRowIdAlias+"]]")
+		return expr, nil, err
+	}
+
+	// build the aggregate expressions
+	transforms := make(map[string]func(value any) any, len(s.aggregate))
+	for _, field := range s.aggregate {
+		expr, transform, err := buildAggregate(field)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, field.Key())
+		selects = append(selects, expr)
+
+		if transform != nil {
+			transforms[field.Key()] = transform
+		}
+	}
+
+	// build the sort expressions
+	orderBy := make([]string, 0, len(s.sort))
+	for _, sortField := range s.sort {
+		var expr string
+		if field, ok := parseAggregate(sortField.Name); ok {
+			var err error
+			expr, _, err = buildAggregate(field)
+			if err != nil {
+				return nil, err
+			}
+		} else if column, ok := groupColumns[sortField.Name]; ok {
+			expr = column
+		} else {
+			return nil, fmt.Errorf("the sort field %q must be a group by field or an aggregate expression", sortField.Name)
+		}
+
+		orderBy = append(orderBy, expr+" "+sortField.Direction)
+	}
+	if len(s.sort) == 0 {
+		// ensure a consistent groups order
+		for _, column := range groupBy {
+			orderBy = append(orderBy, column+" "+SortAsc)
+		}
+	}
+
+	// apply field resolver query modifications (if any)
+	if err := s.fieldResolver.UpdateQuery(&rowsQuery); err != nil {
+		return nil, err
+	}
+
+	// normalize page
+	if s.page <= 0 {
+		s.page = 1
+	}
+
+	// normalize perPage
+	if s.perPage <= 0 {
+		s.perPage = DefaultPerPage
+	} else if s.perPage > MaxPerPage {
+		s.perPage = MaxPerPage
+	}
+
+	// note: the query is shallow cloned and slice/map in-place modifications should be avoided
+	rowsSQL := rowsQuery.Distinct(true).
+		Select(rowsSelects...).
+		OrderBy( /* reset */ ).
+		Limit(-1).
+		Offset(0).
+		Build()
+
+	info := rowsQuery.Info()
+
+	query := info.Builder.Select(selects...).
+		From("(" + rowsSQL.SQL() + ") AS [[__rows]]").
+		Bind(rowsSQL.Params()).
+		OrderBy(orderBy...).
+		Limit(int64(s.perPage)).
+		Offset(int64(s.perPage * (s.page - 1))).
+		WithContext(info.Context)
+
+	if len(groupBy) > 0 {
+		query.GroupBy(groupBy...)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []map[string]any{}
+
+	for rows.Next() {
+		values := make([]any, len(selects))
+		pointers := make([]any, len(selects))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		item := make(map[string]any, len(keys))
+		for i, key := range keys {
+			if b, ok := values[i].([]byte); ok {
+				item[key] = string(b)
+			} else {
+				item[key] = values[i]
+			}
+
+			if transform, ok := transforms[key]; ok {
+				item[key] = transform(item[key])
+			}
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Result{
 		Page:       s.page,
 		PerPage:    s.perPage,
-		TotalItems: totalCount,
-		TotalPages: totalPages,
+		TotalItems: -1,
+		TotalPages: -1,
 		Items:      items,
+	}, nil
+}
+
+// aggregateColumnsResolver resolves the aggregate field names
+// into their already resolved distinct rows query columns.
+type aggregateColumnsResolver map[string]string
+
+// UpdateQuery implements the [FieldResolver] interface.
+func (r aggregateColumnsResolver) UpdateQuery(query *dbx.SelectQuery) error {
+	return nil
+}
+
+// Resolve implements the [FieldResolver] interface.
+func (r aggregateColumnsResolver) Resolve(field string) (*ResolverResult, error) {
+	column, ok := r[field]
+	if !ok {
+		return nil, fmt.Errorf("unresolved aggregate field %q", field)
 	}
 
-	return result, nil
+	return &ResolverResult{Identifier: column}, nil
 }
 
 // ParseAndExec is a short convenient method to trigger both
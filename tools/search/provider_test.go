@@ -550,6 +550,296 @@ func TestProviderParseAndExec(t *testing.T) {
 	}
 }
 
+func TestProviderCursorPagination(t *testing.T) {
+	testDB, err := createTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+
+	// extra rows with duplicated and null sort values
+	testDB.Insert("test", dbx.Params{"id": 3, "test1": 2, "test2": "test2.3"}).Execute()
+	testDB.Insert("test", dbx.Params{"id": 4, "test1": 3, "test2": "test2.4"}).Execute()
+	testDB.Insert("test", dbx.Params{"id": 5, "test1": nil, "test2": "test2.5"}).Execute()
+
+	type item struct {
+		Id    int  `db:"id" json:"id"`
+		Test1 *int `db:"test1" json:"test1"`
+	}
+
+	type page struct {
+		Items      []item `json:"items"`
+		NextCursor string `json:"nextCursor"`
+		PrevCursor string `json:"prevCursor"`
+	}
+
+	fetch := func(queryString string) (*page, error) {
+		result, err := NewProvider(&testFieldResolver{}).
+			Query(testDB.Select("*").From("test")).
+			PerPage(2).
+			ParseAndExec(queryString, &[]item{})
+		if err != nil {
+			return nil, err
+		}
+
+		raw, _ := json.Marshal(result)
+		p := &page{}
+		if err := json.Unmarshal(raw, p); err != nil {
+			return nil, err
+		}
+
+		return p, nil
+	}
+
+	ids := func(p *page) string {
+		result := ""
+		for _, item := range p.Items {
+			result += fmt.Sprintf("%d,", item.Id)
+		}
+		return result
+	}
+
+	// forward
+	// ---
+	page1, err := fetch("sort=-test1&after=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ids(page1); v != "4,2," || page1.NextCursor == "" || page1.PrevCursor != "" {
+		t.Fatalf("Unexpected page1 %s %#v", v, page1)
+	}
+
+	page2, err := fetch("sort=-test1&after=" + page1.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ids(page2); v != "3,1," || page2.NextCursor == "" || page2.PrevCursor == "" {
+		t.Fatalf("Unexpected page2 %s %#v", v, page2)
+	}
+
+	page3, err := fetch("sort=-test1&after=" + page2.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ids(page3); v != "5," || page3.NextCursor != "" || page3.PrevCursor == "" {
+		t.Fatalf("Unexpected page3 %s %#v", v, page3)
+	}
+
+	// backward
+	// ---
+	prev2, err := fetch("sort=-test1&before=" + page3.PrevCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ids(prev2); v != "3,1," || prev2.NextCursor == "" || prev2.PrevCursor == "" {
+		t.Fatalf("Unexpected prev2 %s %#v", v, prev2)
+	}
+
+	prev1, err := fetch("sort=-test1&before=" + prev2.PrevCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ids(prev1); v != "4,2," || prev1.NextCursor == "" || prev1.PrevCursor != "" {
+		t.Fatalf("Unexpected prev1 %s %#v", v, prev1)
+	}
+
+	last, err := fetch("sort=-test1&before=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ids(last); v != "1,5," || last.NextCursor != "" || last.PrevCursor == "" {
+		t.Fatalf("Unexpected last page %s %#v", v, last)
+	}
+
+	// errors
+	// ---
+	errorScenarios := []string{
+		"after=&before=",
+		"after=invalid",
+		"sort=-test1,test2&after=" + page1.NextCursor, // sort changed
+		"sort=@random&after=",
+	}
+	for _, queryString := range errorScenarios {
+		if _, err := fetch(queryString); err == nil {
+			t.Errorf("Expected error for %q", queryString)
+		}
+	}
+}
+
+func TestProviderCursorPaginationMapItems(t *testing.T) {
+	testDB, err := createTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+
+	testDB.Insert("test", dbx.Params{"id": 3, "test1": 2, "test2": "test2.3"}).Execute()
+
+	fetch := func(queryString string) (*Result, []dbx.NullStringMap, error) {
+		items := []dbx.NullStringMap{}
+
+		result, err := NewProvider(&testFieldResolver{}).
+			Query(testDB.Select("*").From("test")).
+			PerPage(2).
+			SkipTotal(true).
+			ParseAndExec(queryString, &items)
+
+		return result, items, err
+	}
+
+	testDB.CalledQueries = []string{}
+
+	page1, items1, err := fetch("sort=-test2&after=")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the cursors should be built from the models query
+	if total := len(testDB.CalledQueries); total != 1 {
+		t.Fatalf("Expected only 1 query, got %d: %v", total, testDB.CalledQueries)
+	}
+
+	if len(items1) != 2 || items1[0]["id"].String != "3" || items1[1]["id"].String != "2" {
+		t.Fatalf("Unexpected page1 items %v", items1)
+	}
+	for _, item := range items1 {
+		if _, ok := item[cursorValuesAlias]; ok {
+			t.Fatalf("Expected the sort values column to be removed from the item %v", item)
+		}
+	}
+	if page1.NextCursor == "" || page1.PrevCursor != "" {
+		t.Fatalf("Unexpected page1 cursors %#v", page1)
+	}
+
+	page2, items2, err := fetch("sort=-test2&after=" + page1.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items2) != 1 || items2[0]["id"].String != "1" {
+		t.Fatalf("Unexpected page2 items %v", items2)
+	}
+	if page2.NextCursor != "" || page2.PrevCursor == "" {
+		t.Fatalf("Unexpected page2 cursors %#v", page2)
+	}
+}
+
+func TestProviderExecAggregate(t *testing.T) {
+	testDB, err := createTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+
+	testDB.Insert("test", dbx.Params{"id": 3, "test1": 5, "test2": "test2.2"}).Execute()
+
+	scenarios := []struct {
+		name         string
+		queryString  string
+		expectError  bool
+		expectResult string
+	}{
+		{
+			"missing aggregate",
+			"groupBy=test2",
+			true,
+			"",
+		},
+		{
+			"invalid aggregate",
+			"aggregate=sum(unknown)",
+			true,
+			"",
+		},
+		{
+			"invalid group by field",
+			"aggregate=count()&groupBy=unknown",
+			true,
+			"",
+		},
+		{
+			"invalid sort field (not grouped)",
+			"aggregate=count()&groupBy=test2&sort=test1",
+			true,
+			"",
+		},
+		{
+			"without group by",
+			"aggregate=count(),sum(test1),max(test1)",
+			false,
+			`{"page":1,"perPage":30,"totalItems":-1,"totalPages":-1,"items":[{"count":3,"max_test1":5,"sum_test1":8}]}`,
+		},
+		{
+			"with group by and filter",
+			"aggregate=count(),avg(test1)&groupBy=test2&filter=test1>0",
+			false,
+			`{"page":1,"perPage":30,"totalItems":-1,"totalPages":-1,"items":[{"avg_test1":1,"count":1,"test2":"test2.1"},{"avg_test1":3.5,"count":2,"test2":"test2.2"}]}`,
+		},
+		{
+			"with aggregate sort and pagination",
+			"aggregate=count()&groupBy=test2&sort=-count()&perPage=1",
+			false,
+			`{"page":1,"perPage":1,"totalItems":-1,"totalPages":-1,"items":[{"count":2,"test2":"test2.2"}]}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		provider := NewProvider(&testFieldResolver{}).
+			Query(testDB.Select("*").From("test"))
+
+		var result *Result
+		err := provider.Parse(s.queryString)
+		if err == nil {
+			result, err = provider.ExecAggregate()
+		}
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.name, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		encoded, _ := json.Marshal(result)
+		if string(encoded) != s.expectResult {
+			t.Errorf("[%s] Expected result %v, got \n%v", s.name, s.expectResult, string(encoded))
+		}
+	}
+}
+
+func TestProviderExecAggregateWithValueTransform(t *testing.T) {
+	testDB, err := createTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+
+	resolver := NewSimpleFieldResolver("test1")
+
+	provider := NewProvider(&testAggregateTransformResolver{resolver}).
+		Query(testDB.Select("*").From("test")).
+		Aggregate([]AggregateField{
+			{Func: AggregateSum, Field: "test1"},
+			{Func: AggregateMax, Field: "test1"},
+			{Func: AggregateAvg, Field: "test1"},
+			{Func: AggregateCount, Field: "test1"},
+		})
+
+	result, err := provider.ExecAggregate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"page":1,"perPage":30,"totalItems":-1,"totalPages":-1,"items":[{"avg_test1":15,"count_test1":2,"max_test1":"raw:2","sum_test1":"raw:3"}]}`
+
+	encoded, _ := json.Marshal(result)
+	if string(encoded) != expected {
+		t.Fatalf("Expected result %v, got \n%v", expected, string(encoded))
+	}
+}
+
 // -------------------------------------------------------------------
 // Helpers
 // -------------------------------------------------------------------
@@ -606,3 +896,23 @@ func (t *testFieldResolver) Resolve(field string) (*ResolverResult, error) {
 
 	return &ResolverResult{Identifier: field}, nil
 }
+
+// testAggregateTransformResolver resolves the fields with a tenfold
+// value fallback and a raw aggregate values transform.
+type testAggregateTransformResolver struct {
+	*SimpleFieldResolver
+}
+
+func (r *testAggregateTransformResolver) Resolve(field string) (*ResolverResult, error) {
+	result, err := r.SimpleFieldResolver.Resolve(field)
+	if err != nil {
+		return nil, err
+	}
+
+	result.ValueFallback = &ResolverResult{Identifier: "(" + result.Identifier + " * 10)"}
+	result.AggregateValueTransform = func(value any) any {
+		return fmt.Sprintf("raw:%v", value)
+	}
+
+	return result, nil
+}
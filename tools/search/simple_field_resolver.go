@@ -31,6 +31,31 @@ type ResolverResult struct {
 	// AfterBuild is an optional function that will be called after building
 	// and combining the result of both resolved operands/sides in a single expression.
 	AfterBuild func(expr dbx.Expression) dbx.Expression
+
+	// EqualityValueTransform is an optional function that restricts the
+	// identifier to equality comparisons (=, !=, ?=, ?!=) with literal values
+	// and transforms the other operand params values (eg. to a blind index hash).
+	//
+	// Results with value transform can't be used for sorting.
+	EqualityValueTransform func(value any) any
+
+	// ValueTransform is an optional function that transforms the other
+	// operand literal value when compared with the identifier
+	// (eg. a formatted sequence value to its stored raw number).
+	//
+	// The like comparisons, the comparisons with non-literal operands
+	// and the aggregations use the ValueFallback result instead.
+	ValueTransform func(value any) any
+
+	// ValueFallback is the optional result that replaces the current one
+	// in the expressions that don't support its ValueTransform.
+	ValueFallback *ResolverResult
+
+	// AggregateValueTransform is an optional function that allows the
+	// sum, min and max aggregations to be computed on the identifier
+	// (instead of the ValueFallback) by transforming their result
+	// (eg. a decimal stored integer sum to its exact decimal value).
+	AggregateValueTransform func(value any) any
 }
 
 // FieldResolver defines an interface for managing search fields.
@@ -26,14 +26,45 @@ func (s *SortField) BuildExpr(fieldResolver FieldResolver) (string, error) {
 		return "RANDOM()", nil
 	}
 
-	result, err := fieldResolver.Resolve(s.Name)
+	identifier, err := s.resolveIdentifier(fieldResolver)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s %s", identifier, s.Direction), nil
+}
+
+// resolveIdentifier resolves the sort field name into a db identifier.
+func (s *SortField) resolveIdentifier(fieldResolver FieldResolver) (string, error) {
+	result, err := s.resolve(fieldResolver)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Identifier, nil
+}
+
+// resolve resolves the sort field name into a plain column resolver result.
+func (s *SortField) resolve(fieldResolver FieldResolver) (*ResolverResult, error) {
+	name := s.Name
+
+	// replace the supported function calls (eg. geoDistance) with placeholder identifiers
+	name, calls, err := extractFunctionCalls(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sort field %q - %w", s.Name, err)
+	}
+	if len(calls) > 0 {
+		fieldResolver = &functionsResolver{FieldResolver: fieldResolver, calls: calls}
+	}
+
+	result, err := fieldResolver.Resolve(strings.TrimSpace(name))
 
 	// invalidate empty fields and non-column identifiers
-	if err != nil || len(result.Params) > 0 || result.Identifier == "" || strings.ToLower(result.Identifier) == "null" {
-		return "", fmt.Errorf("invalid sort field %q", s.Name)
+	if err != nil || len(result.Params) > 0 || result.EqualityValueTransform != nil || result.Identifier == "" || strings.ToLower(result.Identifier) == "null" {
+		return nil, fmt.Errorf("invalid sort field %q", s.Name)
 	}
 
-	return fmt.Sprintf("%s %s", result.Identifier, s.Direction), nil
+	return result, nil
 }
 
 // ParseSortFromString parses the provided string expression
@@ -41,9 +72,9 @@ func (s *SortField) BuildExpr(fieldResolver FieldResolver) (string, error) {
 //
 // Example:
 //
-//	fields := search.ParseSortFromString("-name,+created")
+//	fields := search.ParseSortFromString("-name,+created,geoDistance(location,23.32,42.69)")
 func ParseSortFromString(str string) (fields []SortField) {
-	data := strings.Split(str, ",")
+	data := splitTopLevel(str, ',')
 
 	for _, field := range data {
 		// trim whitespaces
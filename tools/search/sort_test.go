@@ -29,6 +29,12 @@ func TestSortFieldBuildExpr(t *testing.T) {
 		{search.SortField{"test1", search.SortDesc}, false, "[[test1]] DESC"},
 		// special @random field (ignore direction)
 		{search.SortField{"@random", search.SortDesc}, false, "RANDOM()"},
+		// unknown function field
+		{search.SortField{"geoDistance(unknown,1,2)", search.SortAsc}, true, ""},
+		// function with params
+		{search.SortField{"geoDistance(test1,'1',2)", search.SortAsc}, true, ""},
+		// function with inlined number arguments
+		{search.SortField{"geoDistance(test1, 23.32, -42.5)", search.SortDesc}, false, "geo_distance(json_extract([[test1]], '$.lon'), json_extract([[test1]], '$.lat'), 23.32, -42.5) DESC"},
 	}
 
 	for i, s := range scenarios {
@@ -57,6 +63,7 @@ func TestParseSortFromString(t *testing.T) {
 		{"-test", `[{"name":"test","direction":"DESC"}]`},
 		{"test1,-test2,+test3", `[{"name":"test1","direction":"ASC"},{"name":"test2","direction":"DESC"},{"name":"test3","direction":"ASC"}]`},
 		{"@random,-test", `[{"name":"@random","direction":"ASC"},{"name":"test","direction":"DESC"}]`},
+		{"-geoDistance(test1, 1, 2),test2", `[{"name":"geoDistance(test1, 1, 2)","direction":"DESC"},{"name":"test2","direction":"ASC"}]`},
 	}
 
 	for i, s := range scenarios {
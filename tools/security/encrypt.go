@@ -5,6 +5,7 @@ import (
 	"crypto/cipher"
 	crand "crypto/rand"
 	"encoding/base64"
+	"errors"
 	"io"
 )
 
@@ -53,6 +54,10 @@ func Decrypt(cipherText string, key string) ([]byte, error) {
 		return nil, err
 	}
 
+	if len(cipherByte) < nonceSize {
+		return nil, errors.New("cipher text is too short")
+	}
+
 	nonce, cipherByteClean := cipherByte[:nonceSize], cipherByte[nonceSize:]
 	return gcm.Open(nil, nonce, cipherByteClean, nil)
 }
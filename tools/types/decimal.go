@@ -0,0 +1,331 @@
+package types
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// maxDecimalExponent is the max allowed absolute exponent
+// of a parsed decimal string (eg. 1e1000).
+const maxDecimalExponent = 1000
+
+// Decimal defines an exact base 10 decimal number represented by an
+// arbitrary precision unscaled integer and a scale (aka. number of
+// fractional digits), eg. 12.50 is represented as 1250 with scale 2.
+//
+// The zero value is a valid 0 decimal and the arithmetic
+// methods always return a new Decimal instance.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal creates a new Decimal from the provided
+// unscaled integer and scale (eg. NewDecimal(1250, 2) is 12.50).
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{unscaled: new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale))}
+	}
+
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// NewDecimalFromFloat creates a new Decimal from the shortest
+// decimal representation of the provided float64 number.
+//
+// NaN and infinite numbers result in zero Decimal.
+func NewDecimalFromFloat(f float64) Decimal {
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+
+	return d
+}
+
+// ParseDecimal parses the provided decimal number string
+// (eg. "12.50", "-0.5", "1e3") into a new Decimal.
+func ParseDecimal(str string) (Decimal, error) {
+	mantissa := strings.TrimSpace(str)
+
+	var exp int
+	if i := strings.IndexAny(mantissa, "eE"); i >= 0 {
+		e, err := strconv.Atoi(mantissa[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal exponent in %q", str)
+		}
+		mantissa, exp = mantissa[:i], e
+	}
+
+	var sign string
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		if mantissa[0] == '-' {
+			sign = "-"
+		}
+		mantissa = mantissa[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+
+	digits := intPart + fracPart
+	if digits == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", str)
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", str)
+		}
+	}
+
+	unscaled, _ := new(big.Int).SetString(sign+digits, 10)
+
+	scale := len(fracPart) - exp
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// Scale returns the number of fractional digits of the current Decimal.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Precision returns the total number of significant
+// digits of the current Decimal unscaled value.
+func (d Decimal) Precision() int {
+	if d.IsZero() {
+		return 1
+	}
+
+	return len(new(big.Int).Abs(d.unscaled).String())
+}
+
+// Sign returns -1, 0 or 1 depending on whether the current Decimal
+// is negative, zero or positive.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero checks whether the current Decimal is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares the current Decimal with other and returns
+// -1 if d < other, 0 if d == other and 1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	scale := max(d.scale, other.scale)
+
+	return d.rescale(scale).int().Cmp(other.rescale(scale).int())
+}
+
+// Add returns the exact d + other sum.
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+
+	return Decimal{
+		unscaled: new(big.Int).Add(d.rescale(scale).int(), other.rescale(scale).int()),
+		scale:    scale,
+	}
+}
+
+// Sub returns the exact d - other difference.
+func (d Decimal) Sub(other Decimal) Decimal {
+	return d.Add(other.Neg())
+}
+
+// Mul returns the exact d * other product.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{
+		unscaled: new(big.Int).Mul(d.int(), other.int()),
+		scale:    d.scale + other.scale,
+	}
+}
+
+// Neg returns the -d negation.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Round returns the current Decimal rounded half away
+// from zero to the specified number of fractional digits.
+//
+// If the current Decimal has less fractional digits,
+// the result is padded with zeros (eg. 1.5 -> 1.500).
+func (d Decimal) Round(scale int32) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+
+	if scale >= d.scale {
+		return d.rescale(scale)
+	}
+
+	divisor := pow10(d.scale - scale)
+
+	quo, rem := new(big.Int).QuoRem(d.int(), divisor, new(big.Int))
+
+	// round half away from zero
+	rem.Abs(rem).Lsh(rem, 1)
+	if rem.Cmp(divisor) >= 0 {
+		quo.Add(quo, big.NewInt(int64(d.Sign())))
+	}
+
+	return Decimal{unscaled: quo, scale: scale}
+}
+
+// ScaledInt64 returns the current Decimal rounded to the specified
+// scale and multiplied by 10^scale (eg. 12.5 with scale 2 is 1250).
+//
+// Returns an error if the result doesn't fit in int64.
+func (d Decimal) ScaledInt64(scale int32) (int64, error) {
+	unscaled := d.Round(scale).int()
+	if !unscaled.IsInt64() {
+		return 0, errors.New("the decimal value is out of the int64 range")
+	}
+
+	return unscaled.Int64(), nil
+}
+
+// Float64 returns the nearest float64 value of the current Decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+
+	return f
+}
+
+// String returns the plain (non-exponent) string representation of
+// the current Decimal with exactly Scale() fractional digits.
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+
+	if d.scale > 0 {
+		scale := int(d.scale)
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+//
+// The Decimal is serialized as json string to prevent
+// precision loss in the float based json parsers.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+//
+// Both json number and string values are supported.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	raw := string(b)
+
+	if raw == "null" {
+		*d = Decimal{}
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		raw = unquoted
+	}
+
+	return d.Scan(raw)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements [sql.Scanner] interface to scan the provided value
+// into the current Decimal instance.
+//
+// The value could be another Decimal, number or decimal number string
+// (empty string and nil are scanned as zero Decimal).
+func (d *Decimal) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Decimal{}
+	case Decimal:
+		*d = v
+	case *Decimal:
+		if v == nil {
+			*d = Decimal{}
+		} else {
+			*d = *v
+		}
+	case int:
+		*d = NewDecimal(int64(v), 0)
+	case int32:
+		*d = NewDecimal(int64(v), 0)
+	case int64:
+		*d = NewDecimal(v, 0)
+	case float32:
+		*d, _ = ParseDecimal(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		*d = NewDecimalFromFloat(v)
+	case []byte:
+		return d.Scan(string(v))
+	case string:
+		if strings.TrimSpace(v) == "" {
+			*d = Decimal{}
+			return nil
+		}
+
+		parsed, err := ParseDecimal(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+	default:
+		str, err := cast.ToStringE(v)
+		if err != nil {
+			return fmt.Errorf("unable to scan %T into Decimal: %w", value, err)
+		}
+		return d.Scan(str)
+	}
+
+	return nil
+}
+
+// int returns the unscaled value of the current Decimal
+// (nil is normalized to zero).
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+
+	return d.unscaled
+}
+
+// rescale returns the current Decimal with increased scale
+// (the scale must be greater or equal to the current one).
+func (d Decimal) rescale(scale int32) Decimal {
+	if scale <= d.scale {
+		return d
+	}
+
+	return Decimal{
+		unscaled: new(big.Int).Mul(d.int(), pow10(scale-d.scale)),
+		scale:    scale,
+	}
+}
+
+// pow10 returns 10^n as big.Int.
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
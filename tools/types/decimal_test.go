@@ -0,0 +1,334 @@
+package types_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestParseDecimal(t *testing.T) {
+	scenarios := []struct {
+		value       string
+		expectError bool
+		expected    string
+	}{
+		{"", true, "0"},
+		{"-", true, "0"},
+		{".", true, "0"},
+		{"abc", true, "0"},
+		{"1.2.3", true, "0"},
+		{"1e", true, "0"},
+		{"1e10000", true, "0"},
+		{"0", false, "0"},
+		{" 12 ", false, "12"},
+		{"+12.50", false, "12.50"},
+		{"-0.05", false, "-0.05"},
+		{".5", false, "0.5"},
+		{"5.", false, "5"},
+		{"1.5e2", false, "150"},
+		{"15e-3", false, "0.015"},
+		{"123456789012345678901234567890.123456789", false, "123456789012345678901234567890.123456789"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			d, err := types.ParseDecimal(s.value)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if v := d.String(); v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestNewDecimal(t *testing.T) {
+	scenarios := []struct {
+		unscaled int64
+		scale    int32
+		expected string
+	}{
+		{0, 0, "0"},
+		{0, 2, "0.00"},
+		{1250, 2, "12.50"},
+		{-5, 3, "-0.005"},
+		{12, -2, "1200"},
+	}
+
+	for i, s := range scenarios {
+		if v := types.NewDecimal(s.unscaled, s.scale).String(); v != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, v)
+		}
+	}
+}
+
+func TestNewDecimalFromFloat(t *testing.T) {
+	scenarios := []struct {
+		value    float64
+		expected string
+	}{
+		{0, "0"},
+		{0.1, "0.1"},
+		{-12.345, "-12.345"},
+		{1e21, "1000000000000000000000"},
+		{math.NaN(), "0"},
+		{math.Inf(1), "0"},
+	}
+
+	for i, s := range scenarios {
+		if v := types.NewDecimalFromFloat(s.value).String(); v != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, v)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, _ := types.ParseDecimal("0.1")
+	b, _ := types.ParseDecimal("0.2")
+	c, _ := types.ParseDecimal("-1.25")
+
+	scenarios := []struct {
+		name     string
+		result   types.Decimal
+		expected string
+	}{
+		{"add", a.Add(b), "0.3"},
+		{"add with different scale", a.Add(c), "-1.15"},
+		{"sub", a.Sub(b), "-0.1"},
+		{"sub negative", b.Sub(c), "1.45"},
+		{"mul", b.Mul(c), "-0.250"},
+		{"neg", c.Neg(), "1.25"},
+		{"zero value add", types.Decimal{}.Add(a), "0.1"},
+	}
+
+	for _, s := range scenarios {
+		if v := s.result.String(); v != s.expected {
+			t.Errorf("[%s] Expected %q, got %q", s.name, s.expected, v)
+		}
+	}
+
+	// ensure that the operands are not modified
+	if a.String() != "0.1" || b.String() != "0.2" || c.String() != "-1.25" {
+		t.Fatalf("Expected the operands to remain unchanged, got %s, %s, %s", a, b, c)
+	}
+}
+
+func TestDecimalCmpAndSign(t *testing.T) {
+	scenarios := []struct {
+		a            string
+		b            string
+		expectedCmp  int
+		expectedSign int
+	}{
+		{"0", "0.00", 0, 0},
+		{"1.50", "1.5", 0, 1},
+		{"1.49", "1.5", -1, 1},
+		{"-1", "-1.01", 1, -1},
+		{"10", "9.999", 1, 1},
+	}
+
+	for i, s := range scenarios {
+		a, _ := types.ParseDecimal(s.a)
+		b, _ := types.ParseDecimal(s.b)
+
+		if v := a.Cmp(b); v != s.expectedCmp {
+			t.Errorf("(%d) Expected Cmp %d, got %d", i, s.expectedCmp, v)
+		}
+
+		if v := a.Sign(); v != s.expectedSign {
+			t.Errorf("(%d) Expected Sign %d, got %d", i, s.expectedSign, v)
+		}
+
+		if v := a.IsZero(); v != (s.expectedSign == 0) {
+			t.Errorf("(%d) Expected IsZero %v, got %v", i, s.expectedSign == 0, v)
+		}
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	scenarios := []struct {
+		value    string
+		scale    int32
+		expected string
+	}{
+		{"1.5", 3, "1.500"},
+		{"1.234", 2, "1.23"},
+		{"1.235", 2, "1.24"},
+		{"-1.235", 2, "-1.24"},
+		{"-1.234", 2, "-1.23"},
+		{"0.5", 0, "1"},
+		{"-0.5", 0, "-1"},
+		{"0.49", 0, "0"},
+		{"9.999", 2, "10.00"},
+		{"12.5", -1, "13"},
+	}
+
+	for i, s := range scenarios {
+		d, _ := types.ParseDecimal(s.value)
+
+		if v := d.Round(s.scale).String(); v != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, v)
+		}
+	}
+}
+
+func TestDecimalScaledInt64(t *testing.T) {
+	scenarios := []struct {
+		value       string
+		scale       int32
+		expectError bool
+		expected    int64
+	}{
+		{"0", 2, false, 0},
+		{"12.5", 2, false, 1250},
+		{"-0.005", 2, false, -1},
+		{"123456789012345678901", 0, true, 0},
+	}
+
+	for i, s := range scenarios {
+		d, _ := types.ParseDecimal(s.value)
+
+		result, err := d.ScaledInt64(s.scale)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if result != s.expected {
+			t.Errorf("(%d) Expected %d, got %d", i, s.expected, result)
+		}
+	}
+}
+
+func TestDecimalPrecisionAndScale(t *testing.T) {
+	scenarios := []struct {
+		value             string
+		expectedPrecision int
+		expectedScale     int32
+	}{
+		{"0", 1, 0},
+		{"0.05", 1, 2},
+		{"-123.450", 6, 3},
+	}
+
+	for i, s := range scenarios {
+		d, _ := types.ParseDecimal(s.value)
+
+		if v := d.Precision(); v != s.expectedPrecision {
+			t.Errorf("(%d) Expected precision %d, got %d", i, s.expectedPrecision, v)
+		}
+
+		if v := d.Scale(); v != s.expectedScale {
+			t.Errorf("(%d) Expected scale %d, got %d", i, s.expectedScale, v)
+		}
+	}
+}
+
+func TestDecimalFloat64(t *testing.T) {
+	d, _ := types.ParseDecimal("-12.50")
+
+	if v := d.Float64(); v != -12.5 {
+		t.Fatalf("Expected %v, got %v", -12.5, v)
+	}
+}
+
+func TestDecimalJson(t *testing.T) {
+	scenarios := []struct {
+		json        string
+		expectError bool
+		expected    string
+	}{
+		{`null`, false, `"0"`},
+		{`""`, false, `"0"`},
+		{`"abc"`, true, `"0"`},
+		{`true`, true, `"0"`},
+		{`12.50`, false, `"12.50"`},
+		{`"-0.10"`, false, `"-0.10"`},
+		{`1e2`, false, `"100"`},
+		{`123456789012345678901234567890.5`, false, `"123456789012345678901234567890.5"`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.json, func(t *testing.T) {
+			d := types.Decimal{}
+
+			err := json.Unmarshal([]byte(s.json), &d)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			raw, err := json.Marshal(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, raw)
+			}
+		})
+	}
+}
+
+func TestDecimalValue(t *testing.T) {
+	d, _ := types.ParseDecimal("12.50")
+
+	v, err := d.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != "12.50" {
+		t.Fatalf("Expected %q, got %v", "12.50", v)
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	existing, _ := types.ParseDecimal("1.5")
+
+	scenarios := []struct {
+		value       any
+		expectError bool
+		expected    string
+	}{
+		{nil, false, "0"},
+		{"", false, "0"},
+		{"invalid", true, "0"},
+		{[]byte("-1.25"), false, "-1.25"},
+		{"12.50", false, "12.50"},
+		{12, false, "12"},
+		{int64(-12), false, "-12"},
+		{float32(0.1), false, "0.1"},
+		{0.25, false, "0.25"},
+		{json.Number("3.14"), false, "3.14"},
+		{existing, false, "1.5"},
+		{&existing, false, "1.5"},
+		{(*types.Decimal)(nil), false, "0"},
+		{true, true, "0"},
+	}
+
+	for i, s := range scenarios {
+		d := types.Decimal{}
+
+		err := d.Scan(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if v := d.String(); v != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, v)
+		}
+	}
+}
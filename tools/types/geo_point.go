@@ -0,0 +1,75 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// GeoPoint defines a geographic coordinates point that is safe
+// for json and db read/write (stored as `{"lon":0,"lat":0}` json object).
+type GeoPoint struct {
+	Lon float64 `form:"lon" json:"lon"`
+	Lat float64 `form:"lat" json:"lat"`
+}
+
+// IsZero checks whether the current GeoPoint has zero coordinates.
+func (p GeoPoint) IsZero() bool {
+	return p.Lon == 0 && p.Lat == 0
+}
+
+// String returns the json serialized representation of the current GeoPoint.
+func (p GeoPoint) String() string {
+	raw, _ := json.Marshal(p)
+
+	return string(raw)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (p GeoPoint) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan implements [sql.Scanner] interface to scan the provided value
+// into the current GeoPoint instance.
+//
+// The value could be another GeoPoint, serialized json object string
+// or any other value that could be serialized as json object (eg. map).
+func (p *GeoPoint) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		// no cast needed
+	case GeoPoint:
+		*p = v
+		return nil
+	case *GeoPoint:
+		if v != nil {
+			*p = *v
+		}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("Failed to marshal GeoPoint value: %w", err)
+		}
+		data = raw
+	}
+
+	if len(data) == 0 {
+		*p = GeoPoint{}
+		return nil
+	}
+
+	point := GeoPoint{}
+	if err := json.Unmarshal(data, &point); err != nil {
+		return fmt.Errorf("Failed to unmarshal GeoPoint value: %w", err)
+	}
+	*p = point
+
+	return nil
+}
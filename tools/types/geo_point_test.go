@@ -0,0 +1,82 @@
+package types_test
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestGeoPointIsZero(t *testing.T) {
+	scenarios := []struct {
+		point    types.GeoPoint
+		expected bool
+	}{
+		{types.GeoPoint{}, true},
+		{types.GeoPoint{Lon: 1}, false},
+		{types.GeoPoint{Lat: 1}, false},
+		{types.GeoPoint{Lon: -1, Lat: 1}, false},
+	}
+
+	for i, s := range scenarios {
+		if result := s.point.IsZero(); result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestGeoPointValue(t *testing.T) {
+	scenarios := []struct {
+		point    types.GeoPoint
+		expected driver.Value
+	}{
+		{types.GeoPoint{}, `{"lon":0,"lat":0}`},
+		{types.GeoPoint{Lon: 23.32, Lat: -42.7}, `{"lon":23.32,"lat":-42.7}`},
+	}
+
+	for i, s := range scenarios {
+		result, err := s.point.Value()
+		if err != nil {
+			t.Errorf("(%d) %v", i, err)
+			continue
+		}
+
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestGeoPointScan(t *testing.T) {
+	scenarios := []struct {
+		value       any
+		expectError bool
+		expected    string
+	}{
+		{nil, false, `{"lon":0,"lat":0}`},
+		{"", false, `{"lon":0,"lat":0}`},
+		{"invalid", true, `{"lon":0,"lat":0}`},
+		{`{"lon":"invalid"}`, true, `{"lon":0,"lat":0}`},
+		{`{"lon":1.5,"lat":-2}`, false, `{"lon":1.5,"lat":-2}`},
+		{[]byte(`{"lat":3}`), false, `{"lon":0,"lat":3}`},
+		{map[string]any{"lon": 4, "lat": 5}, false, `{"lon":4,"lat":5}`},
+		{types.GeoPoint{Lon: 6, Lat: 7}, false, `{"lon":6,"lat":7}`},
+		{&types.GeoPoint{Lon: 8, Lat: 9}, false, `{"lon":8,"lat":9}`},
+	}
+
+	for i, s := range scenarios {
+		point := types.GeoPoint{}
+
+		err := point.Scan(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if point.String() != s.expected {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expected, point.String())
+		}
+	}
+}